@@ -0,0 +1,495 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package block
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	eventIndexKeyMeta     = 'm'
+	eventIndexKeyGen      = 'g'
+	eventIndexKeyBuilding = 'b'
+	eventIndexKeyCount    = 'c'
+	eventIndexKeyEntry    = 'e'
+)
+
+const (
+	flagEventIndexLock = "eventIndexLock"
+
+	// eventIndexRemoveSize is the number of entries removed at once on
+	// removing a generation.
+	eventIndexRemoveSize = 1000
+
+	// eventIndexCatchUpSize is the number of missing blocks indexed at once
+	// on Update.
+	eventIndexCatchUpSize = 100
+)
+
+// EventIndexEntry locates the transaction emitting the event.
+// Height is the height of the block including the transaction.
+type EventIndexEntry struct {
+	Height  int64
+	TxIndex int
+}
+
+// eventIndexMeta is the range of the heights covered by the entries of
+// the generation. Entries of a new generation are written by the builder,
+// and the previous generation is removed on commit. So stale generations are
+// never referenced.
+type eventIndexMeta struct {
+	Gen  int32
+	From int64
+	To   int64
+}
+
+// EventIndex maps the score address and the signature of the event to
+// the transactions emitting the event.
+type EventIndex struct {
+	lock *sync.RWMutex
+	raw  db.Bucket
+	bk   *db.CodedBucket
+}
+
+// AttachEventIndexLock attaches the lock shared by the event indexes of the
+// database. It serializes updates of the index by the block manager and the
+// builder.
+func AttachEventIndexLock(dbase db.Database) db.Database {
+	return db.WithFlags(dbase, db.Flags{
+		flagEventIndexLock: new(sync.RWMutex),
+	})
+}
+
+func eventIndexLockOf(dbase db.Database) *sync.RWMutex {
+	if lock, ok := db.GetFlag(dbase, flagEventIndexLock).(*sync.RWMutex); ok {
+		return lock
+	}
+	return new(sync.RWMutex)
+}
+
+func NewEventIndex(dbase db.Database) (*EventIndex, error) {
+	raw, err := dbase.GetBucket(db.EventIndex)
+	if err != nil {
+		return nil, err
+	}
+	bk, err := db.NewCodedBucket(dbase, db.EventIndex, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EventIndex{
+		lock: eventIndexLockOf(dbase),
+		raw:  raw,
+		bk:   bk,
+	}, nil
+}
+
+func eventIndexKeyOf(addr module.Address, sig []byte) []byte {
+	return crypto.SHA3Sum256(append(addr.Bytes(), sig...))
+}
+
+func eventIndexCountKey(gen int32, key []byte) []byte {
+	bs := make([]byte, 5, 5+len(key))
+	bs[0] = eventIndexKeyCount
+	binary.BigEndian.PutUint32(bs[1:], uint32(gen))
+	return append(bs, key...)
+}
+
+func eventIndexGenPrefix(prefix byte, gen int32) []byte {
+	bs := make([]byte, 5)
+	bs[0] = prefix
+	binary.BigEndian.PutUint32(bs[1:], uint32(gen))
+	return bs
+}
+
+func eventIndexEntryKey(gen int32, key []byte, idx int32) []byte {
+	bs := make([]byte, 9+len(key))
+	bs[0] = eventIndexKeyEntry
+	binary.BigEndian.PutUint32(bs[1:], uint32(gen))
+	copy(bs[5:], key)
+	binary.BigEndian.PutUint32(bs[5+len(key):], uint32(idx))
+	return bs
+}
+
+func (idx *EventIndex) meta() (*eventIndexMeta, error) {
+	m := new(eventIndexMeta)
+	if err := idx.bk.Get(db.Raw{eventIndexKeyMeta}, m); err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func (idx *EventIndex) count(gen int32, key []byte) (int32, error) {
+	var cnt int32
+	if err := idx.bk.Get(db.Raw(eventIndexCountKey(gen, key)), &cnt); err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return cnt, nil
+}
+
+func (idx *EventIndex) entry(gen int32, key []byte, i int32) (*EventIndexEntry, error) {
+	e := new(EventIndexEntry)
+	if err := idx.bk.Get(db.Raw(eventIndexEntryKey(gen, key, i)), e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// newGeneration returns the meta of a new generation. It should be called
+// with the lock.
+func (idx *EventIndex) newGeneration(from int64) (*eventIndexMeta, error) {
+	gen := int32(-1)
+	if err := idx.bk.Get(db.Raw{eventIndexKeyGen}, &gen); err != nil {
+		if !errors.NotFoundError.Equals(err) {
+			return nil, err
+		}
+	}
+	gen += 1
+	if err := idx.bk.Set(db.Raw{eventIndexKeyGen}, gen); err != nil {
+		return nil, err
+	}
+	return &eventIndexMeta{Gen: gen, From: from, To: from - 1}, nil
+}
+
+// removeGeneration removes entries of the generation. Generations are never
+// reused, so it doesn't need the lock if the generation is not referenced.
+func (idx *EventIndex) removeGeneration(gen int32) error {
+	for _, prefix := range []byte{eventIndexKeyCount, eventIndexKeyEntry} {
+		for {
+			var keys [][]byte
+			err := db.Iterate(idx.raw, eventIndexGenPrefix(prefix, gen), nil,
+				func(key []byte, value []byte) bool {
+					keys = append(keys, append([]byte{}, key...))
+					return len(keys) < eventIndexRemoveSize
+				})
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				if err := idx.raw.Delete(key); err != nil {
+					return err
+				}
+			}
+			if len(keys) < eventIndexRemoveSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Range returns the range of the heights covered by the index.
+// It returns to less than from if nothing is indexed.
+func (idx *EventIndex) Range() (int64, int64, error) {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	m, err := idx.meta()
+	if err != nil || m == nil {
+		return 0, -1, err
+	}
+	return m.From, m.To, nil
+}
+
+// Add indexes the receipts of the normal transactions in the block at the
+// height. Blocks already indexed are ignored. It fails without changes if
+// the block doesn't follow the last indexed block.
+func (idx *EventIndex) Add(height int64, rl module.ReceiptList) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	return idx.add(height, rl)
+}
+
+// Update indexes the receipts of the block at the height like Add. If there
+// are missing blocks before the height, it indexes them first with the
+// receipts returned by get. It indexes up to eventIndexCatchUpSize missing
+// blocks at once, then the index stays behind until following updates catch
+// up with the height.
+func (idx *EventIndex) Update(height int64, rl module.ReceiptList,
+	get func(height int64) (module.ReceiptList, error)) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	m, err := idx.meta()
+	if err != nil {
+		return err
+	}
+	if m != nil {
+		next := m.To + 1
+		for i := 0; next < height && i < eventIndexCatchUpSize; i++ {
+			mrl, err := get(next)
+			if err != nil {
+				return err
+			}
+			if err := idx.add(next, mrl); err != nil {
+				return err
+			}
+			next++
+		}
+		if next < height {
+			return nil
+		}
+	}
+	return idx.add(height, rl)
+}
+
+// add adds entries of the block at the height. It should be called with the
+// lock.
+func (idx *EventIndex) add(height int64, rl module.ReceiptList) error {
+	m, err := idx.meta()
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		if m, err = idx.newGeneration(height); err != nil {
+			return err
+		}
+	} else if height <= m.To {
+		return nil
+	} else if height != m.To+1 {
+		return errors.InvalidStateError.Errorf(
+			"EventIndexGap(height=%d,next=%d)", height, m.To+1)
+	}
+	if err := idx.addEntries(m.Gen, height, rl); err != nil {
+		return err
+	}
+	m.To = height
+	return idx.bk.Set(db.Raw{eventIndexKeyMeta}, m)
+}
+
+func (idx *EventIndex) addEntries(gen int32, height int64, rl module.ReceiptList) error {
+	counts := make(map[string]int32)
+	for it, i := rl.Iterator(), 0; it.Has(); log.Must(it.Next()) {
+		r, err := it.Get()
+		if err != nil {
+			return err
+		}
+		keys := make(map[string]bool)
+		for eit := r.EventLogIterator(); eit.Has(); log.Must(eit.Next()) {
+			el, err := eit.Get()
+			if err != nil {
+				return err
+			}
+			if len(el.Indexed()) == 0 {
+				continue
+			}
+			keys[string(eventIndexKeyOf(el.Address(), el.Indexed()[0]))] = true
+		}
+		for k := range keys {
+			key := []byte(k)
+			cnt, ok := counts[k]
+			if !ok {
+				if cnt, err = idx.count(gen, key); err != nil {
+					return err
+				}
+			}
+			e := &EventIndexEntry{Height: height, TxIndex: i}
+			if err := idx.bk.Set(db.Raw(eventIndexEntryKey(gen, key, cnt)), e); err != nil {
+				return err
+			}
+			counts[k] = cnt + 1
+		}
+		i++
+	}
+	for k, cnt := range counts {
+		if err := idx.bk.Set(db.Raw(eventIndexCountKey(gen, []byte(k))), cnt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lowerBound returns the index of the first entry at the height or above.
+func (idx *EventIndex) lowerBound(gen int32, key []byte, cnt int32, height int64) (int32, error) {
+	low, high := int32(0), cnt
+	for low < high {
+		mid := low + (high-low)/2
+		e, err := idx.entry(gen, key, mid)
+		if err != nil {
+			return 0, err
+		}
+		if e.Height < height {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
+
+// Find calls v for the transactions in the blocks from the height from to
+// the height to emitting the event of the score. Iteration stops if v
+// returns false. Heights out of the range of the index are ignored.
+func (idx *EventIndex) Find(addr module.Address, sig string, from, to int64, v func(e *EventIndexEntry) bool) error {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	m, err := idx.meta()
+	if err != nil || m == nil {
+		return err
+	}
+	if to > m.To {
+		to = m.To
+	}
+	key := eventIndexKeyOf(addr, []byte(sig))
+	cnt, err := idx.count(m.Gen, key)
+	if err != nil {
+		return err
+	}
+	i, err := idx.lowerBound(m.Gen, key, cnt, from)
+	if err != nil {
+		return err
+	}
+	for ; i < cnt; i++ {
+		e, err := idx.entry(m.Gen, key, i)
+		if err != nil {
+			return err
+		}
+		if e.Height > to || !v(e) {
+			break
+		}
+	}
+	return nil
+}
+
+// Next returns the lowest height of the block at the height or above
+// including the transaction emitting the event of the score. It returns -1
+// if there is no such block in the range of the index.
+func (idx *EventIndex) Next(addr module.Address, sig string, height int64) (int64, error) {
+	next := int64(-1)
+	_, to, err := idx.Range()
+	if err != nil {
+		return -1, err
+	}
+	err = idx.Find(addr, sig, height, to, func(e *EventIndexEntry) bool {
+		next = e.Height
+		return false
+	})
+	return next, err
+}
+
+// EventIndexBuilder builds new entries of the index from the height.
+// Entries are referenced after Commit. Only one builder is expected at a
+// time, entries of the builder not committed nor discarded are removed by
+// the next builder.
+type EventIndexBuilder struct {
+	idx  *EventIndex
+	meta *eventIndexMeta
+}
+
+func (idx *EventIndex) NewBuilder(from int64) (*EventIndexBuilder, error) {
+	var building int32
+	if err := idx.bk.Get(db.Raw{eventIndexKeyBuilding}, &building); err == nil {
+		if err := idx.removeGeneration(building); err != nil {
+			return nil, err
+		}
+	} else if !errors.NotFoundError.Equals(err) {
+		return nil, err
+	}
+
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	m, err := idx.newGeneration(from)
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.bk.Set(db.Raw{eventIndexKeyBuilding}, m.Gen); err != nil {
+		return nil, err
+	}
+	return &EventIndexBuilder{idx: idx, meta: m}, nil
+}
+
+// Add indexes the receipts of the normal transactions in the block at the
+// height. Blocks shall be added in order of the height.
+func (b *EventIndexBuilder) Add(height int64, rl module.ReceiptList) error {
+	if b.meta.To+1 != height {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidHeight(height=%d,exp=%d)", height, b.meta.To+1)
+	}
+	if err := b.idx.addEntries(b.meta.Gen, height, rl); err != nil {
+		return err
+	}
+	b.meta.To = height
+	return nil
+}
+
+// Commit makes the index refer to the entries of the builder, then it
+// removes the previous entries. Blocks indexed by Add after the last block of
+// the builder are added to the builder with the receipts returned by get
+// before the switch, so that they are not lost.
+func (b *EventIndexBuilder) Commit(get func(height int64) (module.ReceiptList, error)) error {
+	stale, err := b.commit(get)
+	if err != nil {
+		return err
+	}
+	if stale != nil {
+		return b.idx.removeGeneration(stale.Gen)
+	}
+	return nil
+}
+
+func (b *EventIndexBuilder) commit(get func(height int64) (module.ReceiptList, error)) (*eventIndexMeta, error) {
+	idx := b.idx
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	m, err := idx.meta()
+	if err != nil {
+		return nil, err
+	}
+	if m != nil {
+		for height := b.meta.To + 1; height <= m.To; height++ {
+			rl, err := get(height)
+			if err != nil {
+				return nil, err
+			}
+			if err := idx.addEntries(b.meta.Gen, height, rl); err != nil {
+				return nil, err
+			}
+			b.meta.To = height
+		}
+	}
+	if err := idx.bk.Set(db.Raw{eventIndexKeyMeta}, b.meta); err != nil {
+		return nil, err
+	}
+	if err := idx.raw.Delete([]byte{eventIndexKeyBuilding}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Discard removes the entries of the builder.
+func (b *EventIndexBuilder) Discard() error {
+	if err := b.idx.removeGeneration(b.meta.Gen); err != nil {
+		return err
+	}
+	return b.idx.raw.Delete([]byte{eventIndexKeyBuilding})
+}
@@ -0,0 +1,233 @@
+package block
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+const testEventSig = "Transfer(Address,int)"
+
+func newTestReceiptList(dbase db.Database, logs ...[]module.Address) module.ReceiptList {
+	var rs []txresult.Receipt
+	for _, addrs := range logs {
+		r := txresult.NewReceipt(dbase, module.LatestRevision, nil)
+		for _, addr := range addrs {
+			r.AddLog(addr, [][]byte{[]byte(testEventSig)}, nil)
+		}
+		r.SetResult(module.StatusSuccess, big.NewInt(0), big.NewInt(0), nil)
+		rs = append(rs, r)
+	}
+	return txresult.NewReceiptListFromSlice(dbase, rs)
+}
+
+func findAll(t *testing.T, idx *EventIndex, addr module.Address, from, to int64) []EventIndexEntry {
+	var entries []EventIndexEntry
+	err := idx.Find(addr, testEventSig, from, to, func(e *EventIndexEntry) bool {
+		entries = append(entries, *e)
+		return true
+	})
+	assert.NoError(t, err)
+	return entries
+}
+
+func TestEventIndex_Basic(t *testing.T) {
+	score1 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	score2 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+	dbase := db.NewMapDB()
+	idx, err := NewEventIndex(dbase)
+	assert.NoError(t, err)
+
+	from, to, err := idx.Range()
+	assert.NoError(t, err)
+	assert.True(t, to < from)
+
+	assert.NoError(t, idx.Add(10, newTestReceiptList(dbase,
+		[]module.Address{score1, score1}, []module.Address{score2})))
+	assert.NoError(t, idx.Add(11, newTestReceiptList(dbase,
+		[]module.Address{})))
+	assert.NoError(t, idx.Add(12, newTestReceiptList(dbase,
+		[]module.Address{score2}, []module.Address{score1})))
+
+	from, to, err = idx.Range()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, from)
+	assert.EqualValues(t, 12, to)
+
+	assert.Equal(t, []EventIndexEntry{{10, 0}, {12, 1}}, findAll(t, idx, score1, 0, 100))
+	assert.Equal(t, []EventIndexEntry{{12, 0}}, findAll(t, idx, score2, 11, 12))
+
+	next, err := idx.Next(score1, testEventSig, 11)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 12, next)
+	next, err = idx.Next(score1, testEventSig, 13)
+	assert.NoError(t, err)
+	assert.EqualValues(t, -1, next)
+
+	// discontinuous block is rejected without changes
+	assert.Error(t, idx.Add(20, newTestReceiptList(dbase,
+		[]module.Address{score1})))
+	from, to, err = idx.Range()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, from)
+	assert.EqualValues(t, 12, to)
+	assert.Equal(t, []EventIndexEntry{{10, 0}, {12, 1}}, findAll(t, idx, score1, 0, 100))
+
+	// indexed block is ignored
+	assert.NoError(t, idx.Add(11, newTestReceiptList(dbase,
+		[]module.Address{score1})))
+	assert.Equal(t, []EventIndexEntry{{10, 0}, {12, 1}}, findAll(t, idx, score1, 0, 100))
+	assert.NotZero(t, countGeneration(t, idx, 0))
+}
+
+func TestEventIndex_Update(t *testing.T) {
+	score1 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	dbase := db.NewMapDB()
+	idx, err := NewEventIndex(dbase)
+	assert.NoError(t, err)
+
+	var requested []int64
+	get := func(height int64) (module.ReceiptList, error) {
+		requested = append(requested, height)
+		return newTestReceiptList(dbase, []module.Address{score1}), nil
+	}
+
+	assert.NoError(t, idx.Update(10, newTestReceiptList(dbase,
+		[]module.Address{score1}), get))
+	assert.Empty(t, requested)
+
+	// missing blocks are indexed first
+	assert.NoError(t, idx.Update(13, newTestReceiptList(dbase,
+		[]module.Address{score1}), get))
+	assert.Equal(t, []int64{11, 12}, requested)
+	from, to, err := idx.Range()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, from)
+	assert.EqualValues(t, 13, to)
+	assert.Equal(t, []EventIndexEntry{{10, 0}, {11, 0}, {12, 0}, {13, 0}},
+		findAll(t, idx, score1, 0, 100))
+
+	// large gap is caught up by following updates
+	requested = nil
+	height := int64(15 + eventIndexCatchUpSize)
+	assert.NoError(t, idx.Update(height, newTestReceiptList(dbase,
+		[]module.Address{score1}), get))
+	assert.Len(t, requested, eventIndexCatchUpSize)
+	_, to, err = idx.Range()
+	assert.NoError(t, err)
+	assert.EqualValues(t, height-2, to)
+
+	assert.NoError(t, idx.Update(height+1, newTestReceiptList(dbase,
+		[]module.Address{score1}), get))
+	_, to, err = idx.Range()
+	assert.NoError(t, err)
+	assert.EqualValues(t, height+1, to)
+	assert.Len(t, findAll(t, idx, score1, 0, height+1), int(height+2-10))
+
+	// failure of get keeps the index
+	fail := func(height int64) (module.ReceiptList, error) {
+		return nil, errors.NotFoundError.New("NoBlock")
+	}
+	assert.Error(t, idx.Update(height+5, newTestReceiptList(dbase,
+		[]module.Address{score1}), fail))
+	_, to, err = idx.Range()
+	assert.NoError(t, err)
+	assert.EqualValues(t, height+1, to)
+}
+
+func countGeneration(t *testing.T, idx *EventIndex, gen int32) int {
+	var cnt int
+	for _, prefix := range []byte{eventIndexKeyCount, eventIndexKeyEntry} {
+		err := db.Iterate(idx.raw, eventIndexGenPrefix(prefix, gen), nil,
+			func(key []byte, value []byte) bool {
+				cnt++
+				return true
+			})
+		assert.NoError(t, err)
+	}
+	return cnt
+}
+
+func TestEventIndex_Builder(t *testing.T) {
+	score1 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	dbase := AttachEventIndexLock(db.NewMapDB())
+	idx, err := NewEventIndex(dbase)
+	assert.NoError(t, err)
+
+	assert.NoError(t, idx.Add(5, newTestReceiptList(dbase,
+		[]module.Address{score1})))
+
+	b, err := idx.NewBuilder(0)
+	assert.NoError(t, err)
+	assert.Error(t, b.Add(1, newTestReceiptList(dbase)))
+	for h := int64(0); h <= 5; h++ {
+		assert.NoError(t, b.Add(h, newTestReceiptList(dbase,
+			[]module.Address{score1})))
+	}
+
+	// entries are not referenced before commit
+	assert.Equal(t, []EventIndexEntry{{5, 0}}, findAll(t, idx, score1, 0, 100))
+
+	// blocks indexed while building are added on commit
+	idx2, err := NewEventIndex(dbase)
+	assert.NoError(t, err)
+	assert.Same(t, idx.lock, idx2.lock)
+	assert.NoError(t, idx2.Add(6, newTestReceiptList(dbase,
+		[]module.Address{score1})))
+	assert.NoError(t, idx2.Add(7, newTestReceiptList(dbase,
+		[]module.Address{score1})))
+
+	var replayed []int64
+	assert.NoError(t, b.Commit(func(height int64) (module.ReceiptList, error) {
+		replayed = append(replayed, height)
+		return newTestReceiptList(dbase, []module.Address{score1}), nil
+	}))
+	assert.Equal(t, []int64{6, 7}, replayed)
+	from, to, err := idx.Range()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, from)
+	assert.EqualValues(t, 7, to)
+	assert.Len(t, findAll(t, idx, score1, 2, 100), 6)
+
+	// entries of the previous generation are removed
+	assert.Zero(t, countGeneration(t, idx, 0))
+	assert.NotZero(t, countGeneration(t, idx, 1))
+}
+
+func TestEventIndex_Discard(t *testing.T) {
+	score1 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	dbase := db.NewMapDB()
+	idx, err := NewEventIndex(dbase)
+	assert.NoError(t, err)
+
+	assert.NoError(t, idx.Add(5, newTestReceiptList(dbase,
+		[]module.Address{score1})))
+
+	b, err := idx.NewBuilder(0)
+	assert.NoError(t, err)
+	assert.NoError(t, b.Add(0, newTestReceiptList(dbase,
+		[]module.Address{score1})))
+	assert.NotZero(t, countGeneration(t, idx, 1))
+	assert.NoError(t, b.Discard())
+	assert.Zero(t, countGeneration(t, idx, 1))
+
+	// entries of the builder left are removed by the next builder
+	b, err = idx.NewBuilder(0)
+	assert.NoError(t, err)
+	assert.NoError(t, b.Add(0, newTestReceiptList(dbase,
+		[]module.Address{score1})))
+	assert.NotZero(t, countGeneration(t, idx, 2))
+	_, err = idx.NewBuilder(0)
+	assert.NoError(t, err)
+	assert.Zero(t, countGeneration(t, idx, 2))
+
+	// the index is not changed
+	assert.Equal(t, []EventIndexEntry{{5, 0}}, findAll(t, idx, score1, 0, 100))
+}
@@ -122,6 +122,8 @@ type manager struct {
 	handlers       handlerList
 	activeHandlers handlerList
 	handlerContext handlerContext
+
+	eventIndex *EventIndex
 }
 
 type handlerList []base.BlockHandler
@@ -614,6 +616,13 @@ func NewManager(
 	m.chainContext.trtr.Logger = chain.Logger().WithFields(log.Fields{
 		log.FieldKeyModule: "BM|TRANS",
 	})
+	if chain.EventIndex() {
+		idx, err := NewEventIndex(chain.Database())
+		if err != nil {
+			return nil, err
+		}
+		m.eventIndex = idx
+	}
 	chainPropBucket, err := m.bucketFor(db.ChainProperty)
 	if err != nil {
 		return nil, err
@@ -1087,7 +1096,10 @@ func (m *manager) finalize(bn *bnode) error {
 		m.bntr.TraceRef(bn)
 	}
 
-	err = block.(base.BlockVersionSpec).FinalizeHeader(m.chain.Database())
+	// header, indexes and the last height are written at once, so that
+	// a crash can't leave the block partially finalized.
+	bdb := db.NewBatchDB(m.db())
+	err = block.(base.BlockVersionSpec).FinalizeHeader(bdb)
 	if err != nil {
 		return err
 	}
@@ -1097,20 +1109,26 @@ func (m *manager) finalize(bn *bnode) error {
 	}
 
 	if err = WriteTransactionLocators(
-		m.db(),
+		bdb,
 		block.Height(),
 		block.PatchTransactions(),
 		block.NormalTransactions(),
 	); err != nil {
 		return err
 	}
-	chainProp, err := m.bucketFor(db.ChainProperty)
+	chainProp, err := db.NewCodedBucket(bdb, db.ChainProperty, nil)
 	if err != nil {
 		return err
 	}
 	if err = chainProp.Set(db.Raw(keyLastBlockHeight), block.Height()); err != nil {
 		return err
 	}
+	if err = bdb.Write(); err != nil {
+		return err
+	}
+	if m.eventIndex != nil {
+		m.indexEvents(block)
+	}
 
 	m.log.Debugf("Finalize(%x)\n", block.ID())
 	for i := 0; i < len(m.finalizationCBs); {
@@ -1127,6 +1145,33 @@ func (m *manager) finalize(bn *bnode) error {
 	return nil
 }
 
+// indexEvents adds receipts in the result of the block to the event index.
+// The result of the block has receipts of transactions in the previous block.
+// Failure is not critical, missing blocks are indexed on following blocks.
+func (m *manager) indexEvents(blk module.Block) {
+	height := blk.Height() - 1
+	if height < m.chain.GenesisStorage().Height() {
+		return
+	}
+	rl, err := m.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+	if err == nil {
+		err = m.eventIndex.Update(height, rl, m.normalReceiptsOf)
+	}
+	if err != nil {
+		m.log.Warnf("FailToIndexEvents(height=%d,err=%+v)", height, err)
+	}
+}
+
+// normalReceiptsOf returns receipts of the normal transactions in the block
+// at the height.
+func (m *manager) normalReceiptsOf(height int64) (module.ReceiptList, error) {
+	blk, err := m.getBlockByHeight(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	return m.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+}
+
 func WriteTransactionLocators(
 	dbase db.Database,
 	height int64,
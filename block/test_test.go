@@ -43,6 +43,14 @@ func (c *testChain) MaxWaitTimeout() time.Duration {
 	return 0
 }
 
+func (c *testChain) EventIndex() bool {
+	return false
+}
+
+func (c *testChain) Archive() bool {
+	return false
+}
+
 func (c *testChain) Database() db.Database {
 	return c.database
 }
@@ -96,6 +96,7 @@ type singleChain struct {
 	logger log.Logger
 
 	regulator *regulator
+	gc        *stateGC
 
 	state      State
 	lastErr    error
@@ -201,6 +202,24 @@ func (c *singleChain) PatchTxPoolSize() int {
 	return ConfigDefaultPatchTxPoolSize
 }
 
+func (c *singleChain) TxPoolOrder() string {
+	if len(c.cfg.TxPoolOrder) > 0 {
+		return c.cfg.TxPoolOrder
+	}
+	return service.TxPoolOrderDefault
+}
+
+func (c *singleChain) TxPoolSenderLimit() int {
+	return c.cfg.TxPoolSenderLimit
+}
+
+func (c *singleChain) TxPoolEviction() string {
+	if len(c.cfg.TxPoolEviction) > 0 {
+		return c.cfg.TxPoolEviction
+	}
+	return service.TxPoolEvictionDefault
+}
+
 func (c *singleChain) MaxBlockTxBytes() int {
 	if c.cfg.MaxBlockTxBytes > 0 {
 		return c.cfg.MaxBlockTxBytes
@@ -250,6 +269,14 @@ func (c *singleChain) ValidateTxOnSend() bool {
 	return c.cfg.ValidateTxOnSend
 }
 
+func (c *singleChain) EventIndex() bool {
+	return c.cfg.EventIndex
+}
+
+func (c *singleChain) Archive() bool {
+	return c.cfg.Archive
+}
+
 func (c *singleChain) State() (string, int64, error) {
 	c.mtx.RLock()
 	defer c.mtx.RUnlock()
@@ -389,6 +416,11 @@ func (c *singleChain) prepareDatabase(chainDir string) error {
 	if err != nil {
 		return err
 	}
+	if c.gc != nil {
+		collector := db.NewCollector(cdb)
+		c.gc.setCollector(collector)
+		cdb = collector
+	}
 	if len(c.cfg.NodeCache) == 0 {
 		c.cfg.NodeCache = NodeCacheDefault
 	}
@@ -399,6 +431,10 @@ func (c *singleChain) prepareDatabase(chainDir string) error {
 	}
 	cacheDir := path.Join(chainDir, DefaultCacheDir)
 	c.database = cache.AttachManager(cdb, cacheDir, mLevel, fLevel, stores)
+	c.database = block.AttachEventIndexLock(c.database)
+	if c.gc != nil {
+		c.database = db.WithFlags(c.database, db.Flags{flagStateGC: c.gc})
+	}
 	return nil
 }
 
@@ -434,6 +470,15 @@ func (c *singleChain) _init() error {
 		c.plt = plt
 	}
 
+	if c.cfg.StateGC > 0 {
+		// archive node keeps all the states
+		if c.cfg.Archive {
+			c.logger.Warnf("StateGC is disabled on archive node")
+		} else {
+			c.gc = newStateGC(c)
+		}
+	}
+
 	if err := c.prepareDatabase(chainDir); err != nil {
 		return err
 	}
@@ -573,6 +618,10 @@ func (c *singleChain) Import(src string, height int64) error {
 }
 
 func (c *singleChain) Prune(gsfile string, dbtype string, height int64) error {
+	// archive node keeps all the states
+	if c.cfg.Archive {
+		return errors.InvalidStateError.New("PruneOnArchiveNode")
+	}
 	if dbtype == "" {
 		dbtype = c.cfg.DBType
 	}
@@ -643,8 +692,9 @@ func (c *singleChain) Term() error {
 	return nil
 }
 
-func (c *singleChain) Verify() error {
-	return errors.UnsupportedError.New("UnsupportedFeatureVerify")
+func (c *singleChain) Verify(height int64, execute bool) error {
+	task := newTaskVerify(c, height, execute)
+	return c._runTask(task, false)
 }
 
 func (c *singleChain) Reset(gs string, height int64, blockHash []byte) error {
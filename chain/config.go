@@ -20,6 +20,7 @@ const (
 	ConfigDefaultTxTimeout        = 5000 * time.Millisecond
 	ConfigDefaultChildrenLimit    = 10
 	ConfigDefaultNephewLimit      = 10
+	ConfigDefaultStateGCInterval  = 10000
 )
 
 const (
@@ -37,17 +38,25 @@ type Config struct {
 	Platform string `json:"platform,omitempty"`
 
 	// static
-	SeedAddr         string `json:"seed_addr"`
-	Role             uint   `json:"role"`
-	ConcurrencyLevel int    `json:"concurrency_level,omitempty"`
-	NormalTxPoolSize int    `json:"normal_tx_pool,omitempty"`
-	PatchTxPoolSize  int    `json:"patch_tx_pool,omitempty"`
-	MaxBlockTxBytes  int    `json:"max_block_tx_bytes,omitempty"`
-	NodeCache        string `json:"node_cache,omitempty"`
-	AutoStart        bool   `json:"auto_start,omitempty"`
-	ChildrenLimit    *int   `json:"children_limit,omitempty"`
-	NephewsLimit     *int   `json:"nephews_limit,omitempty"`
-	ValidateTxOnSend bool   `json:"validate_tx_on_send,omitempty"`
+	SeedAddr           string  `json:"seed_addr"`
+	Role               uint    `json:"role"`
+	ConcurrencyLevel   int     `json:"concurrency_level,omitempty"`
+	NormalTxPoolSize   int     `json:"normal_tx_pool,omitempty"`
+	PatchTxPoolSize    int     `json:"patch_tx_pool,omitempty"`
+	TxPoolOrder        string  `json:"tx_pool_order,omitempty"`
+	TxPoolSenderLimit  int     `json:"tx_pool_sender_limit,omitempty"`
+	TxPoolEviction     string  `json:"tx_pool_eviction,omitempty"`
+	MaxBlockTxBytes    int     `json:"max_block_tx_bytes,omitempty"`
+	NodeCache          string  `json:"node_cache,omitempty"`
+	AutoStart          bool    `json:"auto_start,omitempty"`
+	ChildrenLimit      *int    `json:"children_limit,omitempty"`
+	NephewsLimit       *int    `json:"nephews_limit,omitempty"`
+	ValidateTxOnSend   bool    `json:"validate_tx_on_send,omitempty"`
+	EventIndex         bool    `json:"event_index,omitempty"`
+	Archive            bool    `json:"archive,omitempty"`
+	StateGC            int64   `json:"state_gc,omitempty"`
+	StateGCInterval    int64   `json:"state_gc_interval,omitempty"`
+	StateGCCheckpoints []int64 `json:"state_gc_checkpoints,omitempty"`
 
 	// runtime
 	Channel        string `json:"channel"`
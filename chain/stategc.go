@@ -0,0 +1,463 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/merkle"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+const (
+	DefaultGCDir = "gc"
+
+	flagStateGC = "stateGC"
+)
+
+const (
+	gcPhaseIdle     = "idle"
+	gcPhaseMarking  = "marking"
+	gcPhaseSweeping = "sweeping"
+)
+
+// gcMarks is a database for marks of the collector. It's removed on Close.
+type gcMarks struct {
+	db.Database
+	dir string
+}
+
+func (m *gcMarks) Close() error {
+	err := m.Database.Close()
+	_ = os.RemoveAll(m.dir)
+	return err
+}
+
+// stateGC removes world states which are not kept any more from MerkleTrie
+// and BytesByHash buckets while the chain is running.
+//
+// Each cycle marks the entries of all the blocks (headers, votes,
+// validators, transactions and receipts) and the states of the last
+// blocks and the checkpoints, then it removes the entries not marked.
+// Marks of the blocks are kept across cycles, so only new blocks are
+// marked for them. Entries written during the cycle are kept by the
+// collector even though they are not marked.
+//
+// Sweeping MerkleTrie scans entries of all the buckets on the backends
+// sharing the key space among buckets, so the interval should be large
+// enough for the size of the database.
+type stateGC struct {
+	chain       *singleChain
+	keep        int64
+	interval    int64
+	checkpoints []int64
+
+	lock      sync.Mutex
+	collector *db.Collector
+	marker    *db.Marker
+	history   db.Database
+	marked    int64
+	seq       int
+	stop      chan struct{}
+	done      chan struct{}
+	stopped   int32
+
+	// progress
+	phase     string
+	detail    string
+	height    int64
+	cycles    int64
+	lastError error
+	scanned   int64
+	removed   int64
+	reclaimed int64
+	total     int64
+	totalSize int64
+}
+
+func (gc *stateGC) setCollector(c *db.Collector) {
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+	gc.collector = c
+}
+
+func (gc *stateGC) setProgress(phase, detail string) {
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+	gc.phase = phase
+	gc.detail = detail
+}
+
+func (gc *stateGC) setMarker(m *db.Marker) error {
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+	if gc._interrupted() {
+		return errors.ErrInterrupted
+	}
+	gc.marker = m
+	return nil
+}
+
+func (gc *stateGC) _interrupted() bool {
+	return atomic.LoadInt32(&gc.stopped) != 0
+}
+
+func (gc *stateGC) dir() string {
+	return path.Join(gc.chain.cfg.AbsBaseDir(), DefaultGCDir)
+}
+
+func (gc *stateGC) openMarks(name string) (db.Database, error) {
+	dir := path.Join(gc.dir(), name)
+	_ = os.RemoveAll(dir)
+	marks, err := gc.chain.openDatabase(dir, gc.chain.cfg.DBType)
+	if err != nil {
+		return nil, err
+	}
+	return &gcMarks{marks, dir}, nil
+}
+
+func closeMarks(marks db.Database) {
+	if marks != nil {
+		_ = marks.Close()
+	}
+}
+
+// Start starts tracking writes, then it runs cycles in background.
+func (gc *stateGC) Start() error {
+	_ = os.RemoveAll(gc.dir())
+	gc.seq += 1
+	marks, err := gc.openMarks(fmt.Sprintf("written%d", gc.seq))
+	if err != nil {
+		return err
+	}
+	closeMarks(gc.collector.Track(marks))
+
+	blk, err := gc.chain.bm.GetLastBlock()
+	if err != nil {
+		closeMarks(gc.collector.Track(nil))
+		return err
+	}
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+	atomic.StoreInt32(&gc.stopped, 0)
+	gc.phase, gc.detail = gcPhaseIdle, ""
+	gc.stop = make(chan struct{})
+	gc.done = make(chan struct{})
+	go gc.run(gc.stop, gc.done, blk.Height()+1)
+	return nil
+}
+
+// Stop stops the running cycle, and it releases all the marks.
+func (gc *stateGC) Stop() {
+	gc.lock.Lock()
+	done := gc.done
+	if done == nil {
+		gc.lock.Unlock()
+		return
+	}
+	gc.done = nil
+	atomic.StoreInt32(&gc.stopped, 1)
+	close(gc.stop)
+	if gc.marker != nil {
+		gc.marker.Stop()
+		gc.marker = nil
+	}
+	gc.lock.Unlock()
+
+	<-done
+	closeMarks(gc.collector.Track(nil))
+	closeMarks(gc.collector.Track(nil))
+	closeMarks(gc.history)
+	gc.history = nil
+	gc.marked = 0
+	_ = os.RemoveAll(gc.dir())
+	gc.setProgress(gcPhaseIdle, "")
+}
+
+// run runs the first cycle after a block is finalized, then it runs the
+// next cycles every interval blocks.
+func (gc *stateGC) run(stop, done chan struct{}, next int64) {
+	defer close(done)
+	logger := gc.chain.logger
+	for {
+		ch, err := gc.chain.bm.WaitForBlock(next)
+		if err != nil {
+			logger.Warnf("StateGC fail to wait block height=%d err=%+v", next, err)
+			return
+		}
+		select {
+		case <-ch:
+		case <-stop:
+			return
+		}
+		err = gc.collect()
+		if errors.InterruptedError.Equals(err) {
+			return
+		}
+		gc.lock.Lock()
+		gc.lastError = err
+		gc.lock.Unlock()
+		if err != nil {
+			logger.Warnf("StateGC fail to collect err=%+v", err)
+		}
+		gc.setProgress(gcPhaseIdle, "")
+		next = atomic.LoadInt64(&gc.height) + gc.interval
+	}
+}
+
+func (gc *stateGC) collect() error {
+	c := gc.chain
+	start := time.Now()
+
+	// The last block is got after tracking new writes. It waits for the
+	// block being finalized, so entries of the blocks after it are always
+	// written with tracking.
+	gc.seq += 1
+	marks, err := gc.openMarks(fmt.Sprintf("written%d", gc.seq))
+	if err != nil {
+		return err
+	}
+	closeMarks(gc.collector.Track(marks))
+	blk, err := c.bm.GetLastBlock()
+	if err != nil {
+		return err
+	}
+	last := blk.Height()
+	atomic.StoreInt64(&gc.height, last)
+	atomic.StoreInt64(&gc.scanned, 0)
+	atomic.StoreInt64(&gc.removed, 0)
+	atomic.StoreInt64(&gc.reclaimed, 0)
+
+	if err := gc.markBlocks(last); err != nil {
+		// marks of the blocks may be incomplete
+		closeMarks(gc.history)
+		gc.history = nil
+		gc.marked = 0
+		return err
+	}
+	states, err := gc.openMarks("states")
+	if err != nil {
+		return err
+	}
+	defer closeMarks(states)
+	if err := gc.markStates(states, last); err != nil {
+		return err
+	}
+
+	for _, id := range []db.BucketID{db.MerkleTrie, db.BytesByHash} {
+		gc.setProgress(gcPhaseSweeping, fmt.Sprintf("bucket=%q", id))
+		err := gc.collector.Sweep(id, []db.Database{gc.history, states}, gc.onSweep)
+		if err != nil {
+			return err
+		}
+	}
+	atomic.AddInt64(&gc.cycles, 1)
+	c.logger.Infof("StateGC done height=%d removed=%d reclaimed=%d elapsed=%s",
+		last, atomic.LoadInt64(&gc.removed), atomic.LoadInt64(&gc.reclaimed),
+		time.Since(start))
+	return nil
+}
+
+func (gc *stateGC) onSweep(scanned, removed, bytes int64) error {
+	atomic.AddInt64(&gc.scanned, scanned)
+	atomic.AddInt64(&gc.removed, removed)
+	atomic.AddInt64(&gc.reclaimed, bytes)
+	atomic.AddInt64(&gc.total, removed)
+	atomic.AddInt64(&gc.totalSize, bytes)
+	if gc._interrupted() {
+		return errors.ErrInterrupted
+	}
+	return nil
+}
+
+// markBlocks marks entries of the blocks up to the height, which are not
+// marked by previous cycles.
+func (gc *stateGC) markBlocks(to int64) error {
+	c := gc.chain
+	from := gc.marked + 1
+	if gc.history == nil {
+		marks, err := gc.openMarks("blocks")
+		if err != nil {
+			return err
+		}
+		gc.history = marks
+		from = c.GenesisStorage().Height()
+	}
+	marker := gc.collector.NewMarker(gc.history)
+	if err := gc.setMarker(marker); err != nil {
+		return err
+	}
+	ctx := merkle.NewCopyContext(c.Database(), marker)
+	for height := from; height <= to; height++ {
+		if gc._interrupted() {
+			return errors.ErrInterrupted
+		}
+		gc.setProgress(gcPhaseMarking, fmt.Sprintf("blocks %d/%d", height, to))
+		blk, err := c.bm.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		if err := gc.markBlock(ctx, blk); err != nil {
+			return errors.Wrapf(err, "fail to mark block height=%d", height)
+		}
+		if height == c.GenesisStorage().Height() {
+			// previous blocks of pruned genesis for validators and voters
+			if err := gc.markPrevBlocks(ctx, blk, 2); err != nil {
+				return err
+			}
+		}
+	}
+	gc.marked = to
+	return nil
+}
+
+func (gc *stateGC) markPrevBlocks(ctx *merkle.CopyContext, blk module.Block, n int) error {
+	for ; n > 0 && len(blk.PrevID()) > 0; n-- {
+		pblk, err := gc.chain.bm.GetBlock(blk.PrevID())
+		if err != nil {
+			return err
+		}
+		if err := gc.markBlock(ctx, pblk); err != nil {
+			return errors.Wrapf(err, "fail to mark block height=%d", pblk.Height())
+		}
+		blk = pblk
+	}
+	return nil
+}
+
+func (gc *stateGC) markBlock(ctx *merkle.CopyContext, blk module.Block) error {
+	transaction.NewTransactionListWithBuilder(ctx.Builder(), blk.PatchTransactions().Hash())
+	transaction.NewTransactionListWithBuilder(ctx.Builder(), blk.NormalTransactions().Hash())
+	if err := service.RequestReceipts(ctx.Builder(), blk.Result()); err != nil {
+		return err
+	}
+	if err := ctx.Run(); err != nil {
+		return err
+	}
+	if err := ctx.Copy(db.BytesByHash, blk.ID()); err != nil {
+		return err
+	}
+	if err := ctx.Copy(db.BytesByHash, blk.Votes().Hash()); err != nil {
+		return err
+	}
+	return ctx.Copy(db.BytesByHash, blk.NextValidatorsHash())
+}
+
+// statesToKeep returns heights of the states to keep.
+func (gc *stateGC) statesToKeep(last int64) []int64 {
+	genesis := gc.chain.GenesisStorage().Height()
+	var heights []int64
+	for _, h := range gc.checkpoints {
+		if h >= genesis && h <= last-gc.keep {
+			heights = append(heights, h)
+		}
+	}
+	from := last - gc.keep + 1
+	if from < genesis {
+		from = genesis
+	}
+	for h := from; h <= last; h++ {
+		heights = append(heights, h)
+	}
+	return heights
+}
+
+func (gc *stateGC) markStates(states db.Database, last int64) error {
+	c := gc.chain
+	marker := gc.collector.NewMarker(states, gc.history)
+	if err := gc.setMarker(marker); err != nil {
+		return err
+	}
+	heights := gc.statesToKeep(last)
+	for i, height := range heights {
+		if gc._interrupted() {
+			return errors.ErrInterrupted
+		}
+		gc.setProgress(gcPhaseMarking, fmt.Sprintf("states %d/%d", i+1, len(heights)))
+		blk, err := c.bm.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		err = c.sm.ExportResult(blk.Result(), blk.NextValidatorsHash(), marker)
+		if err != nil {
+			return errors.Wrapf(err, "fail to mark state height=%d", height)
+		}
+	}
+	return nil
+}
+
+func (gc *stateGC) inspect() map[string]interface{} {
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+
+	m := make(map[string]interface{})
+	m["keep"] = gc.keep
+	m["interval"] = gc.interval
+	if len(gc.checkpoints) > 0 {
+		m["checkpoints"] = gc.checkpoints
+	}
+	m["phase"] = gc.phase
+	if gc.detail != "" {
+		m["progress"] = gc.detail
+	}
+	m["height"] = atomic.LoadInt64(&gc.height)
+	m["cycles"] = atomic.LoadInt64(&gc.cycles)
+	m["scanned"] = atomic.LoadInt64(&gc.scanned)
+	m["removed"] = atomic.LoadInt64(&gc.removed)
+	m["reclaimed"] = atomic.LoadInt64(&gc.reclaimed)
+	m["totalRemoved"] = atomic.LoadInt64(&gc.total)
+	m["totalReclaimed"] = atomic.LoadInt64(&gc.totalSize)
+	if gc.lastError != nil {
+		m["lastError"] = gc.lastError.Error()
+	}
+	return m
+}
+
+func newStateGC(c *singleChain) *stateGC {
+	interval := c.cfg.StateGCInterval
+	if interval <= 0 {
+		interval = ConfigDefaultStateGCInterval
+	}
+	return &stateGC{
+		chain:       c,
+		keep:        c.cfg.StateGC,
+		interval:    interval,
+		checkpoints: c.cfg.StateGCCheckpoints,
+		phase:       gcPhaseIdle,
+	}
+}
+
+// Inspect returns the status of the chain. It includes progress of the
+// garbage collection of states if it's enabled.
+func Inspect(c module.Chain, informal bool) map[string]interface{} {
+	gc, ok := db.GetFlag(c.Database(), flagStateGC).(*stateGC)
+	if !ok {
+		return nil
+	}
+	m := make(map[string]interface{})
+	m["stateGC"] = gc.inspect()
+	return m
+}
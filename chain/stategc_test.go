@@ -0,0 +1,155 @@
+package chain
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/consensus"
+	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/test"
+)
+
+func newTestStateGC(t *testing.T, keep, interval int64) (*test.Node, *stateGC) {
+	collector := db.NewCollector(db.NewMapDB())
+	nd := test.NewNode(t, test.UseDB(collector))
+	c := &singleChain{
+		database: nd.Chain.Database(),
+		bm:       nd.BM,
+		sm:       nd.SM,
+		logger:   nd.Chain.Logger(),
+		cfg: Config{
+			DBType:          "mapdb",
+			BaseDir:         nd.Base,
+			StateGC:         keep,
+			StateGCInterval: interval,
+			GenesisStorage:  nd.Chain.GenesisStorage(),
+		},
+	}
+	gc := newStateGC(c)
+	gc.setCollector(collector)
+	return nd, gc
+}
+
+// finalizeBlocks finalizes n blocks, each of them has a transaction setting
+// the test variable to its height. It returns the ids of the transactions.
+func finalizeBlocks(nd *test.Node, n int) [][]byte {
+	var ids [][]byte
+	for i := 0; i < n; i++ {
+		value := fmt.Sprint(nd.LastBlock.Height() + 1)
+		tx := test.NewTx().SetVarTest(&value)
+		nd.ProposeFinalizeBlockWithTX(consensus.NewEmptyCommitVoteList(), tx.String())
+		ids = append(ids, tx.ID())
+	}
+	return ids
+}
+
+func assertReceipts(t *testing.T, nd *test.Node, ids [][]byte) {
+	for _, id := range ids {
+		ti, err := nd.BM.GetTransactionInfo(id)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		if _, err := ti.GetReceipt(); err != nil {
+			// receipts of the last block are not finalized yet
+			assert.Equal(t, nd.LastBlock.Height(), ti.Block().Height())
+		}
+	}
+}
+
+// assertStateOf checks that the state after the block at the height is
+// kept entirely, and it has the value set by the block.
+func assertStateOf(t *testing.T, nd *test.Node, height int64) {
+	blk, err := nd.BM.GetBlockByHeight(height + 1)
+	assert.NoError(t, err)
+	err = nd.SM.ExportResult(blk.Result(), blk.NextValidatorsHash(), db.NewMapDB())
+	assert.NoError(t, err, "height=%d", height)
+
+	ws, err := service.NewWorldSnapshot(nd.Chain.Database(), nd.Platform, blk.Result(), nil)
+	assert.NoError(t, err)
+	as := scoredb.NewStateStoreWith(ws.GetAccountSnapshot(state.SystemID))
+	assert.Equal(t, fmt.Sprint(height), scoredb.NewVarDB(as, test.VarTest).String())
+}
+
+func hasStateOf(nd *test.Node, height int64) bool {
+	blk, err := nd.BM.GetBlockByHeight(height + 1)
+	if err != nil {
+		return false
+	}
+	return service.CheckStateAvailable(nd.Chain.Database(), blk.Result()) == nil
+}
+
+func waitCycles(t *testing.T, gc *stateGC, cycles int64) {
+	for i := 0; atomic.LoadInt64(&gc.cycles) < cycles; i++ {
+		if i > 500 {
+			assert.FailNow(t, "no cycle done", "cycles=%d", atomic.LoadInt64(&gc.cycles))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStateGC_Collect(t *testing.T) {
+	nd, gc := newTestStateGC(t, 2, 1)
+	defer nd.Close()
+
+	ids := finalizeBlocks(nd, 6)
+	assert.NoError(t, gc.collect())
+	assert.EqualValues(t, 1, gc.cycles)
+	assert.NotZero(t, atomic.LoadInt64(&gc.total))
+
+	// states of the last blocks are kept, old states are removed
+	last := nd.LastBlock.Height()
+	assertStateOf(t, nd, last-2)
+	assertStateOf(t, nd, last-1)
+	assert.False(t, hasStateOf(nd, 2))
+
+	// blocks, transactions and receipts are kept
+	for h := int64(0); h <= last; h++ {
+		_, err := nd.BM.GetBlockByHeight(h)
+		assert.NoError(t, err)
+	}
+	assertReceipts(t, nd, ids)
+
+	// the chain goes on with the kept states
+	ids = append(ids, finalizeBlocks(nd, 2)...)
+	assert.NoError(t, gc.collect())
+	assertStateOf(t, nd, nd.LastBlock.Height()-1)
+	assertReceipts(t, nd, ids)
+}
+
+func TestStateGC_StartStop(t *testing.T) {
+	nd, gc := newTestStateGC(t, 2, 1)
+	defer nd.Close()
+
+	ids := finalizeBlocks(nd, 2)
+	assert.NoError(t, gc.Start())
+
+	// blocks are written while cycles are running
+	ids = append(ids, finalizeBlocks(nd, 5)...)
+	waitCycles(t, gc, 1)
+	gc.Stop()
+	assertStateOf(t, nd, nd.LastBlock.Height()-1)
+	assertReceipts(t, nd, ids)
+
+	// marks are rebuilt after restart
+	cycles := atomic.LoadInt64(&gc.cycles)
+	assert.NoError(t, gc.Start())
+	ids = append(ids, finalizeBlocks(nd, 5)...)
+	waitCycles(t, gc, cycles+1)
+	ids = append(ids, finalizeBlocks(nd, 2)...)
+	gc.Stop()
+
+	assertStateOf(t, nd, nd.LastBlock.Height()-1)
+	assertReceipts(t, nd, ids)
+	assert.False(t, hasStateOf(nd, 2))
+	for h := int64(0); h <= nd.LastBlock.Height(); h++ {
+		_, err := nd.BM.GetBlockByHeight(h)
+		assert.NoError(t, err)
+	}
+}
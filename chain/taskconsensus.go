@@ -66,11 +66,19 @@ func (t *taskConsensus) _start(c *singleChain) error {
 	if err := c.nm.Start(); err != nil {
 		return err
 	}
+	if c.gc != nil {
+		if err := c.gc.Start(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (t *taskConsensus) Stop() {
 	t.chain.srv.RemoveChain(t.chain.cfg.Channel)
+	if t.chain.gc != nil {
+		t.chain.gc.Stop()
+	}
 	t.chain.releaseManagers()
 	t.result.SetValue(errors.ErrInterrupted)
 }
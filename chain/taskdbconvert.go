@@ -0,0 +1,195 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const DBConvertTask = "db_convert"
+
+var dbConvertStates = map[State]string{
+	Starting: "db converting starting",
+	Stopping: "db converting stopping",
+	Failed:   "db converting failed",
+	Finished: "db converting done",
+}
+
+type dbConvertParams struct {
+	DBType string `json:"dbType"`
+}
+
+// taskDBConvert copies all entries of goleveldb database of the chain into
+// the pebble database, then it replaces the database of the chain with it.
+type taskDBConvert struct {
+	chain   *singleChain
+	result  resultStore
+	dbtype  string
+	entries int64
+	bytes   int64
+	stopped int32
+}
+
+func (t *taskDBConvert) String() string {
+	return fmt.Sprintf("DBConvert(dbType=%s)", t.dbtype)
+}
+
+func (t *taskDBConvert) DetailOf(s State) string {
+	switch s {
+	case Started:
+		entries := atomic.LoadInt64(&t.entries)
+		bytes := atomic.LoadInt64(&t.bytes)
+		return fmt.Sprintf("db converting entries=%d bytes=%d", entries, bytes)
+	default:
+		if st, ok := dbConvertStates[s]; ok {
+			return st
+		} else {
+			return s.String()
+		}
+	}
+}
+
+func (t *taskDBConvert) Start() error {
+	from := t.chain.cfg.DBType
+	if from != string(db.GoLevelDBBackend) || t.dbtype != string(db.PebbleDBBackend) {
+		return errors.IllegalArgumentError.Errorf(
+			"UnsupportedConversion(from=%s,to=%s)", from, t.dbtype)
+	}
+	go t.doConvert()
+	return nil
+}
+
+func (t *taskDBConvert) doConvert() {
+	err := t._convert()
+	t.result.SetValue(err)
+}
+
+func (t *taskDBConvert) OnCopy(entries, bytes int64) error {
+	if atomic.LoadInt32(&t.stopped) != 0 {
+		return errors.ErrInterrupted
+	}
+	atomic.StoreInt64(&t.entries, entries)
+	atomic.StoreInt64(&t.bytes, bytes)
+	return nil
+}
+
+func (t *taskDBConvert) _copyDatabase(src, dst string) (rerr error) {
+	c := t.chain
+	sdb, err := c.openDatabase(src, c.cfg.DBType)
+	if err != nil {
+		return err
+	}
+	defer sdb.Close()
+
+	os.RemoveAll(dst)
+	ddb, err := c.openDatabase(dst, t.dbtype)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ddb.Close()
+		if rerr != nil {
+			os.RemoveAll(dst)
+		}
+	}()
+	return ddb.(*db.PebbleDB).ImportFromGoLevelDB(sdb.(*db.GoLevelDB), t.OnCopy)
+}
+
+func (t *taskDBConvert) _convert() (rerr error) {
+	c := t.chain
+	chainDir := c.cfg.AbsBaseDir()
+	target := path.Join(chainDir, DefaultDBDir)
+	dbpath := path.Join(chainDir, DefaultTmpDBDir)
+
+	c.releaseDatabase()
+	defer c.ensureDatabase()
+
+	c.logger.Infof("Convert Database from=%s to=%s type=%s", target, dbpath, t.dbtype)
+	if err := t._copyDatabase(target, dbpath); err != nil {
+		return err
+	}
+	defer func() {
+		if rerr != nil {
+			os.RemoveAll(dbpath)
+		}
+	}()
+
+	dbbk := target + ".bk"
+	c.logger.Infof("Replace DB %s -> %s", dbpath, target)
+	os.RemoveAll(dbbk)
+	if err := os.Rename(target, dbbk); err != nil {
+		return errors.UnknownError.Errorf("fail on backup %s to %s",
+			target, dbbk)
+	}
+	defer func() {
+		if rerr != nil {
+			os.RemoveAll(target)
+			os.Rename(dbbk, target)
+		} else {
+			os.RemoveAll(dbbk)
+		}
+	}()
+	if err := os.Rename(dbpath, target); err != nil {
+		return errors.UnknownError.Errorf("fail on rename %s to %s",
+			dbpath, target)
+	}
+
+	dbtype := c.cfg.DBType
+	c.cfg.DBType = t.dbtype
+	if err := c.cfg.Save(); err != nil {
+		c.cfg.DBType = dbtype
+		return errors.UnknownError.Wrap(err, "fail to store configuration")
+	}
+	c.logger.Infof("Converted Database entries=%d bytes=%d",
+		atomic.LoadInt64(&t.entries), atomic.LoadInt64(&t.bytes))
+	return nil
+}
+
+func (t *taskDBConvert) Stop() {
+	atomic.StoreInt32(&t.stopped, 1)
+}
+
+func (t *taskDBConvert) Wait() error {
+	return t.result.Wait()
+}
+
+func taskDBConvertFactory(c *singleChain, params json.RawMessage) (chainTask, error) {
+	p := new(dbConvertParams)
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, errors.IllegalArgumentError.Wrap(err, "InvalidParams")
+		}
+	}
+	if p.DBType == "" {
+		p.DBType = string(db.PebbleDBBackend)
+	}
+	return &taskDBConvert{
+		chain:  c,
+		dbtype: p.DBType,
+	}, nil
+}
+
+func init() {
+	registerTaskFactory(DBConvertTask, taskDBConvertFactory)
+}
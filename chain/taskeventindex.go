@@ -0,0 +1,181 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+const EventIndexTask = "event_index"
+
+var eventIndexStates = map[State]string{
+	Starting: "event indexing starting",
+	Stopping: "event indexing stopping",
+	Failed:   "event indexing failed",
+	Finished: "event indexing done",
+}
+
+type eventIndexParams struct {
+	Height int64 `json:"height"`
+}
+
+type taskEventIndex struct {
+	chain   *singleChain
+	result  resultStore
+	height  int64
+	blocks  int64
+	current int64
+}
+
+func (t *taskEventIndex) String() string {
+	return fmt.Sprintf("EventIndex(height=%d)", t.height)
+}
+
+func (t *taskEventIndex) DetailOf(s State) string {
+	switch s {
+	case Started:
+		i, a := t._progress()
+		return fmt.Sprintf("event indexing %d/%d", i, a)
+	default:
+		if st, ok := eventIndexStates[s]; ok {
+			return st
+		} else {
+			return s.String()
+		}
+	}
+}
+
+func (t *taskEventIndex) Start() error {
+	if err := t.chain.prepareManagers(); err != nil {
+		return err
+	}
+	blk, err := t.chain.bm.GetLastBlock()
+	if err != nil {
+		t.chain.releaseManagers()
+		return err
+	}
+	if gh := t.chain.GenesisStorage().Height(); t.height < gh {
+		t.height = gh
+	}
+	// Receipts of the transactions in the last block are not available yet.
+	if t.height >= blk.Height() {
+		t.chain.releaseManagers()
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidHeight(height=%d,last=%d)", t.height, blk.Height())
+	}
+	t.blocks = blk.Height() - t.height
+	t.current = 0
+	go t.doIndex(blk.Height() - 1)
+	return nil
+}
+
+func (t *taskEventIndex) doIndex(to int64) {
+	err := t._index(to)
+	t.result.SetValue(err)
+}
+
+func (t *taskEventIndex) _progress() (int64, int64) {
+	blocks := atomic.LoadInt64(&t.blocks)
+	if blocks == 0 {
+		return 0, 0
+	}
+	current := atomic.LoadInt64(&t.current)
+	return current, blocks
+}
+
+func (t *taskEventIndex) _interrupted() bool {
+	return atomic.LoadInt64(&t.blocks) == 0
+}
+
+func (t *taskEventIndex) _index(to int64) (rerr error) {
+	c := t.chain
+	defer c.releaseManagers()
+
+	idx, err := block.NewEventIndex(c.Database())
+	if err != nil {
+		return err
+	}
+	b, err := idx.NewBuilder(t.height)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rerr != nil {
+			if err := b.Discard(); err != nil {
+				c.logger.Warnf("Fail to discard event index err=%+v", err)
+			}
+		}
+	}()
+	c.logger.Infof("Index events from=%d to=%d", t.height, to)
+	for h := t.height; h <= to; h++ {
+		if t._interrupted() {
+			return errors.ErrInterrupted
+		}
+		rl, err := t._receiptsOf(h)
+		if err != nil {
+			return err
+		}
+		if err := b.Add(h, rl); err != nil {
+			return err
+		}
+		atomic.StoreInt64(&t.current, h-t.height+1)
+	}
+	return b.Commit(t._receiptsOf)
+}
+
+// _receiptsOf returns receipts of the normal transactions in the block at
+// the height.
+func (t *taskEventIndex) _receiptsOf(height int64) (module.ReceiptList, error) {
+	c := t.chain
+	// receipts of the transactions are in the result of the next block
+	blk, err := c.bm.GetBlockByHeight(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	return c.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+}
+
+func (t *taskEventIndex) Stop() {
+	atomic.StoreInt64(&t.blocks, 0)
+}
+
+func (t *taskEventIndex) Wait() error {
+	return t.result.Wait()
+}
+
+func taskEventIndexFactory(c *singleChain, params json.RawMessage) (chainTask, error) {
+	p := new(eventIndexParams)
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, errors.IllegalArgumentError.Wrap(err, "InvalidParams")
+		}
+	}
+	return &taskEventIndex{
+		chain:  c,
+		height: p.Height,
+	}, nil
+}
+
+func init() {
+	registerTaskFactory(EventIndexTask, taskEventIndexFactory)
+}
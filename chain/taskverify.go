@@ -0,0 +1,382 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"bytes"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/chain/base"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+var verifyStates = map[State]string{
+	Starting: "verify starting",
+	Stopping: "verify stopping",
+	Failed:   "verify failed",
+	Finished: "verify done",
+}
+
+// taskVerify walks the blocks from the height to the last, and it checks
+// the header hash chain, commit votes, transaction lists and receipts.
+// If execute is true, it also re-executes transactions of the blocks to
+// compare the results. It fails with the first inconsistency found.
+type taskVerify struct {
+	chain   *singleChain
+	result  resultStore
+	height  int64
+	execute bool
+
+	last    int64
+	current int64
+	stop    chan struct{}
+	stopped int32
+}
+
+func (t *taskVerify) String() string {
+	return fmt.Sprintf("Verify(height=%d,execute=%v)", t.height, t.execute)
+}
+
+func (t *taskVerify) DetailOf(s State) string {
+	switch s {
+	case Started:
+		current := atomic.LoadInt64(&t.current)
+		return fmt.Sprintf("verify %d/%d", current, t.last-t.height+1)
+	default:
+		if st, ok := verifyStates[s]; ok {
+			return st
+		} else {
+			return s.String()
+		}
+	}
+}
+
+func (t *taskVerify) Start() error {
+	if err := t.chain.prepareManagers(); err != nil {
+		return err
+	}
+	blk, err := t.chain.bm.GetLastBlock()
+	if err != nil {
+		t.chain.releaseManagers()
+		return err
+	}
+	genesis := t.chain.GenesisStorage().Height()
+	if t.height == 0 {
+		t.height = genesis
+	}
+	if t.height < genesis || t.height > blk.Height() {
+		t.chain.releaseManagers()
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidHeight(height=%d,genesis=%d,last=%d)",
+			t.height, genesis, blk.Height())
+	}
+	t.last = blk.Height()
+	go t.doVerify()
+	return nil
+}
+
+func (t *taskVerify) doVerify() {
+	defer t.chain.releaseManagers()
+	err := t._verify()
+	t.result.SetValue(err)
+}
+
+func (t *taskVerify) _interrupted() bool {
+	return atomic.LoadInt32(&t.stopped) != 0
+}
+
+func inconsistency(blk module.Block, err error) error {
+	return errors.InvalidStateError.Wrapf(err,
+		"InconsistentBlock(height=%d,id=%#x)", blk.Height(), blk.ID())
+}
+
+func (t *taskVerify) _verify() error {
+	c := t.chain
+	var prev module.Block
+	if t.height > c.GenesisStorage().Height() {
+		blk, err := c.bm.GetBlockByHeight(t.height - 1)
+		if err != nil {
+			return err
+		}
+		prev = blk
+	}
+	for height := t.height; height <= t.last; height++ {
+		if t._interrupted() {
+			return errors.ErrInterrupted
+		}
+		blk, err := c.bm.GetBlockByHeight(height)
+		if err != nil {
+			return errors.InvalidStateError.Wrapf(err,
+				"InconsistentBlock(height=%d)", height)
+		}
+		if err := t._verifyBlock(blk, prev); err != nil {
+			return inconsistency(blk, err)
+		}
+		if prev != nil {
+			if err := t._verifyResult(prev, blk); err != nil {
+				return inconsistency(prev, err)
+			}
+		}
+		prev = blk
+		atomic.StoreInt64(&t.current, height-t.height+1)
+	}
+	c.logger.Infof("Verified blocks from=%d to=%d execute=%v",
+		t.height, t.last, t.execute)
+	return nil
+}
+
+// _hasVoters returns whether voters of the block at the height are
+// available. The previous block of pruned genesis isn't kept.
+func (t *taskVerify) _hasVoters(height int64) bool {
+	return height == 0 || height > t.chain.GenesisStorage().Height()
+}
+
+// _verifyBlock verifies the block with the previous block.
+func (t *taskVerify) _verifyBlock(blk, prev module.Block) error {
+	c := t.chain
+	id, err := block.GetBlockHeaderHashByHeight(c.Database(), codec.BC, blk.Height())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(id, blk.ID()) {
+		return errors.Errorf("bad header hash index=%#x calc=%#x", id, blk.ID())
+	}
+	if nvl := blk.NextValidators(); nvl != nil {
+		if !bytes.Equal(nvl.Hash(), blk.NextValidatorsHash()) {
+			return errors.Errorf("bad next validators hash=%#x calc=%#x",
+				blk.NextValidatorsHash(), nvl.Hash())
+		}
+	}
+	if err := t._verifyTransactions(blk.PatchTransactions(), blk.Version()); err != nil {
+		return errors.Wrap(err, "bad patch transactions")
+	}
+	if err := t._verifyTransactions(blk.NormalTransactions(), blk.Version()); err != nil {
+		return errors.Wrap(err, "bad normal transactions")
+	}
+	if prev == nil {
+		return nil
+	}
+	if blk.Version() != c.sm.GetNextBlockVersion(prev.Result()) {
+		return errors.Errorf("bad block version=%d exp=%d",
+			blk.Version(), c.sm.GetNextBlockVersion(prev.Result()))
+	}
+	if !bytes.Equal(blk.PrevID(), prev.ID()) {
+		return errors.Errorf("bad prev id=%#x exp=%#x", blk.PrevID(), prev.ID())
+	}
+	bvs, ok := prev.(base.BlockVersionSpec)
+	if !ok || !t._hasVoters(prev.Height()) {
+		return nil
+	}
+	voters, err := bvs.GetVoters(c.bm)
+	if err != nil {
+		return err
+	}
+	if _, err := blk.Votes().VerifyBlock(prev, voters); err != nil {
+		return errors.Wrap(err, "bad votes")
+	}
+	return nil
+}
+
+// _verifyTransactions verifies the root of the list with its transactions.
+func (t *taskVerify) _verifyTransactions(txl module.TransactionList, version int) error {
+	var txs []module.Transaction
+	for itr := txl.Iterator(); itr.Has(); itr.Next() {
+		tx, _, err := itr.Get()
+		if err != nil {
+			return err
+		}
+		txs = append(txs, tx)
+	}
+	calc := t.chain.sm.TransactionListFromSlice(txs, version).Hash()
+	if !bytes.Equal(txl.Hash(), calc) {
+		return errors.Errorf("bad transactions root=%#x calc=%#x", txl.Hash(), calc)
+	}
+	return nil
+}
+
+func transactionCountOf(txl module.TransactionList) int {
+	var cnt int
+	for itr := txl.Iterator(); itr.Has(); itr.Next() {
+		cnt++
+	}
+	return cnt
+}
+
+// userTransaction is implemented by the transactions of the users, whose
+// receipts are made for the receivers of the transactions.
+type userTransaction interface {
+	To() module.Address
+	IsSkippable() bool
+}
+
+// verifyReceipt verifies the receipt with the transaction.
+func verifyReceipt(tx module.Transaction, r module.Receipt) error {
+	if utx, ok := tx.(userTransaction); ok && utx.IsSkippable() {
+		if !r.To().Equal(utx.To()) {
+			return errors.Errorf("bad receipt tx=%#x to=%s exp=%s",
+				tx.ID(), r.To(), utx.To())
+		}
+	}
+	return nil
+}
+
+// verifyReceipts verifies the list of the receipts for the transactions.
+// Each transaction shall have its receipt, and the root of the list shall
+// be the one calculated with the receipts.
+func verifyReceipts(rl module.ReceiptList, txl module.TransactionList) error {
+	var rcts []txresult.Receipt
+	for itr := rl.Iterator(); itr.Has(); itr.Next() {
+		r, err := itr.Get()
+		if err != nil {
+			return errors.Wrapf(err, "bad receipts root=%#x", rl.Hash())
+		}
+		rct, ok := r.(txresult.Receipt)
+		if !ok {
+			return errors.Errorf("bad receipts root=%#x type=%T", rl.Hash(), r)
+		}
+		rcts = append(rcts, rct)
+	}
+	if tcnt := transactionCountOf(txl); len(rcts) != tcnt {
+		return errors.Errorf("bad receipts root=%#x receipts=%d txs=%d",
+			rl.Hash(), len(rcts), tcnt)
+	}
+	for i, itr := 0, txl.Iterator(); itr.Has(); i, _ = i+1, itr.Next() {
+		tx, _, err := itr.Get()
+		if err != nil {
+			return err
+		}
+		if err := verifyReceipt(tx, rcts[i]); err != nil {
+			return err
+		}
+	}
+	calc := txresult.NewReceiptListFromSlice(db.NewMapDB(), rcts).Hash()
+	if !bytes.Equal(rl.Hash(), calc) {
+		return errors.Errorf("bad receipts root=%#x calc=%#x", rl.Hash(), calc)
+	}
+	return nil
+}
+
+// _verifyResult verifies receipts for the transactions of the block, which
+// are in the result of the next block. Patch transactions of the next block
+// are applied with the transactions of the block.
+func (t *taskVerify) _verifyResult(blk, nblk module.Block) error {
+	sm := t.chain.sm
+	groups := []struct {
+		group module.TransactionGroup
+		txl   module.TransactionList
+	}{
+		{module.TransactionGroupPatch, nblk.PatchTransactions()},
+		{module.TransactionGroupNormal, blk.NormalTransactions()},
+	}
+	for _, g := range groups {
+		rl, err := sm.ReceiptListFromResult(nblk.Result(), g.group)
+		if err != nil {
+			return err
+		}
+		if err := verifyReceipts(rl, g.txl); err != nil {
+			return err
+		}
+	}
+	if t.execute && blk.Height() > t.chain.GenesisStorage().Height() &&
+		t._hasVoters(blk.Height()-1) {
+		return t._execute(blk, nblk)
+	}
+	return nil
+}
+
+type verifyCallback chan error
+
+func (cb verifyCallback) OnValidate(tr module.Transition, err error) {
+	if err != nil {
+		cb <- err
+	}
+}
+
+func (cb verifyCallback) OnExecute(tr module.Transition, err error) {
+	cb <- err
+}
+
+// _execute re-executes transactions of the block on the state of the
+// block, then it compares the result with the one in the next block.
+func (t *taskVerify) _execute(blk, nblk module.Block) error {
+	c := t.chain
+	csi, err := c.bm.NewConsensusInfo(blk)
+	if err != nil {
+		return err
+	}
+	itr, err := c.sm.CreateInitialTransition(blk.Result(), blk.NextValidators())
+	if err != nil {
+		return err
+	}
+	tr, err := c.sm.CreateTransition(itr, blk.NormalTransactions(), blk, csi, true)
+	if err != nil {
+		return err
+	}
+	tr = c.sm.PatchTransition(tr, nblk.PatchTransactions(), nblk)
+
+	cb := make(verifyCallback, 2)
+	canceler, err := tr.Execute(cb)
+	if err != nil {
+		return err
+	}
+	select {
+	case err := <-cb:
+		if err != nil {
+			return errors.Wrap(err, "fail to execute")
+		}
+	case <-t.stop:
+		canceler()
+		return errors.ErrInterrupted
+	}
+	if !bytes.Equal(tr.Result(), nblk.Result()) {
+		return errors.Errorf("bad result calc=%#x block=%#x", tr.Result(), nblk.Result())
+	}
+	if !tr.LogsBloom().Equal(nblk.LogsBloom()) {
+		return errors.Errorf("bad logs bloom calc=%#x block=%#x",
+			tr.LogsBloom().Bytes(), nblk.LogsBloom().Bytes())
+	}
+	if !bytes.Equal(tr.NextValidators().Hash(), nblk.NextValidatorsHash()) {
+		return errors.Errorf("bad next validators calc=%#x block=%#x",
+			tr.NextValidators().Hash(), nblk.NextValidatorsHash())
+	}
+	return nil
+}
+
+func (t *taskVerify) Stop() {
+	if atomic.CompareAndSwapInt32(&t.stopped, 0, 1) {
+		close(t.stop)
+	}
+}
+
+func (t *taskVerify) Wait() error {
+	return t.result.Wait()
+}
+
+func newTaskVerify(chain *singleChain, height int64, execute bool) chainTask {
+	return &taskVerify{
+		chain:   chain,
+		height:  height,
+		execute: execute,
+		stop:    make(chan struct{}),
+	}
+}
@@ -0,0 +1,200 @@
+package chain
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/consensus"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/transaction"
+	"github.com/icon-project/goloop/service/txresult"
+	"github.com/icon-project/goloop/test"
+)
+
+const testTxJSON = "{\"from\": \"hx54f7853dc6481b670caf69c5a27c7c8fe5be8269\", \"to\": \"hx49a23bd156932485471f582897bf1bec5f875751\", \"value\": \"0x56bc75e2d63100000\", \"fee\": \"0x2386f26fc10000\", \"nonce\": \"0x1\", \"tx_hash\": \"375540830d475a73b704cf8dee9fa9eba2798f9d2af1fa55a85482e48daefd3b\", \"signature\": \"bjarKeF3izGy469dpSciP3TT9caBQVYgHdaNgjY+8wJTOVSFm4o/ODXycFOdXUJcIwqvcE9If8x6Zmgt//XmkQE=\", \"method\": \"icx_sendTransaction\"}"
+
+func newTestReceipt(dbase db.Database, to module.Address, used int64) txresult.Receipt {
+	r := txresult.NewReceipt(dbase, module.LatestRevision, to)
+	r.SetResult(module.StatusSuccess, big.NewInt(used), big.NewInt(10), nil)
+	return r
+}
+
+func newTestReceiptList(dbase db.Database, rcts ...txresult.Receipt) module.ReceiptList {
+	rl := txresult.NewReceiptListFromSlice(dbase, rcts)
+	if err := rl.Flush(); err != nil {
+		panic(err)
+	}
+	return txresult.NewReceiptListFromHash(dbase, rl.Hash())
+}
+
+func TestVerifyReceipts(t *testing.T) {
+	dbase := db.NewMapDB()
+	tx, err := transaction.NewTransactionFromJSON([]byte(testTxJSON))
+	assert.NoError(t, err)
+	txl := transaction.NewTransactionListFromSlice(dbase, []module.Transaction{tx})
+	empty := transaction.NewTransactionListFromSlice(dbase, nil)
+
+	to := tx.To()
+	rl := newTestReceiptList(dbase, newTestReceipt(dbase, to, 100))
+	assert.NoError(t, verifyReceipts(rl, txl))
+
+	// missing or extra receipts
+	assert.Error(t, verifyReceipts(rl, empty))
+	assert.Error(t, verifyReceipts(newTestReceiptList(dbase), txl))
+
+	// receipt for another receiver
+	other := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	assert.Error(t, verifyReceipts(
+		newTestReceiptList(dbase, newTestReceipt(dbase, other, 100)), txl))
+}
+
+func TestVerifyReceipts_Corrupted(t *testing.T) {
+	dbase := db.NewMapDB()
+	tx, err := transaction.NewTransactionFromJSON([]byte(testTxJSON))
+	assert.NoError(t, err)
+	txl := transaction.NewTransactionListFromSlice(dbase, []module.Transaction{tx})
+
+	r := newTestReceipt(dbase, tx.To(), 100)
+	rl := newTestReceiptList(dbase, r)
+	assert.NoError(t, verifyReceipts(rl, txl))
+
+	// replace the stored receipt with the one of different step used
+	good := r.Bytes()
+	bad := newTestReceipt(dbase, tx.To(), 101).Bytes()
+	assert.Equal(t, len(good), len(bad))
+
+	bk, err := dbase.GetBucket(db.MerkleTrie)
+	assert.NoError(t, err)
+	var corrupted int
+	err = db.Iterate(bk, nil, nil, func(key []byte, value []byte) bool {
+		if bytes.Contains(value, good) {
+			value = bytes.Replace(value, good, bad, 1)
+			assert.NoError(t, bk.Set(key, value))
+			corrupted++
+		}
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, corrupted)
+
+	rl = txresult.NewReceiptListFromHash(dbase, rl.Hash())
+	err = verifyReceipts(rl, txl)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "calc=")
+	}
+}
+
+func newTestTaskVerify(nd *test.Node, execute bool) *taskVerify {
+	c := &singleChain{
+		database: nd.Chain.Database(),
+		bm:       nd.BM,
+		sm:       nd.SM,
+		logger:   nd.Chain.Logger(),
+		cfg: Config{
+			GenesisStorage: nd.Chain.GenesisStorage(),
+		},
+	}
+	task := newTaskVerify(c, 0, execute).(*taskVerify)
+	task.height = nd.Chain.GenesisStorage().Height()
+	task.last = nd.LastBlock.Height()
+	return task
+}
+
+func replaceBytes(t *testing.T, dbase db.Database, id db.BucketID, key []byte, f func(value []byte) []byte) {
+	bk, err := dbase.GetBucket(id)
+	assert.NoError(t, err)
+	value, err := bk.Get(key)
+	assert.NoError(t, err)
+	assert.NotNil(t, value)
+	assert.NoError(t, bk.Set(key, f(append([]byte{}, value...))))
+}
+
+// newTestVerifyChain makes 6 blocks whose votes are signed by the node
+// from the block at height 4. The block at height 5 has two transactions,
+// so the receipt list in the result of the next block isn't shared with
+// other blocks.
+func newTestVerifyChain(t *testing.T, nd *test.Node) {
+	tx := test.NewTx().SetValidatorsNode(nd)
+	nd.ProposeFinalizeBlockWithTX(consensus.NewEmptyCommitVoteList(), tx.String())
+	nd.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	nd.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	for h := 4; h <= 6; h++ {
+		txs := 1
+		if h == 5 {
+			txs = 2
+		}
+		for i := 0; i < txs; i++ {
+			value := fmt.Sprint(h, i)
+			tx := test.NewTx().SetVarTest(&value)
+			_, err := nd.SM.SendTransaction(nil, 0, tx.String())
+			assert.NoError(t, err)
+		}
+		nd.ProposeFinalizeBlock(nd.NewVoteListForLastBlock())
+	}
+}
+
+func TestTaskVerify_Chain(t *testing.T) {
+	cases := []struct {
+		name    string
+		height  int64
+		corrupt func(t *testing.T, nd *test.Node, dbase db.Database)
+	}{
+		{"Header", 4, func(t *testing.T, nd *test.Node, dbase db.Database) {
+			blk, err := nd.BM.GetBlockByHeight(4)
+			assert.NoError(t, err)
+			replaceBytes(t, dbase, db.BytesByHash, blk.ID(), func(value []byte) []byte {
+				value[len(value)-1] ^= 0x01
+				return value
+			})
+		}},
+		{"Votes", 4, func(t *testing.T, nd *test.Node, dbase db.Database) {
+			prev, err := nd.BM.GetBlockByHeight(3)
+			assert.NoError(t, err)
+			blk, err := nd.BM.GetBlockByHeight(4)
+			assert.NoError(t, err)
+			votes := consensus.NewCommitVoteList(consensus.NewPrecommitMessage(
+				wallet.New(), prev.Height(), 0, prev.ID(), nil, prev.Timestamp()+1,
+			))
+			replaceBytes(t, dbase, db.BytesByHash, blk.Votes().Hash(), func([]byte) []byte {
+				return votes.Bytes()
+			})
+		}},
+		{"Result", 5, func(t *testing.T, nd *test.Node, dbase db.Database) {
+			blk, err := nd.BM.GetBlockByHeight(6)
+			assert.NoError(t, err)
+			rl, err := nd.SM.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+			assert.NoError(t, err)
+			replaceBytes(t, dbase, db.MerkleTrie, rl.Hash(), func(value []byte) []byte {
+				value[len(value)-1] ^= 0x01
+				return value
+			})
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dbase := db.NewMapDB()
+			nd := test.NewNode(t, test.UseDB(dbase))
+			newTestVerifyChain(t, nd)
+			assert.NoError(t, newTestTaskVerify(nd, true)._verify())
+			c.corrupt(t, nd, dbase)
+			nd.Close()
+
+			// reopen to drop cached blocks
+			nd = test.NewNode(t, test.UseDB(dbase), test.UseWallet(nd.Chain.Wallet()))
+			defer nd.Close()
+			err := newTestTaskVerify(nd, true)._verify()
+			if assert.Error(t, err) {
+				assert.True(t, errors.InvalidStateError.Equals(err), "err=%+v", err)
+				assert.Contains(t, err.Error(), fmt.Sprintf("InconsistentBlock(height=%d", c.height))
+			}
+		})
+	}
+}
@@ -330,6 +330,29 @@ func (c *ClientV3) GetScoreStatus(param *v3.ScoreAddressParam) (interface{}, err
 	return result, nil
 }
 
+// GetStorageAt returns the raw value of the storage key, or nil if the key
+// doesn't exist.
+func (c *ClientV3) GetStorageAt(param *v3.StorageParam) ([]byte, error) {
+	var result *jsonrpc.HexBytes
+	_, err := c.Do("icx_getStorageAt", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	return result.Bytes(), nil
+}
+
+func (c *ClientV3) GetLogs(param *v3.LogsParam) (interface{}, error) {
+	var result interface{}
+	_, err := c.Do("icx_getLogs", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *ClientV3) MonitorBlock(param *server.BlockRequest, cb func(v *server.BlockNotification), cancelCh <-chan bool) error {
 	resp := &server.BlockNotification{}
 	return c.Monitor("/block", param, resp, func(v interface{}) {
@@ -391,7 +414,8 @@ func (c *ClientV3) Cleanup() {
 
 type wsConnectError struct {
 	error
-	httpErr error
+	httpErr    error
+	statusCode int
 }
 
 func (we *wsConnectError) Error() string {
@@ -406,7 +430,12 @@ func (c *ClientV3) wsConnect(reqUrl string, reqHeader http.Header, reqPtr interf
 	wsEndpoint := strings.Replace(c.Endpoint, "http", "ws", 1)
 	conn, httpResp, err := websocket.DefaultDialer.Dial(wsEndpoint+reqUrl, reqHeader)
 	if err != nil {
-		return nil, nil, &wsConnectError{error: err, httpErr: NewHttpError(httpResp)}
+		we := &wsConnectError{error: err}
+		if httpResp != nil {
+			we.httpErr = NewHttpError(httpResp)
+			we.statusCode = httpResp.StatusCode
+		}
+		return nil, nil, we
 	}
 
 	if err = conn.WriteJSON(reqPtr); err != nil {
@@ -463,3 +492,18 @@ func (c *ClientV3) EstimateStep(param *v3.TransactionParamForEstimate) (*common.
 	}
 	return &result, nil
 }
+
+func (c *ClientV3) SimulateTransaction(param *v3.TransactionParamForSimulate) (map[string]interface{}, error) {
+	if len(c.DebugEndPoint) == 0 {
+		return nil, errors.InvalidStateError.New("UnavailableDebugEndPoint")
+	}
+	if param.Timestamp == "" {
+		param.Timestamp = jsonrpc.HexInt(intconv.FormatInt(time.Now().UnixNano() / int64(time.Microsecond)))
+	}
+	var result map[string]interface{}
+	if _, err := c.DoURL(c.DebugEndPoint,
+		"debug_simulateTransaction", param, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
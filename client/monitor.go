@@ -0,0 +1,237 @@
+package client
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/icon-project/goloop/server"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+const (
+	DefaultMonitorMinBackoff = time.Second
+	DefaultMonitorMaxBackoff = 30 * time.Second
+)
+
+type MonitorStatus int
+
+const (
+	MonitorConnecting MonitorStatus = iota
+	MonitorConnected
+	MonitorDisconnected
+	MonitorStopped
+)
+
+func (s MonitorStatus) String() string {
+	switch s {
+	case MonitorConnecting:
+		return "connecting"
+	case MonitorConnected:
+		return "connected"
+	case MonitorDisconnected:
+		return "disconnected"
+	case MonitorStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// MonitorOptions configures reconnection of resumable monitors.
+// Backoff starts from MinBackoff and doubles up to MaxBackoff on each
+// failure. MaxRetry limits consecutive failures (0: no limit, negative:
+// no reconnection).
+// OnStatus is called with the error causing the change if exists.
+type MonitorOptions struct {
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	MaxRetry   int
+	OnStatus   func(s MonitorStatus, err error)
+}
+
+func (o *MonitorOptions) status(s MonitorStatus, err error) {
+	if o.OnStatus != nil {
+		o.OnStatus(s, err)
+	}
+}
+
+// MonitorBlockResumable monitors blocks like MonitorBlock, but it reconnects
+// on failure and resumes from the block following the last delivered one.
+// It returns nil when cancelCh is signaled.
+func (c *ClientV3) MonitorBlockResumable(param *server.BlockRequest, cb func(v *server.BlockNotification),
+	opts *MonitorOptions, cancelCh <-chan bool) error {
+	req := *param
+	next := param.Height.Value
+	return c.monitorResumable("/block", func() interface{} {
+		req.Height.Value = next
+		return &req
+	}, &server.BlockNotification{}, func(v interface{}) {
+		bn := v.(*server.BlockNotification)
+		if bn.Height.Value < next {
+			return
+		}
+		next = bn.Height.Value + 1
+		cb(bn)
+	}, opts, cancelCh)
+}
+
+// eventPosition is the position of the notification for the event.
+// Notifications for a receipt are ordered by the filter.
+type eventPosition struct {
+	height int64
+	index  int32
+	filter int32
+}
+
+func eventPositionOf(en *server.EventNotification) eventPosition {
+	pos := eventPosition{en.Height.Value, en.Index.Value, -1}
+	if en.Filter != nil {
+		pos.filter = en.Filter.Value
+	}
+	return pos
+}
+
+func (p eventPosition) After(p2 eventPosition) bool {
+	if p.height != p2.height {
+		return p.height > p2.height
+	}
+	if p.index != p2.index {
+		return p.index > p2.index
+	}
+	return p.filter > p2.filter
+}
+
+// MonitorEventResumable monitors events like MonitorEvent, but it reconnects
+// on failure and resubscribes from the block of the last delivered
+// notification skipping already delivered ones.
+// It returns nil when cancelCh is signaled.
+func (c *ClientV3) MonitorEventResumable(param *server.EventRequest, cb func(v *server.EventNotification),
+	opts *MonitorOptions, cancelCh <-chan bool) error {
+	req := *param
+	var last *eventPosition
+	return c.monitorResumable("/event", func() interface{} {
+		if last != nil {
+			req.Height.Value = last.height
+		}
+		return &req
+	}, &server.EventNotification{}, func(v interface{}) {
+		en := v.(*server.EventNotification)
+		pos := eventPositionOf(en)
+		if last != nil && !pos.After(*last) {
+			return
+		}
+		last = &pos
+		cb(en)
+	}, opts, cancelCh)
+}
+
+func (c *ClientV3) monitorResumable(reqUrl string, reqFunc func() interface{}, respPtr interface{},
+	cb func(v interface{}), opts *MonitorOptions, cancelCh <-chan bool) error {
+	var o MonitorOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = DefaultMonitorMinBackoff
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = DefaultMonitorMaxBackoff
+		if o.MaxBackoff < o.MinBackoff {
+			o.MaxBackoff = o.MinBackoff
+		}
+	}
+
+	backoff := o.MinBackoff
+	for retry := 0; ; {
+		o.status(MonitorConnecting, nil)
+		conn, wsResp, err := c.wsConnect(reqUrl, nil, reqFunc())
+		if err != nil {
+			if !isRetryable(err, wsResp) {
+				o.status(MonitorStopped, err)
+				return err
+			}
+		} else {
+			o.status(MonitorConnected, nil)
+			retry = 0
+			backoff = o.MinBackoff
+			var cancelled bool
+			if cancelled, err = c.wsReadUntilCancel(conn, respPtr, cb, cancelCh); cancelled {
+				o.status(MonitorStopped, nil)
+				return nil
+			}
+			o.status(MonitorDisconnected, err)
+		}
+		retry++
+		if o.MaxRetry != 0 && retry > o.MaxRetry {
+			o.status(MonitorStopped, err)
+			return err
+		}
+		select {
+		case <-cancelCh:
+			o.status(MonitorStopped, nil)
+			return nil
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > o.MaxBackoff {
+			backoff = o.MaxBackoff
+		}
+	}
+}
+
+// isRetryable returns whether the failure of the connection may be
+// recovered by retrying. Errors of the request (HTTP 4xx except 429, or
+// invalid request in WSResponse) aren't retryable, and others like network
+// errors, HTTP 5xx or temporary errors of the server are retryable.
+func isRetryable(err error, wsResp *server.WSResponse) bool {
+	if wsResp != nil {
+		switch jsonrpc.ErrorCode(wsResp.Code) {
+		case jsonrpc.ErrorCodeJsonParse, jsonrpc.ErrorCodeInvalidRequest,
+			jsonrpc.ErrorCodeInvalidParams, jsonrpc.ErrorCodeMethodNotFound:
+			return false
+		}
+		return true
+	}
+	if we, ok := err.(*wsConnectError); ok {
+		code := we.statusCode
+		if code >= 400 && code < 500 && code != http.StatusTooManyRequests {
+			return false
+		}
+	}
+	return true
+}
+
+// wsReadUntilCancel delivers messages until it fails to read or cancelCh
+// is signaled. It returns whether it's cancelled and the error for reading.
+func (c *ClientV3) wsReadUntilCancel(conn *websocket.Conn, respPtr interface{},
+	cb func(v interface{}), cancelCh <-chan bool) (bool, error) {
+	done := make(chan struct{})
+	cancelled := make(chan bool, 1)
+	go func() {
+		select {
+		case <-cancelCh:
+			c.wsClose(conn)
+			cancelled <- true
+		case <-done:
+			cancelled <- false
+		}
+	}()
+
+	var err error
+	elem := reflect.ValueOf(respPtr).Elem()
+	for {
+		ptr := reflect.New(elem.Type()).Interface()
+		if err = conn.ReadJSON(ptr); err != nil {
+			break
+		}
+		cb(ptr)
+	}
+	close(done)
+	if <-cancelled {
+		return true, nil
+	}
+	c.wsClose(conn)
+	return false, err
+}
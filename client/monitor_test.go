@@ -0,0 +1,249 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/server"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+// newTestWSServer returns the server sending notifications of the session
+// from sessions. Then it drops the connection.
+func newTestWSServer(t *testing.T, sessions func(n int32, req map[string]interface{}) []interface{}) *httptest.Server {
+	var count int32
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Fail to upgrade err=%+v", err)
+			return
+		}
+		defer c.Close()
+		req := make(map[string]interface{})
+		if err := c.ReadJSON(&req); err != nil {
+			return
+		}
+		if err := c.WriteJSON(&server.WSResponse{}); err != nil {
+			return
+		}
+		for _, v := range sessions(atomic.AddInt32(&count, 1)-1, req) {
+			if err := c.WriteJSON(v); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func testOptions() *MonitorOptions {
+	return &MonitorOptions{
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+	}
+}
+
+func TestClientV3_MonitorBlockResumable(t *testing.T) {
+	requested := make(chan string, 3)
+	srv := newTestWSServer(t, func(n int32, req map[string]interface{}) []interface{} {
+		requested <- req["height"].(string)
+		bn := func(h int64) *server.BlockNotification {
+			return &server.BlockNotification{Height: common.HexInt64{Value: h}}
+		}
+		switch n {
+		case 0:
+			return []interface{}{bn(1), bn(2)}
+		case 1:
+			// duplicate shall be ignored
+			return []interface{}{bn(2), bn(3)}
+		default:
+			return nil
+		}
+	})
+	defer srv.Close()
+
+	c := NewClientV3(srv.URL)
+	cancelCh := make(chan bool)
+	var heights []int64
+	var statuses []MonitorStatus
+	opts := testOptions()
+	opts.OnStatus = func(s MonitorStatus, err error) {
+		statuses = append(statuses, s)
+	}
+	err := c.MonitorBlockResumable(&server.BlockRequest{Height: common.HexInt64{Value: 1}},
+		func(v *server.BlockNotification) {
+			heights = append(heights, v.Height.Value)
+			if v.Height.Value == 3 {
+				close(cancelCh)
+			}
+		}, opts, cancelCh)
+	if err != nil {
+		t.Fatalf("Fail to monitor err=%+v", err)
+	}
+	if len(heights) != 3 || heights[0] != 1 || heights[1] != 2 || heights[2] != 3 {
+		t.Errorf("Unexpected heights %v", heights)
+	}
+	if h := <-requested; h != "0x1" {
+		t.Errorf("Unexpected first request height=%s", h)
+	}
+	if h := <-requested; h != "0x3" {
+		t.Errorf("Unexpected resumed request height=%s", h)
+	}
+	if statuses[0] != MonitorConnecting || statuses[len(statuses)-1] != MonitorStopped {
+		t.Errorf("Unexpected statuses %v", statuses)
+	}
+}
+
+func TestClientV3_MonitorEventResumable(t *testing.T) {
+	en := func(h int64, idx int32) *server.EventNotification {
+		return &server.EventNotification{
+			Height: common.HexInt64{Value: h},
+			Index:  common.HexInt32{Value: idx},
+		}
+	}
+	requested := make(chan string, 3)
+	srv := newTestWSServer(t, func(n int32, req map[string]interface{}) []interface{} {
+		requested <- req["height"].(string)
+		if n == 0 {
+			return []interface{}{en(5, 0), en(5, 2)}
+		}
+		// resumed from the block of the last notification
+		return []interface{}{en(5, 0), en(5, 2), en(5, 3), en(6, 0)}
+	})
+	defer srv.Close()
+
+	c := NewClientV3(srv.URL)
+	cancelCh := make(chan bool)
+	var positions []eventPosition
+	err := c.MonitorEventResumable(&server.EventRequest{Height: common.HexInt64{Value: 1}},
+		func(v *server.EventNotification) {
+			positions = append(positions, eventPositionOf(v))
+			if v.Height.Value == 6 {
+				close(cancelCh)
+			}
+		}, testOptions(), cancelCh)
+	if err != nil {
+		t.Fatalf("Fail to monitor err=%+v", err)
+	}
+	expected := []eventPosition{{5, 0, -1}, {5, 2, -1}, {5, 3, -1}, {6, 0, -1}}
+	if len(positions) != len(expected) {
+		t.Fatalf("Unexpected positions %v", positions)
+	}
+	for i, p := range expected {
+		if positions[i] != p {
+			t.Errorf("Unexpected position[%d]=%v expected=%v", i, positions[i], p)
+		}
+	}
+	<-requested
+	if h := <-requested; h != "0x5" {
+		t.Errorf("Unexpected resumed request height=%s", h)
+	}
+}
+
+func TestClientV3_MonitorMaxRetry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClientV3(srv.URL)
+	opts := testOptions()
+	opts.MaxRetry = 2
+	var connecting int
+	opts.OnStatus = func(s MonitorStatus, err error) {
+		if s == MonitorConnecting {
+			connecting++
+		}
+	}
+	err := c.MonitorBlockResumable(&server.BlockRequest{}, func(v *server.BlockNotification) {}, opts, nil)
+	if err == nil {
+		t.Errorf("It should fail after retries")
+	}
+	if connecting != 3 {
+		t.Errorf("Unexpected number of trials=%d", connecting)
+	}
+}
+
+func TestClientV3_MonitorRetryNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	c := NewClientV3(srv.URL)
+	opts := testOptions()
+	opts.MaxRetry = 1
+	var connecting int
+	opts.OnStatus = func(s MonitorStatus, err error) {
+		if s == MonitorConnecting {
+			connecting++
+		}
+	}
+	err := c.MonitorBlockResumable(&server.BlockRequest{}, func(v *server.BlockNotification) {}, opts, nil)
+	if err == nil {
+		t.Errorf("It should fail after retries")
+	}
+	if connecting != 2 {
+		t.Errorf("Unexpected number of trials=%d", connecting)
+	}
+}
+
+func TestClientV3_MonitorStopOnRequestError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	c := NewClientV3(srv.URL)
+	var connecting int
+	opts := testOptions()
+	opts.OnStatus = func(s MonitorStatus, err error) {
+		if s == MonitorConnecting {
+			connecting++
+		}
+	}
+	err := c.MonitorBlockResumable(&server.BlockRequest{}, func(v *server.BlockNotification) {}, opts, nil)
+	if err == nil {
+		t.Errorf("It should fail without retries")
+	}
+	if connecting != 1 {
+		t.Errorf("Unexpected number of trials=%d", connecting)
+	}
+}
+
+func TestClientV3_MonitorRetryServerError(t *testing.T) {
+	var count int32
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		req := make(map[string]interface{})
+		if err := c.ReadJSON(&req); err != nil {
+			return
+		}
+		var resp server.WSResponse
+		switch atomic.AddInt32(&count, 1) {
+		case 1:
+			resp.Code = int(jsonrpc.ErrorCodeServer)
+			resp.Message = "Stopped"
+		case 2:
+			resp.Code = int(jsonrpc.ErrorCodeInvalidParams)
+			resp.Message = "bad request"
+		}
+		c.WriteJSON(&resp)
+	}))
+	defer srv.Close()
+
+	c := NewClientV3(srv.URL)
+	err := c.MonitorBlockResumable(&server.BlockRequest{}, func(v *server.BlockNotification) {}, testOptions(), nil)
+	if err == nil {
+		t.Errorf("It should fail on the invalid request")
+	}
+	if n := atomic.LoadInt32(&count); n != 2 {
+		t.Errorf("It should retry on the server error trials=%d", n)
+	}
+}
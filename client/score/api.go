@@ -0,0 +1,400 @@
+package score
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	TypeFunction = "function"
+	TypeFallback = "fallback"
+	TypeEvent    = "eventlog"
+)
+
+// refer service/scoreapi/method.go Field.ToJSON
+type Field struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// refer service/scoreapi/method.go Method.ToJSON
+type Input struct {
+	Name    string          `json:"name"`
+	Type    string          `json:"type"`
+	Indexed string          `json:"indexed,omitempty"`
+	Default json.RawMessage `json:"default,omitempty"`
+	Fields  []Field         `json:"fields,omitempty"`
+}
+
+func (in *Input) IsIndexed() bool {
+	return in.Indexed == "0x1"
+}
+
+// IsOptional returns whether the input has default value, so it can be
+// omitted.
+func (in *Input) IsOptional() bool {
+	return in.Default != nil
+}
+
+type Output struct {
+	Type string `json:"type"`
+}
+
+type Method struct {
+	Type     string   `json:"type"`
+	Name     string   `json:"name"`
+	Inputs   []Input  `json:"inputs"`
+	Outputs  []Output `json:"outputs,omitempty"`
+	ReadOnly string   `json:"readonly,omitempty"`
+	Payable  string   `json:"payable,omitempty"`
+	Isolated string   `json:"isolated,omitempty"`
+}
+
+func (m *Method) IsReadOnly() bool {
+	return m.ReadOnly == "0x1"
+}
+
+func (m *Method) IsPayable() bool {
+	return m.Payable == "0x1"
+}
+
+func (m *Method) IsEvent() bool {
+	return m.Type == TypeEvent
+}
+
+// Signature returns the signature of the method used for the first
+// indexed value of the event logs.
+func (m *Method) Signature() string {
+	args := make([]string, len(m.Inputs))
+	for i, in := range m.Inputs {
+		args[i] = in.Type
+	}
+	return fmt.Sprintf("%s(%s)", m.Name, strings.Join(args, ","))
+}
+
+// EncodeParams returns the parameters for the method in JSON object form.
+// Values are encoded according to the type of the input. It returns error
+// for unknown or missing parameters.
+func (m *Method) EncodeParams(params map[string]interface{}) (map[string]interface{}, error) {
+	jso := make(map[string]interface{})
+	for _, in := range m.Inputs {
+		v, ok := params[in.Name]
+		if !ok {
+			if in.IsOptional() {
+				continue
+			}
+			return nil, errors.IllegalArgumentError.Errorf(
+				"MissingParam(method=%s,param=%s)", m.Name, in.Name)
+		}
+		ev, err := EncodeValue(in.Type, in.Fields, v)
+		if err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err,
+				"InvalidParam(method=%s,param=%s)", m.Name, in.Name)
+		}
+		jso[in.Name] = ev
+	}
+	if len(jso) < len(params) {
+		for name := range params {
+			if _, ok := jso[name]; !ok {
+				return nil, errors.IllegalArgumentError.Errorf(
+					"UnknownParam(method=%s,param=%s)", m.Name, name)
+			}
+		}
+	}
+	return jso, nil
+}
+
+// DecodeOutput decodes the result of the read-only call.
+func (m *Method) DecodeOutput(v interface{}) (interface{}, error) {
+	if len(m.Outputs) == 0 {
+		return v, nil
+	}
+	return DecodeValue(m.Outputs[0].Type, v)
+}
+
+type API struct {
+	Methods []*Method
+	methods map[string]*Method
+	events  map[string]*Method
+}
+
+// NewAPI returns API from the result of icx_getScoreApi.
+func NewAPI(jso []interface{}) (*API, error) {
+	bs, err := json.Marshal(jso)
+	if err != nil {
+		return nil, err
+	}
+	var methods []*Method
+	if err := json.Unmarshal(bs, &methods); err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidScoreAPI")
+	}
+	api := &API{
+		Methods: methods,
+		methods: make(map[string]*Method),
+		events:  make(map[string]*Method),
+	}
+	for _, m := range methods {
+		if m.IsEvent() {
+			api.events[m.Signature()] = m
+		} else {
+			api.methods[m.Name] = m
+		}
+	}
+	return api, nil
+}
+
+// NewAPIFromJSON returns API from the result of icx_getScoreApi in JSON.
+func NewAPIFromJSON(bs []byte) (*API, error) {
+	var jso []interface{}
+	if err := json.Unmarshal(bs, &jso); err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidScoreAPI")
+	}
+	return NewAPI(jso)
+}
+
+// Method returns the function or the fallback with the name.
+func (a *API) Method(name string) *Method {
+	return a.methods[name]
+}
+
+// EventBySignature returns the event declaration for the signature.
+func (a *API) EventBySignature(sig string) *Method {
+	return a.events[sig]
+}
+
+// Event is decoded event log.
+type Event struct {
+	Address   module.Address
+	Name      string
+	Signature string
+	Params    map[string]interface{}
+}
+
+// DecodeEvent decodes the event log with the declaration of the event.
+// Values of indexed and data are mapped to the inputs in order.
+func (a *API) DecodeEvent(addr string, indexed, data []*string) (*Event, error) {
+	if len(indexed) == 0 || indexed[0] == nil {
+		return nil, errors.IllegalArgumentError.New("NoEventSignature")
+	}
+	sig := *indexed[0]
+	m := a.EventBySignature(sig)
+	if m == nil {
+		return nil, errors.NotFoundError.Errorf("EventNotFound(sig=%s)", sig)
+	}
+	values := append(indexed[1:len(indexed):len(indexed)], data...)
+	if len(values) != len(m.Inputs) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidEventLog(sig=%s,values=%d)", sig, len(values))
+	}
+	e := &Event{
+		Name:      m.Name,
+		Signature: sig,
+		Params:    make(map[string]interface{}),
+	}
+	if addr != "" {
+		address, err := common.NewAddressFromString(addr)
+		if err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidAddress(addr=%s)", addr)
+		}
+		e.Address = address
+	}
+	for i, in := range m.Inputs {
+		if in.IsIndexed() != (i < len(indexed)-1) {
+			return nil, errors.IllegalArgumentError.Errorf(
+				"InvalidEventLog(sig=%s,indexed=%d)", sig, len(indexed)-1)
+		}
+		var v interface{}
+		if values[i] != nil {
+			v = *values[i]
+		}
+		dv, err := DecodeValue(in.Type, v)
+		if err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err,
+				"InvalidEventValue(sig=%s,param=%s)", sig, in.Name)
+		}
+		e.Params[in.Name] = dv
+	}
+	return e, nil
+}
+
+// EncodeValue encodes Go value into the JSON form of the type.
+// Integers accept signed and unsigned integers, *big.Int and
+// common.HexInt. Addresses accept module.Address and string. Bytes accept
+// []byte and hex string. Lists accept slices of acceptable values, and
+// structs accept map[string]interface{}. Nil is encoded as null.
+func EncodeValue(t string, fields []Field, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if strings.HasPrefix(t, "[]") {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, errors.IllegalArgumentError.Errorf("NotList(type=%s,value=%v)", t, v)
+		}
+		l := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			ev, err := EncodeValue(t[2:], fields, rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			l[i] = ev
+		}
+		return l, nil
+	}
+	switch t {
+	case "int":
+		return encodeInt(v)
+	case "str":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "bytes":
+		switch o := v.(type) {
+		case []byte:
+			return "0x" + hex.EncodeToString(o), nil
+		case common.HexBytes:
+			return o.String(), nil
+		case string:
+			if _, err := hex.DecodeString(strings.TrimPrefix(o, "0x")); err == nil {
+				return o, nil
+			}
+		}
+	case "bool":
+		if b, ok := v.(bool); ok {
+			if b {
+				return "0x1", nil
+			}
+			return "0x0", nil
+		}
+	case "Address":
+		switch o := v.(type) {
+		case module.Address:
+			return o.String(), nil
+		case string:
+			if _, err := common.NewAddressFromString(o); err == nil {
+				return o, nil
+			}
+		}
+	case "struct":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			break
+		}
+		jso := make(map[string]interface{})
+		for _, f := range fields {
+			fv, ok := m[f.Name]
+			if !ok {
+				return nil, errors.IllegalArgumentError.Errorf("MissingField(field=%s)", f.Name)
+			}
+			ev, err := EncodeValue(f.Type, f.Fields, fv)
+			if err != nil {
+				return nil, err
+			}
+			jso[f.Name] = ev
+		}
+		return jso, nil
+	default:
+		// dict, list and others are passed as they are.
+		return v, nil
+	}
+	return nil, errors.IllegalArgumentError.Errorf("InvalidValue(type=%s,value=%v)", t, v)
+}
+
+func encodeInt(v interface{}) (interface{}, error) {
+	var i big.Int
+	switch o := v.(type) {
+	case *big.Int:
+		i.Set(o)
+	case big.Int:
+		i.Set(&o)
+	case *common.HexInt:
+		i.Set(&o.Int)
+	case common.HexInt:
+		i.Set(&o.Int)
+	case string:
+		if _, ok := i.SetString(o, 0); !ok {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidInteger(value=%s)", o)
+		}
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i.SetInt64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			i.SetUint64(rv.Uint())
+		default:
+			return nil, errors.IllegalArgumentError.Errorf("InvalidInteger(value=%v)", v)
+		}
+	}
+	return intconv.FormatBigInt(&i), nil
+}
+
+// DecodeValue decodes the value in the JSON form of the type into Go value.
+// Integers are decoded into *big.Int, addresses into module.Address,
+// bytes into []byte, booleans into bool and strings into string.
+// Lists are decoded into []interface{}, and structs and dicts into
+// map[string]interface{} with their values left in JSON form.
+func DecodeValue(t string, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if strings.HasPrefix(t, "[]") {
+		l, ok := v.([]interface{})
+		if !ok {
+			return nil, errors.IllegalArgumentError.Errorf("NotList(type=%s,value=%v)", t, v)
+		}
+		dl := make([]interface{}, len(l))
+		for i, e := range l {
+			dv, err := DecodeValue(t[2:], e)
+			if err != nil {
+				return nil, err
+			}
+			dl[i] = dv
+		}
+		return dl, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	switch t {
+	case "int":
+		i := new(big.Int)
+		if err := intconv.ParseBigInt(i, s); err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidInteger(value=%s)", s)
+		}
+		return i, nil
+	case "bytes":
+		bs, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidBytes(value=%s)", s)
+		}
+		return bs, nil
+	case "bool":
+		switch s {
+		case "0x1":
+			return true, nil
+		case "0x0":
+			return false, nil
+		}
+		return nil, errors.IllegalArgumentError.Errorf("InvalidBool(value=%s)", s)
+	case "Address":
+		addr, err := common.NewAddressFromString(s)
+		if err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidAddress(value=%s)", s)
+		}
+		return addr, nil
+	default:
+		return s, nil
+	}
+}
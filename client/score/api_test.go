@@ -0,0 +1,118 @@
+package score
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+)
+
+const testScoreAPI = `[
+	{
+		"type": "function",
+		"name": "balanceOf",
+		"inputs": [ { "name": "_owner", "type": "Address" } ],
+		"outputs": [ { "type": "int" } ],
+		"readonly": "0x1"
+	},
+	{
+		"type": "function",
+		"name": "transfer",
+		"inputs": [
+			{ "name": "_to", "type": "Address" },
+			{ "name": "_value", "type": "int" },
+			{ "name": "_data", "type": "bytes", "default": null }
+		],
+		"outputs": []
+	},
+	{
+		"type": "eventlog",
+		"name": "Transfer",
+		"inputs": [
+			{ "name": "_from", "type": "Address", "indexed": "0x1" },
+			{ "name": "_to", "type": "Address", "indexed": "0x1" },
+			{ "name": "_value", "type": "int", "indexed": "0x1" },
+			{ "name": "_data", "type": "bytes" }
+		]
+	}
+]`
+
+func newTestAPI(t *testing.T) *API {
+	var jso []interface{}
+	assert.NoError(t, json.Unmarshal([]byte(testScoreAPI), &jso))
+	api, err := NewAPI(jso)
+	assert.NoError(t, err)
+	return api
+}
+
+func TestAPI_EncodeParams(t *testing.T) {
+	api := newTestAPI(t)
+	addr := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+
+	m := api.Method("transfer")
+	assert.NotNil(t, m)
+	assert.False(t, m.IsReadOnly())
+	assert.True(t, m.Inputs[2].IsOptional())
+
+	params, err := m.EncodeParams(map[string]interface{}{
+		"_to":    addr,
+		"_value": big.NewInt(-16),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"_to":    addr.String(),
+		"_value": "-0x10",
+	}, params)
+
+	params, err = m.EncodeParams(map[string]interface{}{
+		"_to":    addr.String(),
+		"_value": 1,
+		"_data":  []byte{0x12},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1", params["_value"])
+	assert.Equal(t, "0x12", params["_data"])
+
+	_, err = m.EncodeParams(map[string]interface{}{"_value": 1})
+	assert.Error(t, err)
+	_, err = m.EncodeParams(map[string]interface{}{"_to": addr, "_value": 1, "_x": 1})
+	assert.Error(t, err)
+	_, err = m.EncodeParams(map[string]interface{}{"_to": addr, "_value": "abc"})
+	assert.Error(t, err)
+
+	v, err := api.Method("balanceOf").DecodeOutput("0x100")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(256), v)
+}
+
+func TestAPI_DecodeEvent(t *testing.T) {
+	api := newTestAPI(t)
+	str := func(s string) *string { return &s }
+	from := "hx0000000000000000000000000000000000000001"
+	to := "cx0000000000000000000000000000000000000002"
+
+	e, err := api.DecodeEvent(to,
+		[]*string{str("Transfer(Address,Address,int,bytes)"), str(from), str(to), str("0x10")},
+		[]*string{nil})
+	assert.NoError(t, err)
+	assert.Equal(t, "Transfer", e.Name)
+	assert.True(t, e.Address.Equal(common.MustNewAddressFromString(to)))
+	assert.Equal(t, map[string]interface{}{
+		"_from":  common.MustNewAddressFromString(from),
+		"_to":    common.MustNewAddressFromString(to),
+		"_value": big.NewInt(16),
+		"_data":  nil,
+	}, e.Params)
+
+	// indexed layout mismatch
+	_, err = api.DecodeEvent(to,
+		[]*string{str("Transfer(Address,Address,int,bytes)"), str(from), str(to)},
+		[]*string{str("0x10"), nil})
+	assert.Error(t, err)
+
+	_, err = api.DecodeEvent(to, []*string{str("Unknown(int)"), str("0x1")}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,235 @@
+package score
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	v3 "github.com/icon-project/goloop/server/v3"
+)
+
+const (
+	DefaultStepMargin = 10
+)
+
+var deployAddress = jsonrpc.Address("cx0000000000000000000000000000000000000000")
+
+// Client sends transactions on top of client.ClientV3.
+// If StepLimit is zero, step limit of the transaction is estimated with
+// EstimateStep and increased by StepMargin percent.
+type Client struct {
+	*client.ClientV3
+	NID        int64
+	StepLimit  int64
+	StepMargin int64
+	nonce      int64
+}
+
+func NewClient(c *client.ClientV3, nid int64) *Client {
+	return &Client{
+		ClientV3:   c,
+		NID:        nid,
+		StepMargin: DefaultStepMargin,
+		nonce:      time.Now().UnixNano(),
+	}
+}
+
+// Score returns Score for the address with its API.
+func (c *Client) Score(addr module.Address) (*Score, error) {
+	jso, err := c.GetScoreApi(&v3.ScoreAddressParam{
+		Address: jsonrpc.Address(addr.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	api, err := NewAPI(jso)
+	if err != nil {
+		return nil, err
+	}
+	return c.NewScore(addr, api), nil
+}
+
+// NewScore returns Score for the address with the API known in advance.
+func (c *Client) NewScore(addr module.Address, api *API) *Score {
+	return &Score{
+		client:  c,
+		address: addr,
+		api:     api,
+	}
+}
+
+func (c *Client) newTransaction(w module.Wallet, to jsonrpc.Address, value *big.Int,
+	dataType string, data interface{}) *v3.TransactionParam {
+	param := &v3.TransactionParam{
+		Version:     v3.VersionValue,
+		FromAddress: jsonrpc.Address(w.Address().String()),
+		ToAddress:   to,
+		NetworkID:   jsonrpc.HexInt(intconv.FormatInt(c.NID)),
+		Nonce:       jsonrpc.HexInt(intconv.FormatInt(atomic.AddInt64(&c.nonce, 1))),
+		DataType:    dataType,
+		Data:        data,
+	}
+	if value != nil && value.Sign() != 0 {
+		param.Value = jsonrpc.HexInt(intconv.FormatBigInt(value))
+	}
+	return param
+}
+
+func (c *Client) stepLimitFor(param *v3.TransactionParam) (*big.Int, error) {
+	if c.StepLimit > 0 {
+		return big.NewInt(c.StepLimit), nil
+	}
+	step, err := c.EstimateStep(&v3.TransactionParamForEstimate{
+		Version:     param.Version,
+		FromAddress: param.FromAddress,
+		ToAddress:   param.ToAddress,
+		Value:       param.Value,
+		NetworkID:   param.NetworkID,
+		Nonce:       param.Nonce,
+		DataType:    param.DataType,
+		Data:        param.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	limit := new(big.Int).Mul(step.Value(), big.NewInt(100+c.StepMargin))
+	return limit.Div(limit, big.NewInt(100)), nil
+}
+
+// Send fills step limit of the transaction, signs and sends it with the
+// wallet. Then it waits for the result of the transaction.
+func (c *Client) Send(w module.Wallet, param *v3.TransactionParam) (*client.TransactionResult, error) {
+	if param.StepLimit == "" {
+		limit, err := c.stepLimitFor(param)
+		if err != nil {
+			return nil, err
+		}
+		param.StepLimit = jsonrpc.HexInt(intconv.FormatBigInt(limit))
+	}
+	txHash, err := c.SendTransaction(w, param)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitTransactionResult(&v3.TransactionHashParam{Hash: *txHash})
+}
+
+// Deploy deploys the content. If addr is nil, it installs new SCORE,
+// otherwise, it updates the SCORE at addr.
+func (c *Client) Deploy(w module.Wallet, addr module.Address, contentType string,
+	content []byte, params map[string]interface{}) (*client.TransactionResult, error) {
+	data := map[string]interface{}{
+		"contentType": contentType,
+		"content":     "0x" + hex.EncodeToString(content),
+	}
+	if len(params) > 0 {
+		data["params"] = params
+	}
+	to := deployAddress
+	if addr != nil {
+		to = jsonrpc.Address(addr.String())
+	}
+	return c.Send(w, c.newTransaction(w, to, nil, "deploy", data))
+}
+
+// Score is the handle to call the methods of the SCORE with its API.
+type Score struct {
+	client  *Client
+	address module.Address
+	api     *API
+}
+
+func (s *Score) Address() module.Address {
+	return s.address
+}
+
+func (s *Score) API() *API {
+	return s.api
+}
+
+func (s *Score) callData(method string, params map[string]interface{}) (*Method, map[string]interface{}, error) {
+	m := s.api.Method(method)
+	if m == nil {
+		return nil, nil, errors.NotFoundError.Errorf("MethodNotFound(method=%s)", method)
+	}
+	data := map[string]interface{}{"method": method}
+	if p, err := m.EncodeParams(params); err != nil {
+		return nil, nil, err
+	} else if len(p) > 0 {
+		data["params"] = p
+	}
+	return m, data, nil
+}
+
+// Call calls the read-only method and returns the result decoded with
+// the output type of the method.
+func (s *Score) Call(method string, params map[string]interface{}) (interface{}, error) {
+	m, data, err := s.callData(method, params)
+	if err != nil {
+		return nil, err
+	}
+	if !m.IsReadOnly() {
+		return nil, errors.IllegalArgumentError.Errorf("NotReadOnly(method=%s)", method)
+	}
+	ret, err := s.client.ClientV3.Call(&v3.CallParam{
+		ToAddress: jsonrpc.Address(s.address.String()),
+		DataType:  "call",
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.DecodeOutput(ret)
+}
+
+// Invoke sends the transaction calling the method with value and waits
+// for the result.
+func (s *Score) Invoke(w module.Wallet, method string, params map[string]interface{},
+	value *big.Int) (*client.TransactionResult, error) {
+	m, data, err := s.callData(method, params)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil && value.Sign() != 0 && !m.IsPayable() {
+		return nil, errors.IllegalArgumentError.Errorf("NotPayable(method=%s)", method)
+	}
+	param := s.client.newTransaction(w, jsonrpc.Address(s.address.String()), value, "call", data)
+	return s.client.Send(w, param)
+}
+
+// Transfer sends value to the SCORE, which is handled by its fallback,
+// and waits for the result.
+func (s *Score) Transfer(w module.Wallet, value *big.Int) (*client.TransactionResult, error) {
+	param := s.client.newTransaction(w, jsonrpc.Address(s.address.String()), value, "", nil)
+	return s.client.Send(w, param)
+}
+
+// DecodeEventLog decodes the event log of the SCORE.
+func (s *Score) DecodeEventLog(el *client.EventLog) (*Event, error) {
+	if string(el.Addr) != s.address.String() {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"AddressMismatch(exp=%s,real=%s)", s.address, el.Addr)
+	}
+	return s.api.DecodeEvent(string(el.Addr), el.Indexed, el.Data)
+}
+
+// Events returns decoded event logs of the SCORE in the result.
+func (s *Score) Events(r *client.TransactionResult) ([]*Event, error) {
+	var events []*Event
+	for i := range r.EventLogs {
+		if string(r.EventLogs[i].Addr) != s.address.String() {
+			continue
+		}
+		e, err := s.DecodeEventLog(&r.EventLogs[i])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
@@ -0,0 +1,265 @@
+package score
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+const testTxHash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+
+// testNode serves the methods of JSON-RPC used by Client, and it keeps
+// parameters of the requests.
+type testNode struct {
+	*httptest.Server
+	lock     sync.Mutex
+	estimate string
+	errors   map[string]*jsonrpc.Error
+	requests map[string][]map[string]interface{}
+}
+
+func newTestNode(t *testing.T) *testNode {
+	n := &testNode{
+		estimate: "0x64",
+		errors:   make(map[string]*jsonrpc.Error),
+		requests: make(map[string][]map[string]interface{}),
+	}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{}            `json:"id"`
+			Method string                 `json:"method"`
+			Params map[string]interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Fail to decode request err=%+v", err)
+			return
+		}
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		if req.Method == "debug_estimateStep" && !strings.HasSuffix(r.URL.Path, "/v3d") {
+			t.Errorf("Estimation on wrong endpoint path=%s", r.URL.Path)
+		}
+		n.requests[req.Method] = append(n.requests[req.Method], req.Params)
+
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+		}
+		if e, ok := n.errors[req.Method]; ok {
+			resp["error"] = e
+		} else {
+			switch req.Method {
+			case "debug_estimateStep":
+				resp["result"] = n.estimate
+			case "icx_sendTransaction":
+				resp["result"] = testTxHash
+			case "icx_waitTransactionResult":
+				resp["result"] = map[string]interface{}{
+					"status": "0x1",
+					"txHash": testTxHash,
+				}
+			default:
+				resp["error"] = jsonrpc.ErrorCodeMethodNotFound.New(req.Method)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	return n
+}
+
+func (n *testNode) requestsOf(method string) []map[string]interface{} {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.requests[method]
+}
+
+func (n *testNode) newClient() *Client {
+	return NewClient(client.NewClientV3(n.URL+"/api/v3"), 3)
+}
+
+func TestClient_StepLimit(t *testing.T) {
+	n := newTestNode(t)
+	defer n.Close()
+	c := n.newClient()
+	w := wallet.New()
+	to := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+
+	// estimated step is increased by the margin
+	_, err := c.Send(w, c.newTransaction(w, jsonrpc.Address(to.String()), nil, "", nil))
+	assert.NoError(t, err)
+	sent := n.requestsOf("icx_sendTransaction")
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, "0x6e", sent[0]["stepLimit"])
+	}
+
+	c.StepMargin = 0
+	_, err = c.Send(w, c.newTransaction(w, jsonrpc.Address(to.String()), nil, "", nil))
+	assert.NoError(t, err)
+	sent = n.requestsOf("icx_sendTransaction")
+	if assert.Len(t, sent, 2) {
+		assert.Equal(t, "0x64", sent[1]["stepLimit"])
+	}
+
+	// fixed step limit and the one of the transaction are used without
+	// estimation
+	c.StepLimit = 500
+	_, err = c.Send(w, c.newTransaction(w, jsonrpc.Address(to.String()), nil, "", nil))
+	assert.NoError(t, err)
+	param := c.newTransaction(w, jsonrpc.Address(to.String()), nil, "", nil)
+	param.StepLimit = "0x10"
+	_, err = c.Send(w, param)
+	assert.NoError(t, err)
+	sent = n.requestsOf("icx_sendTransaction")
+	if assert.Len(t, sent, 4) {
+		assert.Equal(t, "0x1f4", sent[2]["stepLimit"])
+		assert.Equal(t, "0x10", sent[3]["stepLimit"])
+	}
+	assert.Len(t, n.requestsOf("debug_estimateStep"), 2)
+}
+
+func TestClient_Nonce(t *testing.T) {
+	n := newTestNode(t)
+	defer n.Close()
+	c := n.newClient()
+	w := wallet.New()
+	to := jsonrpc.Address("hx0000000000000000000000000000000000000001")
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Send(w, c.newTransaction(w, to, big.NewInt(1), "", nil))
+		assert.NoError(t, err)
+	}
+	estimated := n.requestsOf("debug_estimateStep")
+	sent := n.requestsOf("icx_sendTransaction")
+	if !assert.Len(t, sent, 3) || !assert.Len(t, estimated, 3) {
+		return
+	}
+	var last *big.Int
+	for i, req := range sent {
+		// estimation is for the transaction to send
+		assert.Equal(t, req["nonce"], estimated[i]["nonce"])
+		assert.Equal(t, "0x3", req["nid"])
+		assert.Equal(t, "0x1", req["value"])
+
+		var nonce common.HexInt
+		assert.NoError(t, nonce.UnmarshalJSON([]byte(`"`+req["nonce"].(string)+`"`)))
+		if last != nil {
+			assert.True(t, nonce.Cmp(last) > 0, "nonce=%s last=%s", &nonce, last)
+		}
+		last = new(big.Int).Set(&nonce.Int)
+	}
+}
+
+func TestClient_SendErrors(t *testing.T) {
+	n := newTestNode(t)
+	defer n.Close()
+	c := n.newClient()
+	w := wallet.New()
+	to := jsonrpc.Address("hx0000000000000000000000000000000000000001")
+
+	// failure of estimation
+	n.errors["debug_estimateStep"] = jsonrpc.ErrorCodeScore.New("Reverted")
+	_, err := c.Send(w, c.newTransaction(w, to, nil, "", nil))
+	if je, ok := err.(*jsonrpc.Error); assert.True(t, ok, "err=%+v", err) {
+		assert.Equal(t, jsonrpc.ErrorCodeScore, je.Code)
+	}
+	assert.Empty(t, n.requestsOf("icx_sendTransaction"))
+
+	// failure of sending
+	c.StepLimit = 100
+	n.errors["icx_sendTransaction"] = jsonrpc.ErrorCodeInvalidParams.New("BadTransaction")
+	_, err = c.Send(w, c.newTransaction(w, to, nil, "", nil))
+	assert.Error(t, err)
+	assert.Len(t, n.requestsOf("icx_sendTransaction"), 1)
+	assert.Empty(t, n.requestsOf("icx_waitTransactionResult"))
+
+	// no debug endpoint for estimation
+	c = NewClient(client.NewClientV3(n.URL), 3)
+	_, err = c.Send(w, c.newTransaction(w, to, nil, "", nil))
+	assert.Error(t, err)
+}
+
+func TestClient_Deploy(t *testing.T) {
+	n := newTestNode(t)
+	defer n.Close()
+	c := n.newClient()
+	w := wallet.New()
+
+	r, err := c.Deploy(w, nil, "application/java", []byte{0x01, 0x02},
+		map[string]interface{}{"name": "0x1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1", string(r.Status))
+
+	addr := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	_, err = c.Deploy(w, addr, "application/java", []byte{0x03}, nil)
+	assert.NoError(t, err)
+
+	sent := n.requestsOf("icx_sendTransaction")
+	if !assert.Len(t, sent, 2) {
+		return
+	}
+	assert.Equal(t, "cx0000000000000000000000000000000000000000", sent[0]["to"])
+	assert.Equal(t, "deploy", sent[0]["dataType"])
+	assert.Equal(t, map[string]interface{}{
+		"contentType": "application/java",
+		"content":     "0x0102",
+		"params":      map[string]interface{}{"name": "0x1"},
+	}, sent[0]["data"])
+	assert.Equal(t, addr.String(), sent[1]["to"])
+	assert.Equal(t, map[string]interface{}{
+		"contentType": "application/java",
+		"content":     "0x03",
+	}, sent[1]["data"])
+}
+
+func TestScore_Invoke(t *testing.T) {
+	n := newTestNode(t)
+	defer n.Close()
+	c := n.newClient()
+	w := wallet.New()
+	addr := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	owner := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	s := c.NewScore(addr, newTestAPI(t))
+
+	_, err := s.Invoke(w, "transfer", map[string]interface{}{
+		"_to":    owner,
+		"_value": big.NewInt(16),
+	}, nil)
+	assert.NoError(t, err)
+	sent := n.requestsOf("icx_sendTransaction")
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, addr.String(), sent[0]["to"])
+		assert.Equal(t, "call", sent[0]["dataType"])
+		assert.Equal(t, map[string]interface{}{
+			"method": "transfer",
+			"params": map[string]interface{}{
+				"_to":    owner.String(),
+				"_value": "0x10",
+			},
+		}, sent[0]["data"])
+	}
+
+	// invalid calls are not sent
+	_, err = s.Invoke(w, "unknown", nil, nil)
+	assert.Error(t, err)
+	_, err = s.Invoke(w, "transfer", map[string]interface{}{
+		"_to":    owner,
+		"_value": big.NewInt(16),
+	}, big.NewInt(1))
+	assert.Error(t, err)
+	_, err = s.Invoke(w, "transfer", map[string]interface{}{"_to": owner}, nil)
+	assert.Error(t, err)
+	assert.Len(t, n.requestsOf("icx_sendTransaction"), 1)
+	assert.Len(t, n.requestsOf("debug_estimateStep"), 1)
+}
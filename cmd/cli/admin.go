@@ -23,6 +23,7 @@ import (
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/node"
+	"github.com/icon-project/goloop/service"
 )
 
 func ReadFile(name string) ([]byte, error) {
@@ -128,6 +129,9 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			param.ConcurrencyLevel, _ = fs.GetInt("concurrency")
 			param.NormalTxPoolSize, _ = fs.GetInt("normal_tx_pool")
 			param.PatchTxPoolSize, _ = fs.GetInt("patch_tx_pool")
+			param.TxPoolOrder, _ = fs.GetString("tx_pool_order")
+			param.TxPoolSenderLimit, _ = fs.GetInt("tx_pool_sender_limit")
+			param.TxPoolEviction, _ = fs.GetString("tx_pool_eviction")
 			param.MaxBlockTxBytes, _ = fs.GetInt("max_block_tx_bytes")
 			param.NodeCache, _ = fs.GetString("node_cache")
 			param.Channel, _ = fs.GetString("channel")
@@ -146,6 +150,15 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 				param.NephewsLimit = &nephewsLimit
 			}
 			param.ValidateTxOnSend, _ = fs.GetBool("validate_tx_on_send")
+			param.EventIndex, _ = fs.GetBool("event_index")
+			param.Archive, _ = fs.GetBool("archive")
+			param.StateGC, _ = fs.GetInt64("state_gc")
+			param.StateGCInterval, _ = fs.GetInt64("state_gc_interval")
+			if checkpoints, _ := fs.GetIntSlice("state_gc_checkpoints"); len(checkpoints) > 0 {
+				for _, h := range checkpoints {
+					param.StateGCCheckpoints = append(param.StateGCCheckpoints, int64(h))
+				}
+			}
 
 			var buf *bytes.Buffer
 			if len(genesisZip) > 0 {
@@ -189,6 +202,9 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	joinFlags.Int("concurrency", 1, "Maximum number of executors to be used for concurrency")
 	joinFlags.Int("normal_tx_pool", 0, "Size of normal transaction pool")
 	joinFlags.Int("patch_tx_pool", 0, "Size of patch transaction pool")
+	joinFlags.String("tx_pool_order", service.TxPoolOrderDefault, "Order of transactions in the pool (fifo,fee)")
+	joinFlags.Int("tx_pool_sender_limit", 0, "Maximum number of pending transactions of a sender (0: no limit)")
+	joinFlags.String("tx_pool_eviction", service.TxPoolEvictionDefault, "Eviction policy on full pool (none,oldest,lowest_fee,largest_sender)")
 	joinFlags.Int("max_block_tx_bytes", 0, "Max size of transactions in a block")
 	joinFlags.String("node_cache", chain.NodeCacheDefault, "Node cache (none,small,large)")
 	joinFlags.String("channel", "", "Channel")
@@ -203,6 +219,11 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	joinFlags.Int("children_limit", -1, "Maximum number of child connections (-1: uses system default value)")
 	joinFlags.Int("nephews_limit", -1, "Maximum number of nephew connections (-1: uses system default value)")
 	joinFlags.Bool("validate_tx_on_send", false, "Validate transaction on send")
+	joinFlags.Bool("event_index", false, "Index event logs by SCORE address and signature")
+	joinFlags.Bool("archive", false, "Archive mode keeping all the states (pruning is not allowed)")
+	joinFlags.Int64("state_gc", 0, "Number of the latest states kept by garbage collection of states (0: disable)")
+	joinFlags.Int64("state_gc_interval", 0, "Number of blocks between garbage collections of states (0: uses system default value)")
+	joinFlags.IntSlice("state_gc_checkpoints", nil, "Heights of the states kept by garbage collection of states - Comma separated integers")
 
 	leaveCmd := &cobra.Command{
 		Use:   "leave CID",
@@ -283,14 +304,33 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			Short: "Chain stop",
 			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
 			RunE:  opFunc("stop"),
-		},
-		&cobra.Command{
-			Use:   "verify CID",
-			Short: "Chain data verify",
-			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
-			RunE:  opFunc("verify"),
 		})
 
+	verifyCmd := &cobra.Command{
+		Use:   "verify CID",
+		Short: "Chain data verify",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			param := &node.ChainVerifyParam{}
+			param.Height, _ = fs.GetInt64("height")
+			param.Execute, _ = fs.GetBool("execute")
+
+			var v string
+			reqUrl := node.UrlChain + "/" + args[0] + "/verify"
+			_, err := adminClient.PostWithJson(reqUrl, param, &v)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(verifyCmd)
+	verifyFlags := verifyCmd.Flags()
+	verifyFlags.Int64("height", 0, "Block height to start verification(default:genesis)")
+	verifyFlags.Bool("execute", false, "Re-execute transactions to compare the results")
+
 	resetCmd := &cobra.Command{
 		Use:   "reset CID",
 		Short: "Chain data reset",
@@ -409,6 +449,54 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	backupFlags := backupCmd.Flags()
 	backupFlags.Bool("manual", false, "Manual backup mode (just release database)")
 
+	reindexCmd := &cobra.Command{
+		Use:   "reindex CID",
+		Short: "Start to rebuild the event index from the height",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			height, _ := fs.GetInt64("height")
+			param := map[string]interface{}{
+				"height": height,
+			}
+			var v string
+			reqUrl := node.UrlChain + "/" + args[0] + "/" + chain.EventIndexTask
+			_, err := adminClient.PostWithJson(reqUrl, param, &v)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(reindexCmd)
+	reindexFlags := reindexCmd.Flags()
+	reindexFlags.Int64("height", 0, "Block Height to start indexing (default:genesis)")
+
+	dbconvertCmd := &cobra.Command{
+		Use:   "dbconvert CID",
+		Short: "Start to convert the database into the other type",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			dbType, _ := fs.GetString("db_type")
+			param := map[string]interface{}{
+				"dbType": dbType,
+			}
+			var v string
+			reqUrl := node.UrlChain + "/" + args[0] + "/" + chain.DBConvertTask
+			_, err := adminClient.PostWithJson(reqUrl, param, &v)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(dbconvertCmd)
+	dbconvertFlags := dbconvertCmd.Flags()
+	dbconvertFlags.String("db_type", string(db.PebbleDBBackend), "Database type to convert into(only goleveldb to pebble is supported)")
+
 	genesisCmd := &cobra.Command{
 		Use:   "genesis CID FILE",
 		Short: "Download chain genesis file",
@@ -480,6 +568,66 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	configFlags.String("value", "", "use if value starts with '-'.\n"+
 		"(if the third arg is used, this flag will be ignored)")
 
+	dbscanCmd := &cobra.Command{
+		Use:   "dbscan CID BUCKET",
+		Short: "Scan entries of the bucket in chain database",
+		Long: "Scan entries of the bucket in chain database.\n" +
+			"BUCKET is the ID of the bucket (e.g. H for block header hashes by height,\n" +
+			"T for transaction locators by hash). Keys and values are printed in hex.",
+		Args: ArgsWithDefaultErrorFunc(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			prefix, _ := fs.GetString("prefix")
+			start, _ := fs.GetString("start")
+			limit, _ := fs.GetInt("limit")
+			countOnly, _ := fs.GetBool("count")
+			if _, err := hex.DecodeString(prefix); err != nil {
+				return errors.Errorf("invalid prefix=%s", prefix)
+			}
+			if _, err := hex.DecodeString(start); err != nil {
+				return errors.Errorf("invalid start=%s", start)
+			}
+
+			reqUrl := node.UrlDB + "/" + args[0] + "/" + url.PathEscape(args[1])
+			var cnt int
+			for {
+				params := &url.Values{}
+				params.Add("prefix", prefix)
+				params.Add("count", strconv.Itoa(node.MaxBucketListCount))
+				if start != "" {
+					params.Add("start", start)
+				}
+				v := new(node.BucketListView)
+				if _, err := adminClient.Get(reqUrl, v, params); err != nil {
+					return err
+				}
+				for _, e := range v.Entries {
+					if limit > 0 && cnt >= limit {
+						break
+					}
+					if !countOnly {
+						fmt.Println(e.Key, e.Value)
+					}
+					cnt++
+				}
+				if v.Next == "" || (limit > 0 && cnt >= limit) {
+					break
+				}
+				start = v.Next
+			}
+			if countOnly {
+				fmt.Println(cnt)
+			}
+			return nil
+		},
+	}
+	rootCmd.AddCommand(dbscanCmd)
+	dbscanFlags := dbscanCmd.Flags()
+	dbscanFlags.String("prefix", "", "Prefix of keys in hex")
+	dbscanFlags.String("start", "", "Key to start scanning in hex")
+	dbscanFlags.Int("limit", 0, "Maximum number of entries to scan(default:unlimited)")
+	dbscanFlags.Bool("count", false, "Print the number of entries instead of entries")
+
 	rootCmd.Use = "chain TASK CID PARAM"
 	rootCmd.Args = ArgsWithDefaultErrorFunc(cobra.ExactArgs(3))
 	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
@@ -714,6 +862,81 @@ func NewUserCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command
 			return nil
 		},
 	})
+
+	roleCmd := &cobra.Command{
+		Use:   "role",
+		Short: "Role management",
+	}
+	rootCmd.AddCommand(roleCmd)
+	roleCmd.AddCommand(&cobra.Command{
+		Use:   "ls",
+		Short: "List roles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := make([]*node.RoleView, 0)
+			reqUrl := node.UrlUser + node.UrlRole
+			resp, err := adminClient.Get(reqUrl, &l)
+			if err != nil {
+				return err
+			}
+			if err = JsonPrettyPrintln(os.Stdout, l); err != nil {
+				return errors.Errorf("failed JsonIntend resp=%+v, err=%+v", resp, err)
+			}
+			return nil
+		},
+	}, &cobra.Command{
+		Use:   "add NAME ACTION...",
+		Short: "Add or update role with permitted actions",
+		Long: "Add or update role with permitted actions\n" +
+			"Actions: *, view, join, leave, start, stop, reset, verify, import,\n" +
+			"         prune, backup, task, configure, restore, db",
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqUrl := node.UrlUser + node.UrlRole
+			param := &struct {
+				Name    string   `json:"name"`
+				Actions []string `json:"actions"`
+			}{Name: args[0], Actions: args[1:]}
+			var v string
+			if _, err := adminClient.PostWithJson(reqUrl, param, &v); err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}, &cobra.Command{
+		Use:   "rm NAME",
+		Short: "Remove role",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqUrl := node.UrlUser + node.UrlRole + "/" + args[0]
+			var v string
+			if _, err := adminClient.Delete(reqUrl, &v); err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}, &cobra.Command{
+		Use:   "assign ADDRESS [ROLE]",
+		Short: "Assign role to user, revoke the role if ROLE is omitted",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqUrl := node.UrlUser + "/" + args[0] + node.UrlRole
+			param := &struct {
+				Role string `json:"role"`
+			}{}
+			if len(args) > 1 {
+				param.Role = args[1]
+			}
+			var v string
+			if _, err := adminClient.PostWithJson(reqUrl, param, &v); err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	})
 	return rootCmd, vc
 }
 
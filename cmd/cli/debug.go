@@ -17,6 +17,8 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"os"
 
@@ -24,6 +26,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	v3 "github.com/icon-project/goloop/server/v3"
 )
@@ -52,13 +55,24 @@ func NewDebugCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	BindPFlags(vc, rootCmd.PersistentFlags())
 
 	traceCmd := &cobra.Command{
-		Use:   "trace HASH",
-		Short: "Get trace of the transaction",
-		Args:  cobra.ExactArgs(1),
+		Use:   "trace [HASH]",
+		Short: "Get trace of the transaction or balance changes of blocks",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			param := &v3.TransactionHashParam{
+			fs := cmd.Flags()
+			if fs.Changed("from") {
+				if len(args) > 0 {
+					return errors.New("HASH can't be used with --from")
+				}
+				return traceBlocks(&debugClient, cmd)
+			}
+			if len(args) != 1 {
+				return errors.New("HASH or --from is required")
+			}
+			param := &v3.TraceParam{
 				Hash: jsonrpc.HexBytes(args[0]),
 			}
+			param.Mode, _ = fs.GetString("mode")
 			trace, err := debugClient.Do("debug_getTrace", param, nil)
 			if err != nil {
 				return err
@@ -66,7 +80,99 @@ func NewDebugCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			return JsonPrettyPrintln(os.Stdout, trace.Result)
 		},
 	}
+	traceFlags := traceCmd.Flags()
+	traceFlags.String("mode", "invoke", "Trace mode (invoke,callTree)")
+	traceFlags.Int64("from", 0, "Trace balance changes of blocks from the height")
+	traceFlags.Int64("to", -1, "Last height of blocks to trace (default: last finalized block)")
+	traceFlags.StringSlice("address", nil, "Address to filter balance changes (repeatable)")
 	rootCmd.AddCommand(traceCmd)
 
+	poolCmd := &cobra.Command{
+		Use:   "pool",
+		Short: "Inspect transaction pool",
+	}
+	rootCmd.AddCommand(poolCmd)
+
+	pendingCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Get pending transactions in the pool",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			param := &v3.PendingTransactionsParam{}
+			param.Group, _ = fs.GetString("group")
+			if from, _ := fs.GetString("from"); from != "" {
+				param.From = jsonrpc.Address(from)
+			}
+			if skip, _ := fs.GetInt("skip"); skip > 0 {
+				param.Skip = jsonrpc.HexInt(intconv.FormatInt(int64(skip)))
+			}
+			if limit, _ := fs.GetInt("limit"); limit > 0 {
+				param.Limit = jsonrpc.HexInt(intconv.FormatInt(int64(limit)))
+			}
+			txs, err := debugClient.Do("debug_getPendingTransactions", param, nil)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, txs.Result)
+		},
+	}
+	pendingFlags := pendingCmd.Flags()
+	pendingFlags.String("group", "normal", "Transaction group (normal,patch)")
+	pendingFlags.String("from", "", "Address of the sender")
+	pendingFlags.Int("skip", 0, "Number of transactions to skip")
+	pendingFlags.Int("limit", 0, "Maximum number of transactions (0: uses server default value)")
+	poolCmd.AddCommand(pendingCmd)
+
+	poolCmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Get size and used capacity of the pools",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, err := debugClient.Do("debug_getPoolStatus", nil, nil)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, status.Result)
+		},
+	})
+
 	return rootCmd, vc
 }
+
+// traceBlocks prints balance changes of blocks in the range chunk by chunk
+// following "next" of debug_traceBlocks.
+func traceBlocks(debugClient *client.JsonRpcClient, cmd *cobra.Command) error {
+	fs := cmd.Flags()
+	from, _ := fs.GetInt64("from")
+	to, _ := fs.GetInt64("to")
+	addrs, _ := fs.GetStringSlice("address")
+
+	param := &v3.TraceBlocksParam{
+		From: jsonrpc.HexInt(intconv.FormatInt(from)),
+	}
+	if to >= 0 {
+		param.To = jsonrpc.HexInt(intconv.FormatInt(to))
+	}
+	for _, addr := range addrs {
+		param.Addresses = append(param.Addresses, jsonrpc.Address(addr))
+	}
+	for {
+		var result struct {
+			Blocks []json.RawMessage `json:"blocks"`
+			Next   jsonrpc.HexInt    `json:"next"`
+		}
+		if _, err := debugClient.Do("debug_traceBlocks", param, &result); err != nil {
+			return err
+		}
+		for _, blk := range result.Blocks {
+			if err := JsonPrettyPrintln(os.Stdout, blk); err != nil {
+				return err
+			}
+		}
+		if result.Next == "" {
+			return nil
+		}
+		param.From = result.Next
+	}
+}
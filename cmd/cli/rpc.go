@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/icon-project/goloop/client"
@@ -423,6 +424,82 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 	rootCmd.AddCommand(scoreStatusCmd)
 	flags = scoreStatusCmd.Flags()
 	flags.Int("height", -1, "BlockHeight")
+
+	logsCmd := &cobra.Command{
+		Use:   "logs FROM_HEIGHT [TO_HEIGHT]",
+		Short: "Get event logs in the range of blocks",
+		Args:  ArgsWithDefaultErrorFunc(cobra.RangeArgs(1, 2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.LogsParam{}
+			if rawJson := cmd.Flag("raw").Value.String(); rawJson != "" {
+				var dataBytes []byte
+				if strings.HasPrefix(strings.TrimSpace(rawJson), "{") {
+					dataBytes = []byte(rawJson)
+				} else {
+					var err error
+					if dataBytes, err = readFile(rawJson); err != nil {
+						return err
+					}
+				}
+				if err := json.Unmarshal(dataBytes, param); err != nil {
+					return err
+				}
+			} else if err := ValidateFlags(cmd.Flags(), "event"); err != nil {
+				return err
+			}
+			for i, arg := range args {
+				height, err := intconv.ParseInt(arg, 64)
+				if err != nil {
+					return err
+				}
+				if i == 0 {
+					param.FromHeight = jsonrpc.HexInt(intconv.FormatInt(height))
+				} else {
+					param.ToHeight = jsonrpc.HexInt(intconv.FormatInt(height))
+				}
+			}
+			if sig := cmd.Flag("event").Value.String(); sig != "" {
+				param.Event = sig
+			}
+			if addrs, err := cmd.Flags().GetStringSlice("addr"); err == nil {
+				for _, addr := range addrs {
+					param.Addrs = append(param.Addrs, jsonrpc.Address(addr))
+				}
+			}
+			if evtIndexed, err := cmd.Flags().GetStringSlice("indexed"); err == nil && len(evtIndexed) > 0 {
+				param.Indexed = make([]*string, len(evtIndexed))
+				for i := range evtIndexed {
+					param.Indexed[i] = &evtIndexed[i]
+				}
+			}
+			if evtData, err := cmd.Flags().GetStringSlice("data"); err == nil && len(evtData) > 0 {
+				param.Data = make([]*string, len(evtData))
+				for i := range evtData {
+					param.Data[i] = &evtData[i]
+				}
+			}
+			if cursor := cmd.Flag("cursor").Value.String(); cursor != "" {
+				param.Cursor = cursor
+			}
+			if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 {
+				param.Limit = jsonrpc.HexInt(intconv.FormatInt(int64(limit)))
+			}
+			logs, err := rpcClient.GetLogs(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, logs)
+		},
+	}
+	rootCmd.AddCommand(logsCmd)
+	flags = logsCmd.Flags()
+	flags.StringSlice("addr", nil, "SCORE Addresses, comma-separated string")
+	flags.String("event", "", "Signature of Event")
+	flags.StringSlice("indexed", nil, "Indexed Arguments of Event, comma-separated string")
+	flags.StringSlice("data", nil, "Not indexed Arguments of Event, comma-separated string")
+	flags.String("cursor", "", "Cursor returned by the previous query")
+	flags.Int("limit", 0, "Maximum number of logs")
+	flags.String("raw", "", "LogsParam raw json file or json-string")
 	return rootCmd, vc
 }
 
@@ -915,10 +992,11 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 				}
 				param.EventFilters = append(param.EventFilters, ef)
 			}
-			OnInterrupt(rpcClient.Cleanup)
-			err = rpcClient.MonitorBlock(param, func(v *server.BlockNotification) {
+			cancelCh := make(chan bool)
+			OnInterrupt(func() { close(cancelCh) })
+			err = rpcClient.MonitorBlockResumable(param, func(v *server.BlockNotification) {
 				JsonPrettyPrintln(os.Stdout, v)
-			}, nil)
+			}, monitorOptions(cmd), cancelCh)
 			if err != nil {
 				return err
 			}
@@ -929,6 +1007,7 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 	monitorBlockFlags := monitorBlockCmd.Flags()
 	monitorBlockFlags.StringArray("filter", nil,
 		"EventFilter raw json file or json string")
+	addMonitorFlags(monitorBlockFlags)
 
 	monitorEventCmd := &cobra.Command{
 		Use:   "event HEIGHT",
@@ -936,6 +1015,10 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 		Args:  ArgsWithDefaultErrorFunc(cobra.MaximumNArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			param := &server.EventRequest{}
+			fs, err := cmd.Flags().GetStringArray("filter")
+			if err != nil {
+				return err
+			}
 			if rawJson := cmd.Flag("raw").Value.String(); rawJson != "" {
 				var dataBytes []byte
 				if strings.HasPrefix(strings.TrimSpace(rawJson), "{") {
@@ -953,10 +1036,28 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 				if err := cobra.ExactArgs(1)(cmd, args); err != nil {
 					return err
 				}
-				if err := ValidateFlags(cmd.Flags(), "event"); err != nil {
-					return err
+				if len(fs) == 0 {
+					if err := ValidateFlags(cmd.Flags(), "event"); err != nil {
+						return err
+					}
 				}
 			}
+			for _, f := range fs {
+				ef := &server.EventFilter{}
+				var efBytes []byte
+				if strings.HasPrefix(strings.TrimSpace(f), "{") {
+					efBytes = []byte(f)
+				} else {
+					var err error
+					if efBytes, err = readFile(f); err != nil {
+						return err
+					}
+				}
+				if err := json.Unmarshal(efBytes, ef); err != nil {
+					return fmt.Errorf("fail to unmarshal from %s, err:%+v", f, err)
+				}
+				param.EventFilters = append(param.EventFilters, ef)
+			}
 			if len(args) > 0 {
 				height, err := intconv.ParseInt(args[0], 64)
 				if err != nil {
@@ -983,10 +1084,11 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 					param.Data[i] = &v
 				}
 			}
-			OnInterrupt(rpcClient.Cleanup)
-			err := rpcClient.MonitorEvent(param, func(v *server.EventNotification) {
+			cancelCh := make(chan bool)
+			OnInterrupt(func() { close(cancelCh) })
+			err = rpcClient.MonitorEventResumable(param, func(v *server.EventNotification) {
 				JsonPrettyPrintln(os.Stdout, v)
-			}, nil)
+			}, monitorOptions(cmd), cancelCh)
 			if err != nil {
 				return err
 			}
@@ -1001,5 +1103,30 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 	monitorEventFlags.StringSlice("indexed", nil, "Indexed Arguments of Event, comma-separated string")
 	monitorEventFlags.StringSlice("data", nil, "Not indexed Arguments of Event, comma-separated string")
 	monitorEventFlags.String("raw", "", "EventFilter raw json file or json-string")
+	monitorEventFlags.StringArray("filter", nil,
+		"EventFilter raw json file or json string, it can't be used with event")
+	addMonitorFlags(monitorEventFlags)
 	return rootCmd
 }
+
+func addMonitorFlags(fs *pflag.FlagSet) {
+	fs.Int("max_retry", 0, "Maximum number of consecutive reconnections (0: no limit, -1: no reconnection)")
+	fs.Duration("max_backoff", client.DefaultMonitorMaxBackoff, "Maximum delay between reconnections")
+}
+
+func monitorOptions(cmd *cobra.Command) *client.MonitorOptions {
+	fs := cmd.Flags()
+	maxRetry, _ := fs.GetInt("max_retry")
+	maxBackoff, _ := fs.GetDuration("max_backoff")
+	return &client.MonitorOptions{
+		MaxBackoff: maxBackoff,
+		MaxRetry:   maxRetry,
+		OnStatus: func(s client.MonitorStatus, err error) {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "monitor %s err=%v\n", s, err)
+			} else {
+				fmt.Fprintf(os.Stderr, "monitor %s\n", s)
+			}
+		},
+	}
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"golang.org/x/tools/imports"
+
+	"github.com/icon-project/goloop/client/score"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreapi"
+)
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, strings.Join([]string{
+		"gobind <file> <package> <struct> <api-file>",
+		"",
+		"<api-file> is the result of icx_getScoreApi in JSON (or the response",
+		"of the JSON-RPC), or binary dump of scoreapi.Info. Use '-' for stdin.",
+		"",
+		"Example:",
+		"    goloop rpc scoreapi cx... > token.json",
+		"    gobind token.go token Token token.json",
+		"",
+	}, "\n"))
+}
+
+// readAPI returns the API in the form of the result of icx_getScoreApi.
+func readAPI(file string) ([]byte, error) {
+	var bs []byte
+	var err error
+	if file == "-" {
+		bs, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		bs, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(bs)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var resp struct {
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal(trimmed, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Result, nil
+	}
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return trimmed, nil
+	}
+	info := new(scoreapi.Info)
+	if err := info.SetBytes(bs); err != nil {
+		return nil, fmt.Errorf("fail to parse %s err:%+v", file, err)
+	}
+	jso, err := info.ToJSON(module.JSONVersion3)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jso)
+}
+
+var reservedNames = map[string]bool{
+	"bool": true, "byte": true, "error": true, "interface": true,
+	"string": true, "map": true, "nil": true, "true": true, "false": true,
+	"big": true, "client": true, "errors": true, "module": true, "score": true,
+}
+
+func exportedName(s string) string {
+	var sb strings.Builder
+	upper := true
+	for _, c := range s {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			upper = true
+			continue
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+			upper = false
+		}
+		sb.WriteRune(c)
+	}
+	name := sb.String()
+	if len(name) == 0 || unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+func paramName(s string) string {
+	name := exportedName(s)
+	name = strings.ToLower(name[:1]) + name[1:]
+	if token.IsKeyword(name) || reservedNames[name] {
+		name += "_"
+	}
+	return name
+}
+
+// names assigns unique names avoiding collisions.
+type names map[string]bool
+
+func (n names) unique(name string) string {
+	un := name
+	for i := 2; n[un]; i++ {
+		un = name + strconv.Itoa(i)
+	}
+	n[un] = true
+	return un
+}
+
+// goType returns Go type for the type of SCORE API. Optional parameters use
+// nilable types.
+func goType(t string, optional bool) string {
+	if strings.HasPrefix(t, "[]") {
+		return "[]" + goType(t[2:], false)
+	}
+	switch t {
+	case "int":
+		return "*big.Int"
+	case "str":
+		if optional {
+			return "*string"
+		}
+		return "string"
+	case "bool":
+		if optional {
+			return "*bool"
+		}
+		return "bool"
+	case "bytes":
+		return "[]byte"
+	case "Address":
+		return "module.Address"
+	case "struct", "dict":
+		return "map[string]interface{}"
+	case "list":
+		return "[]interface{}"
+	default:
+		return "interface{}"
+	}
+}
+
+// outputType returns Go type for the value decoded by score.DecodeValue.
+func outputType(t string) string {
+	if strings.HasPrefix(t, "[]") {
+		return "[]interface{}"
+	}
+	return goType(t, false)
+}
+
+type param struct {
+	Name    string
+	GoName  string
+	GoType  string
+	Deref   bool
+	Nilable bool
+}
+
+type method struct {
+	*score.Method
+	GoName  string
+	Params  []param
+	OutType string
+}
+
+type field struct {
+	Name   string
+	GoName string
+	GoType string
+}
+
+type event struct {
+	*score.Method
+	GoName    string
+	TypeName  string
+	Decoder   string
+	Filter    string
+	Signature string
+	Fields    []field
+}
+
+type binding struct {
+	Package  string
+	Struct   string
+	APIName  string
+	API      string
+	Fallback string
+	ReadOnly []*method
+	Writable []*method
+	Events   []*event
+}
+
+func newBinding(pkg, strt string, bs []byte) (*binding, error) {
+	api, err := score.NewAPIFromJSON(bs)
+	if err != nil {
+		return nil, err
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, bs); err != nil {
+		return nil, err
+	}
+	b := &binding{
+		Package: pkg,
+		Struct:  strt,
+		APIName: paramName(strt) + "API",
+		API:     compact.String(),
+	}
+	mNames := names{"Score": true}
+	tNames := names{strt: true, "New" + strt: true, b.APIName: true}
+	for _, m := range api.Methods {
+		switch m.Type {
+		case score.TypeFallback:
+			b.Fallback = mNames.unique("Fallback")
+		case score.TypeEvent:
+			e := &event{
+				Method:    m,
+				GoName:    exportedName(m.Name),
+				Signature: m.Signature(),
+			}
+			e.TypeName = tNames.unique(strt + e.GoName)
+			e.Decoder = mNames.unique("Decode" + e.GoName)
+			e.Filter = mNames.unique("Filter" + e.GoName)
+			fNames := names{}
+			for _, in := range m.Inputs {
+				e.Fields = append(e.Fields, field{
+					Name:   in.Name,
+					GoName: fNames.unique(exportedName(in.Name)),
+					GoType: outputType(in.Type),
+				})
+			}
+			b.Events = append(b.Events, e)
+		case score.TypeFunction:
+			bm := &method{
+				Method: m,
+				GoName: mNames.unique(exportedName(m.Name)),
+			}
+			pNames := names{}
+			for _, in := range m.Inputs {
+				gt := goType(in.Type, in.IsOptional())
+				bm.Params = append(bm.Params, param{
+					Name:    in.Name,
+					GoName:  pNames.unique(paramName(in.Name)),
+					GoType:  gt,
+					Deref:   strings.HasPrefix(gt, "*") && gt != "*big.Int",
+					Nilable: in.IsOptional(),
+				})
+			}
+			if m.IsReadOnly() {
+				bm.OutType = "interface{}"
+				if len(m.Outputs) > 0 {
+					bm.OutType = outputType(m.Outputs[0].Type)
+				}
+				b.ReadOnly = append(b.ReadOnly, bm)
+			} else {
+				b.Writable = append(b.Writable, bm)
+			}
+		}
+	}
+	return b, nil
+}
+
+var bindingTemplate = template.Must(template.New("binding").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`// Code generated by gobind; DO NOT EDIT.
+package {{.Package}}
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/client/score"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+const {{.APIName}} = {{quote .API}}
+
+type {{.Struct}} struct {
+	score *score.Score
+}
+
+func New{{.Struct}}(c *score.Client, addr module.Address) (*{{.Struct}}, error) {
+	api, err := score.NewAPIFromJSON([]byte({{.APIName}}))
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Struct}}{score: c.NewScore(addr, api)}, nil
+}
+
+func (_r *{{.Struct}}) Score() *score.Score {
+	return _r.score
+}
+{{define "params"}}	_params := map[string]interface{}{}
+{{- range .Params}}
+{{- if .Nilable}}
+	if {{.GoName}} != nil {
+		_params[{{quote .Name}}] = {{if .Deref}}*{{end}}{{.GoName}}
+	}
+{{- else}}
+	_params[{{quote .Name}}] = {{.GoName}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- range .ReadOnly}}
+// {{.GoName}} calls read-only method {{.Signature}}.
+func (_r *{{$.Struct}}) {{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{.GoName}} {{.GoType}}{{end}}) ({{.OutType}}, error) {
+{{template "params" .}}
+{{- if eq .OutType "interface{}"}}
+	return _r.score.Call({{quote .Name}}, _params)
+{{- else}}
+	var _out {{.OutType}}
+	_ret, _err := _r.score.Call({{quote .Name}}, _params)
+	if _err != nil {
+		return _out, _err
+	}
+	if _ret != nil {
+		var _ok bool
+		if _out, _ok = _ret.({{.OutType}}); !_ok {
+			return _out, errors.InvalidStateError.Errorf("InvalidResult(method={{.Name}},ret=%v)", _ret)
+		}
+	}
+	return _out, nil
+{{- end}}
+}
+{{end}}
+{{- range .Writable}}
+// {{.GoName}} sends the transaction calling {{.Signature}} and waits for the result.
+func (_r *{{$.Struct}}) {{.GoName}}(_w module.Wallet{{if .IsPayable}}, _value *big.Int{{end}}{{range .Params}}, {{.GoName}} {{.GoType}}{{end}}) (*client.TransactionResult, error) {
+{{template "params" .}}
+	return _r.score.Invoke(_w, {{quote .Name}}, _params, {{if .IsPayable}}_value{{else}}nil{{end}})
+}
+{{end}}
+{{- if .Fallback}}
+// {{.Fallback}} transfers value to the SCORE and waits for the result.
+func (_r *{{.Struct}}) {{.Fallback}}(_w module.Wallet, _value *big.Int) (*client.TransactionResult, error) {
+	return _r.score.Transfer(_w, _value)
+}
+{{end}}
+{{- range .Events}}
+// {{.TypeName}} is the event {{.Signature}}.
+type {{.TypeName}} struct {
+{{- range .Fields}}
+	{{.GoName}} {{.GoType}}
+{{- end}}
+}
+
+// {{.Decoder}} decodes the event log of {{.Signature}}.
+func (_r *{{$.Struct}}) {{.Decoder}}(_el *client.EventLog) (*{{.TypeName}}, error) {
+	_e, _err := _r.score.DecodeEventLog(_el)
+	if _err != nil {
+		return nil, _err
+	}
+	if _e.Signature != {{quote .Signature}} {
+		return nil, errors.IllegalArgumentError.Errorf("SignatureMismatch(sig=%s)", _e.Signature)
+	}
+	_ev := new({{.TypeName}})
+{{- range .Fields}}
+	_ev.{{.GoName}}, _ = _e.Params[{{quote .Name}}].({{.GoType}})
+{{- end}}
+	return _ev, nil
+}
+
+// {{.Filter}} returns the events of {{.Signature}} from the SCORE in the result.
+func (_r *{{$.Struct}}) {{.Filter}}(_tr *client.TransactionResult) ([]*{{.TypeName}}, error) {
+	var _evs []*{{.TypeName}}
+	for _i := range _tr.EventLogs {
+		_el := &_tr.EventLogs[_i]
+		if string(_el.Addr) != _r.score.Address().String() ||
+			len(_el.Indexed) == 0 || _el.Indexed[0] == nil || *_el.Indexed[0] != {{quote .Signature}} {
+			continue
+		}
+		_ev, _err := _r.{{.Decoder}}(_el)
+		if _err != nil {
+			return nil, _err
+		}
+		_evs = append(_evs, _ev)
+	}
+	return _evs, nil
+}
+{{end}}`))
+
+// generate returns the source of the binding for the API in the form of
+// the result of icx_getScoreApi.
+func generate(pkg, strt string, api []byte) ([]byte, error) {
+	b, err := newBinding(pkg, strt, api)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := bindingTemplate.Execute(&out, b); err != nil {
+		return nil, err
+	}
+	return imports.Process("", out.Bytes(), nil)
+}
+
+func main() {
+	if len(os.Args) != 5 {
+		printUsage()
+		os.Exit(1)
+	}
+	file := os.Args[1]
+	pkg := os.Args[2]
+	strt := os.Args[3]
+
+	bs, err := readAPI(os.Args[4])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+	iout, err := generate(pkg, strt, bs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+
+	err = ioutil.WriteFile(file, iout, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+}
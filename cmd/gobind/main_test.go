@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+const (
+	testAPIFile    = "testdata/token.json"
+	testGoldenFile = "testdata/token/token.go"
+)
+
+func TestGenerate_Golden(t *testing.T) {
+	api, err := readAPI(testAPIFile)
+	if err != nil {
+		t.Fatalf("Fail to read API err=%+v", err)
+	}
+	out, err := generate("token", "Token", api)
+	if err != nil {
+		t.Fatalf("Fail to generate err=%+v", err)
+	}
+	if *update {
+		if err := ioutil.WriteFile(testGoldenFile, out, 0644); err != nil {
+			t.Fatalf("Fail to update golden file err=%+v", err)
+		}
+	}
+	golden, err := ioutil.ReadFile(testGoldenFile)
+	if err != nil {
+		t.Fatalf("Fail to read golden file err=%+v", err)
+	}
+	if string(out) != string(golden) {
+		t.Errorf("Generated binding differs from %s (run with -update to accept)\n%s",
+			testGoldenFile, out)
+	}
+}
+
+func TestGenerate_GoldenCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skip building the golden package in short mode")
+	}
+	cmd := exec.Command("go", "build", "-o", os.DevNull, "./testdata/token")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("Fail to build the golden package err=%+v\n%s", err, out)
+	}
+}
+
+func TestReadAPI_Response(t *testing.T) {
+	api, err := readAPI(testAPIFile)
+	if err != nil {
+		t.Fatalf("Fail to read API err=%+v", err)
+	}
+	f, err := ioutil.TempFile("", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	resp := `{"jsonrpc":"2.0","id":1,"result":` + string(api) + `}`
+	if _, err := f.WriteString(resp); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// the response of JSON-RPC gives the same binding
+	api2, err := readAPI(f.Name())
+	if err != nil {
+		t.Fatalf("Fail to read API from the response err=%+v", err)
+	}
+	if string(api) != string(api2) {
+		t.Errorf("API from the response differs\n%s\n%s", api, api2)
+	}
+}
@@ -0,0 +1,132 @@
+// Code generated by gobind; DO NOT EDIT.
+package token
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/client/score"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+const tokenAPI = "[{\"type\":\"function\",\"name\":\"balanceOf\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"Address\"}],\"outputs\":[{\"type\":\"int\"}],\"readonly\":\"0x1\"},{\"type\":\"function\",\"name\":\"name\",\"inputs\":[],\"outputs\":[{\"type\":\"str\"}],\"readonly\":\"0x1\"},{\"type\":\"function\",\"name\":\"transfer\",\"inputs\":[{\"name\":\"_to\",\"type\":\"Address\"},{\"name\":\"_value\",\"type\":\"int\"},{\"name\":\"_data\",\"type\":\"bytes\",\"default\":null}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"deposit\",\"inputs\":[{\"name\":\"memo\",\"type\":\"str\",\"default\":null}],\"outputs\":[],\"payable\":\"0x1\"},{\"type\":\"fallback\",\"name\":\"fallback\",\"inputs\":[],\"payable\":\"0x1\"},{\"type\":\"eventlog\",\"name\":\"Transfer\",\"inputs\":[{\"name\":\"_from\",\"type\":\"Address\",\"indexed\":\"0x1\"},{\"name\":\"_to\",\"type\":\"Address\",\"indexed\":\"0x1\"},{\"name\":\"_value\",\"type\":\"int\",\"indexed\":\"0x1\"},{\"name\":\"_data\",\"type\":\"bytes\"}]}]"
+
+type Token struct {
+	score *score.Score
+}
+
+func NewToken(c *score.Client, addr module.Address) (*Token, error) {
+	api, err := score.NewAPIFromJSON([]byte(tokenAPI))
+	if err != nil {
+		return nil, err
+	}
+	return &Token{score: c.NewScore(addr, api)}, nil
+}
+
+func (_r *Token) Score() *score.Score {
+	return _r.score
+}
+
+// BalanceOf calls read-only method balanceOf(Address).
+func (_r *Token) BalanceOf(owner module.Address) (*big.Int, error) {
+	_params := map[string]interface{}{}
+	_params["_owner"] = owner
+	var _out *big.Int
+	_ret, _err := _r.score.Call("balanceOf", _params)
+	if _err != nil {
+		return _out, _err
+	}
+	if _ret != nil {
+		var _ok bool
+		if _out, _ok = _ret.(*big.Int); !_ok {
+			return _out, errors.InvalidStateError.Errorf("InvalidResult(method=balanceOf,ret=%v)", _ret)
+		}
+	}
+	return _out, nil
+}
+
+// Name calls read-only method name().
+func (_r *Token) Name() (string, error) {
+	_params := map[string]interface{}{}
+	var _out string
+	_ret, _err := _r.score.Call("name", _params)
+	if _err != nil {
+		return _out, _err
+	}
+	if _ret != nil {
+		var _ok bool
+		if _out, _ok = _ret.(string); !_ok {
+			return _out, errors.InvalidStateError.Errorf("InvalidResult(method=name,ret=%v)", _ret)
+		}
+	}
+	return _out, nil
+}
+
+// Transfer sends the transaction calling transfer(Address,int,bytes) and waits for the result.
+func (_r *Token) Transfer(_w module.Wallet, to module.Address, value *big.Int, data []byte) (*client.TransactionResult, error) {
+	_params := map[string]interface{}{}
+	_params["_to"] = to
+	_params["_value"] = value
+	if data != nil {
+		_params["_data"] = data
+	}
+	return _r.score.Invoke(_w, "transfer", _params, nil)
+}
+
+// Deposit sends the transaction calling deposit(str) and waits for the result.
+func (_r *Token) Deposit(_w module.Wallet, _value *big.Int, memo *string) (*client.TransactionResult, error) {
+	_params := map[string]interface{}{}
+	if memo != nil {
+		_params["memo"] = *memo
+	}
+	return _r.score.Invoke(_w, "deposit", _params, _value)
+}
+
+// Fallback transfers value to the SCORE and waits for the result.
+func (_r *Token) Fallback(_w module.Wallet, _value *big.Int) (*client.TransactionResult, error) {
+	return _r.score.Transfer(_w, _value)
+}
+
+// TokenTransfer is the event Transfer(Address,Address,int,bytes).
+type TokenTransfer struct {
+	From  module.Address
+	To    module.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// DecodeTransfer decodes the event log of Transfer(Address,Address,int,bytes).
+func (_r *Token) DecodeTransfer(_el *client.EventLog) (*TokenTransfer, error) {
+	_e, _err := _r.score.DecodeEventLog(_el)
+	if _err != nil {
+		return nil, _err
+	}
+	if _e.Signature != "Transfer(Address,Address,int,bytes)" {
+		return nil, errors.IllegalArgumentError.Errorf("SignatureMismatch(sig=%s)", _e.Signature)
+	}
+	_ev := new(TokenTransfer)
+	_ev.From, _ = _e.Params["_from"].(module.Address)
+	_ev.To, _ = _e.Params["_to"].(module.Address)
+	_ev.Value, _ = _e.Params["_value"].(*big.Int)
+	_ev.Data, _ = _e.Params["_data"].([]byte)
+	return _ev, nil
+}
+
+// FilterTransfer returns the events of Transfer(Address,Address,int,bytes) from the SCORE in the result.
+func (_r *Token) FilterTransfer(_tr *client.TransactionResult) ([]*TokenTransfer, error) {
+	var _evs []*TokenTransfer
+	for _i := range _tr.EventLogs {
+		_el := &_tr.EventLogs[_i]
+		if string(_el.Addr) != _r.score.Address().String() ||
+			len(_el.Indexed) == 0 || _el.Indexed[0] == nil || *_el.Indexed[0] != "Transfer(Address,Address,int,bytes)" {
+			continue
+		}
+		_ev, _err := _r.DecodeTransfer(_el)
+		if _err != nil {
+			return nil, _err
+		}
+		_evs = append(_evs, _ev)
+	}
+	return _evs, nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/server"
 	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/service"
 	"github.com/icon-project/goloop/service/eeproxy"
 )
 
@@ -134,9 +135,16 @@ func main() {
 	flag.IntVar(&cfg.ConcurrencyLevel, "concurrency", 1, "Maximum number of executors to be used for concurrency")
 	flag.IntVar(&cfg.NormalTxPoolSize, "normal_tx_pool", 0, "Normal transaction pool size")
 	flag.IntVar(&cfg.PatchTxPoolSize, "patch_tx_pool", 0, "Patch transaction pool size")
+	flag.StringVar(&cfg.TxPoolOrder, "tx_pool_order", service.TxPoolOrderDefault, "Order of transactions in the pool (fifo,fee)")
+	flag.IntVar(&cfg.TxPoolSenderLimit, "tx_pool_sender_limit", 0, "Maximum number of pending transactions of a sender (0: no limit)")
+	flag.StringVar(&cfg.TxPoolEviction, "tx_pool_eviction", service.TxPoolEvictionDefault, "Eviction policy on full pool (none,oldest,lowest_fee,largest_sender)")
 	flag.IntVar(&cfg.MaxBlockTxBytes, "max_block_tx_bytes", 0, "Maximum size of transactions in a block")
 	flag.StringVar(&cfg.NodeCache, "node_cache", chain.NodeCacheDefault, "Node cache (none,small,large)")
 	flag.BoolVar(&cfg.ValidateTxOnSend, "validate_tx_on_send", false, "Validate transaction on send")
+	flag.BoolVar(&cfg.EventIndex, "event_index", false, "Index event logs by SCORE address and signature")
+	flag.BoolVar(&cfg.Archive, "archive", false, "Archive mode keeping all the states (pruning is not allowed)")
+	flag.Int64Var(&cfg.StateGC, "state_gc", 0, "Number of the latest states kept by garbage collection of states (0: disable)")
+	flag.Int64Var(&cfg.StateGCInterval, "state_gc_interval", 0, "Number of blocks between garbage collections of states (0: uses system default value)")
 	cfg.ChildrenLimit = flag.Int("children_limit", -1, "Maximum number of child connections (-1: uses system default value)")
 	cfg.NephewsLimit = flag.Int("nephews_limit", -1, "Maximum number of nephew connections (-1: uses system default value)")
 	flag.StringVar(&cfg.LogLevel, "log_level", "debug", "Main log level")
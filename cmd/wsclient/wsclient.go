@@ -1,79 +1,56 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"log"
 	"net/url"
 	"os"
 	"os/signal"
-	"time"
 
-	"github.com/gorilla/websocket"
+	"github.com/icon-project/goloop/client"
 	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/server"
 )
 
 var addr = flag.String("addr", "localhost:9080", "http service address")
-
-type BlockRequest struct {
-	Height common.HexInt64
-}
+var channel = flag.String("channel", "default", "channel of the chain")
+var height = flag.Int64("height", 0, "height of the first block")
+var maxRetry = flag.Int("max_retry", 0, "maximum number of consecutive reconnections (0: no limit, -1: no reconnection)")
 
 func main() {
 	flag.Parse()
 	log.SetFlags(0)
 
+	cancelCh := make(chan bool)
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
-
-	u := url.URL{Scheme: "ws", Host: *addr, Path: "/api/v3/default/block"}
-	log.Printf("connecting to %s", u.String())
-
-	c, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
-	if err != nil {
-		log.Fatal("dial:", err)
-	}
-	defer c.Close()
-
-	var blockRequest BlockRequest
-	blockRequest.Height.Value = 0
-	err = c.WriteJSON(blockRequest)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	done := make(chan struct{})
-
 	go func() {
-		defer close(done)
-		for i:=0; ; i++ {
-			_, message, err := c.ReadMessage()
-			if err != nil {
-				log.Println("read:", err)
-				return
-			}
-			log.Printf("recv: %s\n", message)
-		}
+		<-interrupt
+		log.Println("interrupt")
+		close(cancelCh)
 	}()
 
-	for {
-		select {
-		case <-done:
-			return
-		case <-interrupt:
-			log.Println("interrupt")
+	u := url.URL{Scheme: "http", Host: *addr, Path: "/api/v3/" + *channel}
+	log.Printf("connecting to %s", u.String())
 
-			// Cleanly close the connection by sending a close message and then
-			// waiting (with timeout) for the server to close the connection.
-			err := c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c := client.NewClientV3(u.String())
+	req := &server.BlockRequest{Height: common.HexInt64{Value: *height}}
+	opts := &client.MonitorOptions{
+		MaxRetry: *maxRetry,
+		OnStatus: func(s client.MonitorStatus, err error) {
 			if err != nil {
-				log.Println("write close:", err)
-				return
+				log.Printf("%s: %v", s, err)
+			} else {
+				log.Printf("%s", s)
 			}
-			select {
-			case <-done:
-			case <-time.After(time.Second):
-			}
-			return
-		}
+		},
 	}
-}
\ No newline at end of file
+	err := c.MonitorBlockResumable(req, func(v *server.BlockNotification) {
+		message, _ := json.Marshal(v)
+		log.Printf("recv: %s\n", message)
+	}, opts, cancelCh)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
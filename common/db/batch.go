@@ -0,0 +1,283 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"sync"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// Batch collects writes to the buckets of the database, then it applies
+// them at once on Write. Once Write succeeds, the batch is cleared for reuse.
+type Batch interface {
+	Set(id BucketID, key []byte, value []byte) error
+	Delete(id BucketID, key []byte) error
+	Len() int
+	Write() error
+}
+
+// Batcher is implemented by the database applying the batch atomically.
+type Batcher interface {
+	NewBatch() Batch
+}
+
+// NewBatch returns a new batch for the database. If the database doesn't
+// implement Batcher, writes are applied to the buckets one by one.
+func NewBatch(database Database) Batch {
+	if b, ok := database.(Batcher); ok {
+		return b.NewBatch()
+	}
+	return &bucketBatch{database: database}
+}
+
+// batchOp is a write in the batch. nil value means deletion.
+type batchOp struct {
+	id    BucketID
+	key   []byte
+	value []byte
+}
+
+func (op *batchOp) applyTo(bk Bucket) error {
+	if op.value == nil {
+		return bk.Delete(op.key)
+	}
+	return bk.Set(op.key, op.value)
+}
+
+func (op *batchOp) addTo(batch Batch) error {
+	if op.value == nil {
+		return batch.Delete(op.id, op.key)
+	}
+	return batch.Set(op.id, op.key, op.value)
+}
+
+// batchOps keeps writes in order for emulating the batch.
+type batchOps []batchOp
+
+// checkBatchKey rejects empty keys, which are not supported by some
+// backends on writing the batch.
+func checkBatchKey(key []byte) error {
+	if len(key) == 0 {
+		return errors.IllegalArgumentError.New("EmptyKey")
+	}
+	return nil
+}
+
+func (ops *batchOps) Set(id BucketID, key []byte, value []byte) error {
+	if err := checkBatchKey(key); err != nil {
+		return err
+	}
+	*ops = append(*ops, batchOp{
+		id:    id,
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+	return nil
+}
+
+func (ops *batchOps) Delete(id BucketID, key []byte) error {
+	if err := checkBatchKey(key); err != nil {
+		return err
+	}
+	*ops = append(*ops, batchOp{
+		id:  id,
+		key: append([]byte{}, key...),
+	})
+	return nil
+}
+
+func (ops *batchOps) Len() int {
+	return len(*ops)
+}
+
+type bucketBatch struct {
+	database Database
+	batchOps
+}
+
+func (b *bucketBatch) Write() error {
+	for i := range b.batchOps {
+		op := &b.batchOps[i]
+		bk, err := b.database.GetBucket(op.id)
+		if err != nil {
+			return err
+		}
+		if err := op.applyTo(bk); err != nil {
+			return err
+		}
+	}
+	b.batchOps = nil
+	return nil
+}
+
+// BatchDB is a database collecting writes to its buckets with a batch
+// of the real database until Write. Pending writes are visible through
+// its buckets.
+type BatchDB interface {
+	Database
+	Write() error
+}
+
+type batchBucket struct {
+	id   BucketID
+	db   *batchDB
+	real Bucket
+}
+
+func (bk *batchBucket) Get(key []byte) ([]byte, error) {
+	if value, ok := bk.db.pendingOf(bk.id, key); ok {
+		return value, nil
+	}
+	return bk.real.Get(key)
+}
+
+func (bk *batchBucket) Has(key []byte) (bool, error) {
+	if value, ok := bk.db.pendingOf(bk.id, key); ok {
+		return value != nil, nil
+	}
+	return bk.real.Has(key)
+}
+
+func (bk *batchBucket) Set(key []byte, value []byte) error {
+	return bk.db.set(bk.id, key, value)
+}
+
+func (bk *batchBucket) Delete(key []byte) error {
+	return bk.db.delete(bk.id, key)
+}
+
+type batchDB struct {
+	lock    sync.Mutex
+	real    Database
+	batch   Batch
+	pending map[BucketID]map[string][]byte
+	buckets map[BucketID]*batchBucket
+}
+
+func (bdb *batchDB) GetBucket(id BucketID) (Bucket, error) {
+	bdb.lock.Lock()
+	defer bdb.lock.Unlock()
+
+	if bk, ok := bdb.buckets[id]; ok {
+		return bk, nil
+	}
+	real, err := bdb.real.GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	bk := &batchBucket{
+		id:   id,
+		db:   bdb,
+		real: real,
+	}
+	bdb.buckets[id] = bk
+	return bk, nil
+}
+
+func (bdb *batchDB) pendingOf(id BucketID, key []byte) ([]byte, bool) {
+	bdb.lock.Lock()
+	defer bdb.lock.Unlock()
+
+	value, ok := bdb.pending[id][string(key)]
+	return value, ok
+}
+
+func (bdb *batchDB) set(id BucketID, key []byte, value []byte) error {
+	bdb.lock.Lock()
+	defer bdb.lock.Unlock()
+
+	if err := bdb.batch.Set(id, key, value); err != nil {
+		return err
+	}
+	bdb.pendingFor(id)[string(key)] = append([]byte{}, value...)
+	return nil
+}
+
+func (bdb *batchDB) delete(id BucketID, key []byte) error {
+	bdb.lock.Lock()
+	defer bdb.lock.Unlock()
+
+	if err := bdb.batch.Delete(id, key); err != nil {
+		return err
+	}
+	bdb.pendingFor(id)[string(key)] = nil
+	return nil
+}
+
+func (bdb *batchDB) pendingFor(id BucketID) map[string][]byte {
+	if p, ok := bdb.pending[id]; ok {
+		return p
+	}
+	p := make(map[string][]byte)
+	bdb.pending[id] = p
+	return p
+}
+
+func (bdb *batchDB) Write() error {
+	bdb.lock.Lock()
+	defer bdb.lock.Unlock()
+
+	if bdb.batch.Len() == 0 {
+		return nil
+	}
+	if err := bdb.batch.Write(); err != nil {
+		return err
+	}
+	bdb.pending = make(map[BucketID]map[string][]byte)
+	return nil
+}
+
+func (bdb *batchDB) Close() error {
+	return nil
+}
+
+type batchDBContext struct {
+	BatchDB
+	flags Flags
+}
+
+func (c *batchDBContext) WithFlags(flags Flags) Context {
+	newFlags := c.flags.Merged(flags)
+	return &batchDBContext{c.BatchDB, newFlags}
+}
+
+func (c *batchDBContext) GetFlag(name string) interface{} {
+	return c.flags.Get(name)
+}
+
+func (c *batchDBContext) Flags() Flags {
+	return c.flags.Clone()
+}
+
+func (bdb *batchDB) WithFlags(flags Flags) Context {
+	return &batchDBContext{bdb, flags}
+}
+
+func NewBatchDB(database Database) BatchDB {
+	bdb := &batchDB{
+		real:    database,
+		batch:   NewBatch(database),
+		pending: make(map[BucketID]map[string][]byte),
+		buckets: make(map[BucketID]*batchBucket),
+	}
+	if ctx, ok := database.(Context); ok {
+		return &batchDBContext{bdb, ctx.Flags()}
+	} else {
+		return bdb
+	}
+}
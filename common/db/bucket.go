@@ -62,6 +62,10 @@ const (
 
 	// ChainProperty is general key value map for chain property.
 	ChainProperty BucketID = "C"
+
+	// EventIndex maps (height, transaction index) of event logs from
+	// sha3(score address, event signature).
+	EventIndex BucketID = "E"
 )
 
 // internalKey returns key prefixed with the bucket's id.
@@ -0,0 +1,378 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const (
+	// collectorScanSize is the number of entries scanned before removing
+	// garbage entries found.
+	collectorScanSize = 10000
+)
+
+// markValue is stored for marked keys. Some backends can't tell an empty
+// value from a missing one.
+var markValue = []byte{1}
+
+// Collector is a database removing garbage entries of the buckets whose
+// keys are hashes of their values (like MerkleTrie and BytesByHash).
+//
+// Entries to keep are marked in separate databases by Marker while the
+// database is in use, then Sweep removes the others. Keys written to the
+// buckets through the collector are marked by Track, so entries written
+// while collecting are never removed even though they are not reachable
+// from the structures marked.
+type Collector struct {
+	lock    sync.RWMutex
+	real    Database
+	written Database
+	prev    Database
+}
+
+func (c *Collector) GetBucket(id BucketID) (Bucket, error) {
+	bk, err := c.real.GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	if id.Hasher() == nil {
+		return bk, nil
+	}
+	return &collectorBucket{id: id, c: c, real: bk}, nil
+}
+
+func (c *Collector) Close() error {
+	return c.real.Close()
+}
+
+func (c *Collector) NewBatch() Batch {
+	return &collectorBatch{c: c, Batch: NewBatch(c.real)}
+}
+
+// Track starts marking keys written to the buckets into marks. Keys marked
+// by the previous call are regarded as written until the next call, then
+// their marks are returned to be released by the caller. Track(nil) stops
+// marking written keys.
+func (c *Collector) Track(marks Database) Database {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	old := c.prev
+	c.prev, c.written = c.written, marks
+	return old
+}
+
+// markWritten marks the key written to the bucket. It should be called
+// with the read lock.
+func (c *Collector) markWritten(id BucketID, key []byte) error {
+	if c.written == nil {
+		return nil
+	}
+	bk, err := c.written.GetBucket(id)
+	if err != nil {
+		return err
+	}
+	return bk.Set(key, markValue)
+}
+
+// isWritten returns whether the key is written since the previous call of
+// Track. It should be called with the lock.
+func (c *Collector) isWritten(id BucketID, key []byte) (bool, error) {
+	for _, marks := range []Database{c.written, c.prev} {
+		if marks == nil {
+			continue
+		}
+		if ok, err := isMarked(marks, id, key); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func isMarked(marks Database, id BucketID, key []byte) (bool, error) {
+	bk, err := marks.GetBucket(id)
+	if err != nil {
+		return false, err
+	}
+	return bk.Has(key)
+}
+
+func isMarkedIn(marks []Database, id BucketID, key []byte) (bool, error) {
+	for _, m := range marks {
+		if ok, err := isMarked(m, id, key); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// NewMarker returns a marker marking entries of the collector into marks.
+// Entries marked in marks or bases are regarded as marked already.
+func (c *Collector) NewMarker(marks Database, bases ...Database) *Marker {
+	return &Marker{
+		real:  c.real,
+		marks: marks,
+		bases: append([]Database{marks}, bases...),
+	}
+}
+
+// Sweep removes entries of the bucket which are not marked in marks nor
+// written since the previous call of Track. Only entries whose keys are
+// hashes of their values are removed, so entries of other buckets sharing
+// the key space with the bucket are kept. on is called with the numbers of
+// scanned and removed entries and the bytes of removed entries after each
+// step, and sweeping stops with the error returned by on.
+//
+// Backends sharing the key space among buckets (like GoLevelDB and Pebble)
+// iterate entries of all the buckets for MerkleTrie whose id is empty, so
+// sweeping it scans the whole database. Entries whose keys are not in the
+// size of the hash are skipped without hashing their values.
+func (c *Collector) Sweep(id BucketID, marks []Database, on func(scanned, removed, bytes int64) error) error {
+	hasher := id.Hasher()
+	if hasher == nil {
+		return errors.IllegalArgumentError.Errorf("NotHashedBucket(id=%q)", id)
+	}
+	bk, err := c.real.GetBucket(id)
+	if err != nil {
+		return err
+	}
+	hashSize := len(hasher.Hash(nil))
+	var start []byte
+	for {
+		var keys [][]byte
+		var sizes []int64
+		var next []byte
+		var scanned int64
+		var ierr error
+		err := Iterate(bk, nil, start, func(key []byte, value []byte) bool {
+			if scanned >= collectorScanSize {
+				next = append([]byte{}, key...)
+				return false
+			}
+			scanned += 1
+			if len(key) != hashSize || !bytes.Equal(hasher.Hash(value), key) {
+				return true
+			}
+			if ok, err := isMarkedIn(marks, id, key); err != nil {
+				ierr = err
+				return false
+			} else if !ok {
+				keys = append(keys, append([]byte{}, key...))
+				sizes = append(sizes, int64(len(key)+len(value)))
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if ierr != nil {
+			return ierr
+		}
+		removed, size, err := c.remove(id, keys, sizes)
+		if err != nil {
+			return err
+		}
+		if on != nil {
+			if err := on(scanned, removed, size); err != nil {
+				return err
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		start = next
+	}
+}
+
+// remove removes the entries unless they are written after they are
+// scanned. Writers are blocked while removing them, so an entry written
+// again is marked before or written after the removal.
+func (c *Collector) remove(id BucketID, keys [][]byte, sizes []int64) (int64, int64, error) {
+	if len(keys) == 0 {
+		return 0, 0, nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var removed, size int64
+	batch := NewBatch(c.real)
+	for i, key := range keys {
+		if ok, err := c.isWritten(id, key); err != nil {
+			return 0, 0, err
+		} else if ok {
+			continue
+		}
+		if err := batch.Delete(id, key); err != nil {
+			return 0, 0, err
+		}
+		removed += 1
+		size += sizes[i]
+	}
+	if err := batch.Write(); err != nil {
+		return 0, 0, err
+	}
+	return removed, size, nil
+}
+
+func NewCollector(database Database) *Collector {
+	return &Collector{real: database}
+}
+
+//----------------------------------------
+// Bucket
+
+var _ Iterable = (*collectorBucket)(nil)
+
+type collectorBucket struct {
+	id   BucketID
+	c    *Collector
+	real Bucket
+}
+
+func (bk *collectorBucket) Get(key []byte) ([]byte, error) {
+	return bk.real.Get(key)
+}
+
+func (bk *collectorBucket) Has(key []byte) (bool, error) {
+	return bk.real.Has(key)
+}
+
+func (bk *collectorBucket) Set(key []byte, value []byte) error {
+	bk.c.lock.RLock()
+	defer bk.c.lock.RUnlock()
+
+	if err := bk.c.markWritten(bk.id, key); err != nil {
+		return err
+	}
+	return bk.real.Set(key, value)
+}
+
+func (bk *collectorBucket) Delete(key []byte) error {
+	return bk.real.Delete(key)
+}
+
+func (bk *collectorBucket) Iterate(start, limit []byte, f func(key []byte, value []byte) bool) error {
+	if ib, ok := bk.real.(Iterable); ok {
+		return ib.Iterate(start, limit, f)
+	}
+	return errors.UnsupportedError.New("NotIterableBucket")
+}
+
+//----------------------------------------
+// Batch
+
+type collectorBatch struct {
+	c *Collector
+	Batch
+}
+
+// Set marks the key before it's written. The entry may be removed before
+// Write, but it's written again by Write.
+func (b *collectorBatch) Set(id BucketID, key []byte, value []byte) error {
+	if id.Hasher() != nil {
+		b.c.lock.RLock()
+		defer b.c.lock.RUnlock()
+
+		if err := b.c.markWritten(id, key); err != nil {
+			return err
+		}
+	}
+	return b.Batch.Set(id, key, value)
+}
+
+//----------------------------------------
+// Marker
+
+// Marker is a database used as the destination of merkle.CopyContext for
+// marking entries reachable from the structures copied into it. It returns
+// values of the collector for marked keys only, so the structures under
+// marked entries are skipped. After Stop, it fails with ErrInterrupted.
+type Marker struct {
+	real    Database
+	marks   Database
+	bases   []Database
+	stopped int32
+}
+
+func (m *Marker) GetBucket(id BucketID) (Bucket, error) {
+	bk, err := m.real.GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	return &markerBucket{id: id, m: m, real: bk}, nil
+}
+
+func (m *Marker) Close() error {
+	return nil
+}
+
+// Stop makes following accesses fail, so that copying into the marker
+// stops soon.
+func (m *Marker) Stop() {
+	atomic.StoreInt32(&m.stopped, 1)
+}
+
+func (m *Marker) isMarked(id BucketID, key []byte) (bool, error) {
+	if atomic.LoadInt32(&m.stopped) != 0 {
+		return false, errors.ErrInterrupted
+	}
+	if id.Hasher() == nil {
+		return false, nil
+	}
+	return isMarkedIn(m.bases, id, key)
+}
+
+type markerBucket struct {
+	id   BucketID
+	m    *Marker
+	real Bucket
+}
+
+func (bk *markerBucket) Get(key []byte) ([]byte, error) {
+	if ok, err := bk.m.isMarked(bk.id, key); err != nil || !ok {
+		return nil, err
+	}
+	return bk.real.Get(key)
+}
+
+func (bk *markerBucket) Has(key []byte) (bool, error) {
+	return bk.m.isMarked(bk.id, key)
+}
+
+// Set marks the key. Entries of the buckets not collected are ignored.
+func (bk *markerBucket) Set(key []byte, value []byte) error {
+	if atomic.LoadInt32(&bk.m.stopped) != 0 {
+		return errors.ErrInterrupted
+	}
+	if bk.id.Hasher() == nil {
+		return nil
+	}
+	marks, err := bk.m.marks.GetBucket(bk.id)
+	if err != nil {
+		return err
+	}
+	return marks.Set(key, markValue)
+}
+
+func (bk *markerBucket) Delete(key []byte) error {
+	return nil
+}
@@ -20,6 +20,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
 )
 
 func testDatabase_GetSetDelete(t *testing.T, creator dbCreator) {
@@ -150,3 +153,327 @@ func TestDatabase_SetReopenGet(t *testing.T) {
 		})
 	}
 }
+
+func testDatabase_Batch(t *testing.T, creator dbCreator) {
+	dir := t.TempDir()
+	testDB, err := creator("test", dir)
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	key := []byte("hello")
+	key2 := []byte("hell")
+	value := []byte("world")
+
+	bk1, err := testDB.GetBucket("hello")
+	assert.NoError(t, err)
+	bk2, err := testDB.GetBucket(BytesByHash)
+	assert.NoError(t, err)
+	assert.NoError(t, bk1.Set(key2, value))
+
+	batch := NewBatch(testDB)
+	assert.NoError(t, batch.Set("hello", key, value))
+	assert.NoError(t, batch.Set(BytesByHash, key, value))
+	assert.NoError(t, batch.Delete("hello", key2))
+	assert.Equal(t, 3, batch.Len())
+
+	// empty keys are rejected
+	err = batch.Set("hello", nil, value)
+	assert.True(t, errors.IllegalArgumentError.Equals(err), "err=%+v", err)
+	err = batch.Delete("hello", []byte{})
+	assert.True(t, errors.IllegalArgumentError.Equals(err), "err=%+v", err)
+	assert.Equal(t, 3, batch.Len())
+
+	// nothing is applied before write
+	has, err := bk1.Has(key)
+	assert.NoError(t, err)
+	assert.False(t, has)
+	has, err = bk1.Has(key2)
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	assert.NoError(t, batch.Write())
+	assert.Equal(t, 0, batch.Len())
+
+	stored, err := bk1.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, stored)
+	stored, err = bk2.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, stored)
+	has, err = bk1.Has(key2)
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestDatabase_Batch(t *testing.T) {
+	for name, be := range backends {
+		t.Run(string(name), func(t *testing.T) {
+			testDatabase_Batch(t, be)
+		})
+	}
+	t.Run("layerdb", func(t *testing.T) {
+		var creator dbCreator = func(name string, dir string) (Database, error) {
+			origin := NewMapDB()
+			return NewLayerDB(origin), nil
+		}
+		testDatabase_Batch(t, creator)
+	})
+	t.Run("emulated", func(t *testing.T) {
+		var creator dbCreator = func(name string, dir string) (Database, error) {
+			pdb := NewProxyDB()
+			err := pdb.SetReal(NewMapDB())
+			return pdb, err
+		}
+		testDatabase_Batch(t, creator)
+	})
+}
+
+func TestLayerDB_FlushWithBatch(t *testing.T) {
+	origin := NewMapDB()
+	ldb := NewLayerDB(origin)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	batch := NewBatch(ldb)
+	assert.NoError(t, batch.Set(BytesByHash, key, value))
+	assert.NoError(t, batch.Write())
+
+	obk, err := origin.GetBucket(BytesByHash)
+	assert.NoError(t, err)
+	has, err := obk.Has(key)
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	assert.NoError(t, ldb.Flush(true))
+	stored, err := obk.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, stored)
+
+	// after flush, batch is applied to the origin directly
+	assert.NoError(t, batch.Delete(BytesByHash, key))
+	assert.NoError(t, batch.Write())
+	has, err = obk.Has(key)
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestBatchDB(t *testing.T) {
+	origin := NewMapDB()
+	bdb := NewBatchDB(WithFlags(origin, Flags{"test": 1}))
+	assert.Equal(t, 1, GetFlag(bdb, "test"))
+
+	key := []byte("hello")
+	key2 := []byte("hell")
+	value := []byte("world")
+
+	obk, err := origin.GetBucket(ChainProperty)
+	assert.NoError(t, err)
+	assert.NoError(t, obk.Set(key2, value))
+
+	bk, err := bdb.GetBucket(ChainProperty)
+	assert.NoError(t, err)
+	assert.NoError(t, bk.Set(key, value))
+	assert.NoError(t, bk.Delete(key2))
+
+	// pending writes are visible only through the batch database
+	stored, err := bk.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, stored)
+	has, err := bk.Has(key2)
+	assert.NoError(t, err)
+	assert.False(t, has)
+	has, err = obk.Has(key)
+	assert.NoError(t, err)
+	assert.False(t, has)
+	has, err = obk.Has(key2)
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	assert.NoError(t, bdb.Write())
+	stored, err = obk.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, stored)
+	has, err = obk.Has(key2)
+	assert.NoError(t, err)
+	assert.False(t, has)
+	stored, err = bk.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, stored)
+}
+
+func testDatabase_Iterate(t *testing.T, creator dbCreator) {
+	dir := t.TempDir()
+	testDB, err := creator("test", dir)
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	bk, err := testDB.GetBucket(BytesByHash)
+	assert.NoError(t, err)
+	obk, err := testDB.GetBucket(ChainProperty)
+	assert.NoError(t, err)
+
+	keys := []string{"a", "b1", "b2", "b3", "c"}
+	for _, k := range keys {
+		assert.NoError(t, bk.Set([]byte(k), []byte("v"+k)))
+	}
+	assert.NoError(t, obk.Set([]byte("b0"), []byte("other")))
+
+	collect := func(prefix, start string, max int) []string {
+		var res []string
+		err := Iterate(bk, []byte(prefix), []byte(start), func(key []byte, value []byte) bool {
+			assert.Equal(t, "v"+string(key), string(value))
+			res = append(res, string(key))
+			return len(res) < max
+		})
+		assert.NoError(t, err)
+		return res
+	}
+	assert.Equal(t, keys, collect("", "", 10))
+	assert.Equal(t, []string{"b1", "b2", "b3"}, collect("b", "", 10))
+	assert.Equal(t, []string{"b2", "b3"}, collect("b", "b2", 10))
+	assert.Equal(t, []string{"b1", "b2", "b3"}, collect("b", "a", 10))
+	assert.Equal(t, []string{"b1", "b2"}, collect("b", "", 2))
+	assert.Empty(t, collect("d", "", 10))
+}
+
+func TestDatabase_Iterate(t *testing.T) {
+	for name, be := range backends {
+		t.Run(string(name), func(t *testing.T) {
+			testDatabase_Iterate(t, be)
+		})
+	}
+}
+
+func TestPrefixRange(t *testing.T) {
+	start, limit := PrefixRange([]byte{0x01, 0xff})
+	assert.Equal(t, []byte{0x01, 0xff}, start)
+	assert.Equal(t, []byte{0x02}, limit)
+
+	_, limit = PrefixRange([]byte{0xff, 0xff})
+	assert.Nil(t, limit)
+
+	_, limit = PrefixRange(nil)
+	assert.Nil(t, limit)
+}
+
+func TestPebbleDB_ImportFromGoLevelDB(t *testing.T) {
+	dir := t.TempDir()
+	src, err := NewGoLevelDB("src", dir)
+	assert.NoError(t, err)
+	defer src.Close()
+
+	key := []byte("hello")
+	value := []byte("world")
+	buckets := []BucketID{MerkleTrie, BytesByHash, ChainProperty}
+	for _, id := range buckets {
+		bk, err := src.GetBucket(id)
+		assert.NoError(t, err)
+		assert.NoError(t, bk.Set(key, value))
+	}
+
+	dst, err := NewPebbleDB("dst", dir)
+	assert.NoError(t, err)
+	defer dst.Close()
+
+	var entries int64
+	err = dst.ImportFromGoLevelDB(src, func(n, bytes int64) error {
+		entries = n
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(buckets), entries)
+
+	for _, id := range buckets {
+		bk, err := dst.GetBucket(id)
+		assert.NoError(t, err)
+		stored, err := bk.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, value, stored)
+	}
+}
+
+func testCollector(t *testing.T, creator dbCreator) {
+	dir := t.TempDir()
+	testDB, err := creator("test", dir)
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	c := NewCollector(testDB)
+	bk, err := c.GetBucket(MerkleTrie)
+	assert.NoError(t, err)
+	obk, err := c.GetBucket(ChainProperty)
+	assert.NoError(t, err)
+
+	set := func(v string) []byte {
+		key := crypto.SHA3Sum256([]byte(v))
+		assert.NoError(t, bk.Set(key, []byte(v)))
+		return key
+	}
+	has := func(key []byte) bool {
+		ok, err := bk.Has(key)
+		assert.NoError(t, err)
+		return ok
+	}
+	keep := set("keep")
+	drop := set("drop")
+	assert.NoError(t, obk.Set([]byte("prop"), []byte("value")))
+
+	// entries are written with the batch while marking
+	c.Track(NewMapDB())
+	batch := c.NewBatch()
+	written := crypto.SHA3Sum256([]byte("written"))
+	assert.NoError(t, batch.Set(MerkleTrie, written, []byte("written")))
+	assert.NoError(t, batch.Write())
+
+	marks := NewMapDB()
+	marker := c.NewMarker(marks)
+	mbk, err := marker.GetBucket(MerkleTrie)
+	assert.NoError(t, err)
+	assert.NoError(t, mbk.Set(keep, []byte("keep")))
+	value, err := mbk.Get(keep)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("keep"), value)
+	value, err = mbk.Get(drop)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	var removed, size int64
+	err = c.Sweep(MerkleTrie, []Database{marks}, func(s, r, b int64) error {
+		removed += r
+		size += b
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, removed)
+	assert.EqualValues(t, len(drop)+len("drop"), size)
+	assert.True(t, has(keep))
+	assert.False(t, has(drop))
+	assert.True(t, has(written))
+
+	ok, err := obk.Has([]byte("prop"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// written entries are kept until the second Track
+	c.Track(nil)
+	assert.NoError(t, c.Sweep(MerkleTrie, []Database{marks}, nil))
+	assert.True(t, has(written))
+	c.Track(nil)
+	assert.NoError(t, c.Sweep(MerkleTrie, []Database{marks}, nil))
+	assert.False(t, has(written))
+	assert.True(t, has(keep))
+
+	marker.Stop()
+	_, err = mbk.Get(keep)
+	assert.Error(t, err)
+	assert.Error(t, c.Sweep(ChainProperty, nil, nil))
+}
+
+func TestCollector(t *testing.T) {
+	for name, be := range backends {
+		t.Run(string(name), func(t *testing.T) {
+			testCollector(t, be)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 const GoLevelDBBackend BackendType = "goleveldb"
@@ -65,10 +66,15 @@ func (db *GoLevelDB) Close() error {
 	return db.db.Close()
 }
 
+func (db *GoLevelDB) NewBatch() Batch {
+	return &goLevelBatch{db: db.db}
+}
+
 //----------------------------------------
 // GetBucket
 
 var _ Bucket = (*goLevelBucket)(nil)
+var _ Iterable = (*goLevelBucket)(nil)
 
 type goLevelBucket struct {
 	id BucketID
@@ -95,3 +101,62 @@ func (bucket *goLevelBucket) Set(key []byte, value []byte) error {
 func (bucket *goLevelBucket) Delete(key []byte) error {
 	return bucket.db.Delete(internalKey(bucket.id, key), nil)
 }
+
+// Iterate iterates entries of the bucket. Buckets share the key space,
+// so that it includes entries of other buckets for MerkleTrie whose id
+// is empty.
+func (bucket *goLevelBucket) Iterate(start, limit []byte, f func(key []byte, value []byte) bool) error {
+	r := util.BytesPrefix([]byte(bucket.id))
+	if len(start) > 0 {
+		r.Start = internalKey(bucket.id, start)
+	}
+	if len(limit) > 0 {
+		r.Limit = internalKey(bucket.id, limit)
+	}
+	itr := bucket.db.NewIterator(r, nil)
+	defer itr.Release()
+	for itr.Next() {
+		if !f(itr.Key()[len(bucket.id):], itr.Value()) {
+			break
+		}
+	}
+	return itr.Error()
+}
+
+//----------------------------------------
+// Batch
+
+var _ Batch = (*goLevelBatch)(nil)
+
+type goLevelBatch struct {
+	db    *leveldb.DB
+	batch leveldb.Batch
+}
+
+func (b *goLevelBatch) Set(id BucketID, key []byte, value []byte) error {
+	if err := checkBatchKey(key); err != nil {
+		return err
+	}
+	b.batch.Put(internalKey(id, key), value)
+	return nil
+}
+
+func (b *goLevelBatch) Delete(id BucketID, key []byte) error {
+	if err := checkBatchKey(key); err != nil {
+		return err
+	}
+	b.batch.Delete(internalKey(id, key))
+	return nil
+}
+
+func (b *goLevelBatch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *goLevelBatch) Write() error {
+	if err := b.db.Write(&b.batch, nil); err != nil {
+		return err
+	}
+	b.batch.Reset()
+	return nil
+}
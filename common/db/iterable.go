@@ -0,0 +1,57 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// Iterable is implemented by the bucket supporting iteration.
+type Iterable interface {
+	// Iterate calls f with entries of the bucket whose keys are in
+	// [start, limit) in ascending order of the keys. Empty start or limit
+	// means no bound. It stops if f returns false. The key and the value
+	// are valid only until f returns.
+	Iterate(start, limit []byte, f func(key []byte, value []byte) bool) error
+}
+
+// PrefixRange returns the range of the keys having the prefix.
+func PrefixRange(prefix []byte) (start, limit []byte) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if c := prefix[i]; c < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			break
+		}
+	}
+	return prefix, limit
+}
+
+// Iterate calls f with entries of the bucket having the prefix in ascending
+// order of the keys. It starts from the start if it's not empty.
+func Iterate(bk Bucket, prefix, start []byte, f func(key []byte, value []byte) bool) error {
+	ib, ok := bk.(Iterable)
+	if !ok {
+		return errors.UnsupportedError.New("NotIterableBucket")
+	}
+	from, limit := PrefixRange(prefix)
+	if len(start) > 0 && string(start) > string(from) {
+		from = start
+	}
+	return ib.Iterate(from, limit, f)
+}
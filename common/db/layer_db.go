@@ -1,6 +1,7 @@
 package db
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -8,6 +9,7 @@ import (
 
 type layerBucket struct {
 	lock sync.Mutex
+	id   BucketID
 	data map[string][]byte
 	real Bucket
 }
@@ -66,27 +68,32 @@ func (bk *layerBucket) Delete(key []byte) error {
 	}
 }
 
-func (bk *layerBucket) Flush(write bool) error {
+// collect adds pending writes of the bucket to the batch.
+func (bk *layerBucket) collect(batch Batch) error {
 	bk.lock.Lock()
 	defer bk.lock.Unlock()
 
-	if write && bk.data != nil {
-		for k, v := range bk.data {
-			if v == nil {
-				if err := bk.real.Delete([]byte(k)); err != nil {
-					return err
-				}
-			} else {
-				if err := bk.real.Set([]byte(k), v); err != nil {
-					return err
-				}
-			}
+	for k, v := range bk.data {
+		var err error
+		if v == nil {
+			err = batch.Delete(bk.id, []byte(k))
+		} else {
+			err = batch.Set(bk.id, []byte(k), v)
+		}
+		if err != nil {
+			return err
 		}
 	}
-	bk.data = nil
 	return nil
 }
 
+func (bk *layerBucket) drop() {
+	bk.lock.Lock()
+	defer bk.lock.Unlock()
+
+	bk.data = nil
+}
+
 type layerDB struct {
 	lock sync.Mutex
 
@@ -99,6 +106,10 @@ func (ldb *layerDB) GetBucket(id BucketID) (Bucket, error) {
 	ldb.lock.Lock()
 	defer ldb.lock.Unlock()
 
+	return ldb.getBucket(id)
+}
+
+func (ldb *layerDB) getBucket(id BucketID) (Bucket, error) {
 	if bk, ok := ldb.buckets[string(id)]; ok {
 		return bk, nil
 	}
@@ -111,6 +122,7 @@ func (ldb *layerDB) GetBucket(id BucketID) (Bucket, error) {
 		return realbk, nil
 	}
 	bk := &layerBucket{
+		id:   id,
 		data: make(map[string][]byte),
 		real: realbk,
 	}
@@ -118,19 +130,34 @@ func (ldb *layerDB) GetBucket(id BucketID) (Bucket, error) {
 	return bk, nil
 }
 
+// Flush writes pending data to the real database with a batch if write
+// is true, then it passes all requests to the real database.
 func (ldb *layerDB) Flush(write bool) error {
 	ldb.lock.Lock()
 	defer ldb.lock.Unlock()
 
-	for _, bk := range ldb.buckets {
-		if err := bk.Flush(write); err != nil {
+	if write && !ldb.flushed {
+		batch := NewBatch(ldb.real)
+		for _, bk := range ldb.buckets {
+			if err := bk.collect(batch); err != nil {
+				return err
+			}
+		}
+		if err := batch.Write(); err != nil {
 			return err
 		}
 	}
+	for _, bk := range ldb.buckets {
+		bk.drop()
+	}
 	ldb.flushed = true
 	return nil
 }
 
+func (ldb *layerDB) NewBatch() Batch {
+	return &layerBatch{ldb: ldb}
+}
+
 func (ldb *layerDB) Close() error {
 	return nil
 }
@@ -153,6 +180,10 @@ func (c *layerDBContext) Flags() Flags {
 	return c.flags.Clone()
 }
 
+func (c *layerDBContext) NewBatch() Batch {
+	return NewBatch(c.LayerDB)
+}
+
 func (ldb *layerDB) WithFlags(flags Flags) Context {
 	return &layerDBContext{ldb, flags}
 }
@@ -168,3 +199,56 @@ func NewLayerDB(database Database) LayerDB {
 		return ldb
 	}
 }
+
+// layerBatch applies writes to the layer while it holds locks of all
+// related buckets. After flush, writes are applied to the real database
+// with its batch.
+type layerBatch struct {
+	ldb *layerDB
+	batchOps
+}
+
+func (b *layerBatch) Write() error {
+	ldb := b.ldb
+	ldb.lock.Lock()
+	defer ldb.lock.Unlock()
+
+	if ldb.flushed {
+		batch := NewBatch(ldb.real)
+		for _, op := range b.batchOps {
+			if err := op.addTo(batch); err != nil {
+				return err
+			}
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		b.batchOps = nil
+		return nil
+	}
+
+	ids := make([]string, 0)
+	bks := make(map[BucketID]*layerBucket)
+	for _, op := range b.batchOps {
+		if _, ok := bks[op.id]; ok {
+			continue
+		}
+		bk, err := ldb.getBucket(op.id)
+		if err != nil {
+			return err
+		}
+		bks[op.id] = bk.(*layerBucket)
+		ids = append(ids, string(op.id))
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		bk := bks[BucketID(id)]
+		bk.lock.Lock()
+		defer bk.lock.Unlock()
+	}
+	for _, op := range b.batchOps {
+		bks[op.id].data[string(op.key)] = op.value
+	}
+	b.batchOps = nil
+	return nil
+}
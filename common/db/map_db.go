@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -34,6 +35,7 @@ func NewMapDB() Database {
 // DB
 
 var _ Database = (*mapDatabase)(nil)
+var _ Batcher = (*mapDatabase)(nil)
 
 type mapDatabase struct {
 	lock sync.Mutex
@@ -45,25 +47,81 @@ func (t *mapDatabase) GetBucket(id BucketID) (Bucket, error) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
+	return t.getBucket(id), nil
+}
+
+func (t *mapDatabase) getBucket(id BucketID) *mapBucket {
 	if bk, ok := t.bks[id]; ok {
-		return bk, nil
+		return bk
 	}
 	bk := &mapBucket{
 		id:   fmt.Sprintf("%s:%s", t.name, id),
 		real: make(map[string]string),
 	}
 	t.bks[id] = bk
-	return bk, nil
+	return bk
 }
 
 func (t *mapDatabase) Close() error {
 	return nil
 }
 
+func (t *mapDatabase) NewBatch() Batch {
+	return &mapBatch{database: t}
+}
+
+//----------------------------------------
+// Batch
+
+// mapBatch applies writes while it holds locks of all related buckets,
+// so that others can't see the partial result.
+type mapBatch struct {
+	database *mapDatabase
+	batchOps
+}
+
+func (b *mapBatch) Write() error {
+	t := b.database
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ids := make([]string, 0)
+	bks := make(map[BucketID]*mapBucket)
+	for _, op := range b.batchOps {
+		if len(op.key) == 0 {
+			return errors.Errorf("Illegal Key:%x", op.key)
+		}
+		if _, ok := bks[op.id]; !ok {
+			bks[op.id] = t.getBucket(op.id)
+			ids = append(ids, string(op.id))
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		bk := bks[BucketID(id)]
+		bk.mutex.Lock()
+		defer bk.mutex.Unlock()
+	}
+	for _, op := range b.batchOps {
+		bk := bks[op.id]
+		if configLogMapDB {
+			log.Printf("mapBucket[%s].Batch(%x,%x)", bk.id, op.key, op.value)
+		}
+		if op.value == nil {
+			delete(bk.real, string(op.key))
+		} else {
+			bk.real[string(op.key)] = string(op.value)
+		}
+	}
+	b.batchOps = nil
+	return nil
+}
+
 //----------------------------------------
 // Bucket
 
 var _ Bucket = (*mapBucket)(nil)
+var _ Iterable = (*mapBucket)(nil)
 
 type mapBucket struct {
 	id    string
@@ -111,6 +169,29 @@ func (t *mapBucket) Set(k, v []byte) error {
 	return nil
 }
 
+func (t *mapBucket) Iterate(start, limit []byte, f func(key []byte, value []byte) bool) error {
+	t.mutex.Lock()
+	keys := make([]string, 0, len(t.real))
+	for k := range t.real {
+		if k >= string(start) && (len(limit) == 0 || k < string(limit)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = t.real[k]
+	}
+	t.mutex.Unlock()
+
+	for i, k := range keys {
+		if !f([]byte(k), []byte(values[i])) {
+			break
+		}
+	}
+	return nil
+}
+
 func (t *mapBucket) Delete(k []byte) error {
 	if configLogMapDB {
 		log.Printf("mapBucket[%s].Delete(%x)", t.id, k)
@@ -0,0 +1,231 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+)
+
+const PebbleDBBackend BackendType = "pebble"
+
+// pebbleImportBatchSize is the size of the batch for importing entries.
+const pebbleImportBatchSize = 4 * 1024 * 1024
+
+func init() {
+	dbCreator := func(name string, dir string) (Database, error) {
+		return NewPebbleDB(name, dir)
+	}
+	registerDBCreator(PebbleDBBackend, dbCreator, false)
+}
+
+func NewPebbleDB(name string, dir string) (*PebbleDB, error) {
+	return NewPebbleDBWithOpts(name, dir, nil)
+}
+
+func NewPebbleDBWithOpts(name string, dir string, o *pebble.Options) (*PebbleDB, error) {
+	dbPath := filepath.Join(dir, name)
+	db, err := pebble.Open(dbPath, o)
+	if err != nil {
+		return nil, err
+	}
+	database := &PebbleDB{
+		db:      db,
+		buckets: make(map[BucketID]Bucket),
+	}
+	return database, nil
+}
+
+//----------------------------------------
+// Database
+
+var _ Database = (*PebbleDB)(nil)
+var _ Batcher = (*PebbleDB)(nil)
+
+// PebbleDB uses the same key layout as GoLevelDB. Keys of the buckets are
+// prefixed with their IDs in a key space.
+type PebbleDB struct {
+	lock    sync.Mutex
+	db      *pebble.DB
+	buckets map[BucketID]Bucket
+}
+
+func (db *PebbleDB) GetBucket(id BucketID) (Bucket, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if bk, ok := db.buckets[id]; ok {
+		return bk, nil
+	} else {
+		bk = &pebbleBucket{
+			id: id,
+			db: db.db,
+		}
+		db.buckets[id] = bk
+		return bk, nil
+	}
+}
+
+func (db *PebbleDB) Close() error {
+	return db.db.Close()
+}
+
+func (db *PebbleDB) NewBatch() Batch {
+	return &pebbleBatch{db: db.db}
+}
+
+// ImportFromGoLevelDB copies all entries of the source into the database.
+// They share the key layout, so entries are copied as they are. on is
+// called with the number of entries and bytes copied after each batch, and
+// copying stops with the error returned by on.
+func (db *PebbleDB) ImportFromGoLevelDB(src *GoLevelDB, on func(entries, bytes int64) error) error {
+	itr := src.db.NewIterator(nil, nil)
+	defer itr.Release()
+
+	var entries, bytes int64
+	batch := db.db.NewBatch()
+	defer func() {
+		batch.Close()
+	}()
+	commit := func() error {
+		if err := batch.Commit(pebble.NoSync); err != nil {
+			return err
+		}
+		batch.Close()
+		batch = db.db.NewBatch()
+		if on != nil {
+			return on(entries, bytes)
+		}
+		return nil
+	}
+	for itr.Next() {
+		if err := batch.Set(itr.Key(), itr.Value(), nil); err != nil {
+			return err
+		}
+		entries += 1
+		bytes += int64(len(itr.Key()) + len(itr.Value()))
+		if len(batch.Repr()) >= pebbleImportBatchSize {
+			if err := commit(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	if err := commit(); err != nil {
+		return err
+	}
+	return db.db.Flush()
+}
+
+//----------------------------------------
+// Bucket
+
+var _ Bucket = (*pebbleBucket)(nil)
+var _ Iterable = (*pebbleBucket)(nil)
+
+type pebbleBucket struct {
+	id BucketID
+	db *pebble.DB
+}
+
+func (bucket *pebbleBucket) Get(key []byte) ([]byte, error) {
+	value, closer, err := bucket.db.Get(internalKey(bucket.id, key))
+	if err == pebble.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return append([]byte{}, value...), nil
+}
+
+func (bucket *pebbleBucket) Has(key []byte) (bool, error) {
+	_, closer, err := bucket.db.Get(internalKey(bucket.id, key))
+	if err == pebble.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	closer.Close()
+	return true, nil
+}
+
+func (bucket *pebbleBucket) Set(key []byte, value []byte) error {
+	return bucket.db.Set(internalKey(bucket.id, key), value, pebble.NoSync)
+}
+
+func (bucket *pebbleBucket) Delete(key []byte) error {
+	return bucket.db.Delete(internalKey(bucket.id, key), pebble.NoSync)
+}
+
+// Iterate iterates entries of the bucket. Like GoLevelDB, it includes
+// entries of other buckets for MerkleTrie whose id is empty.
+func (bucket *pebbleBucket) Iterate(start, limit []byte, f func(key []byte, value []byte) bool) error {
+	o := new(pebble.IterOptions)
+	o.LowerBound, o.UpperBound = PrefixRange([]byte(bucket.id))
+	if len(start) > 0 {
+		o.LowerBound = internalKey(bucket.id, start)
+	}
+	if len(limit) > 0 {
+		o.UpperBound = internalKey(bucket.id, limit)
+	}
+	itr := bucket.db.NewIter(o)
+	for valid := itr.First(); valid; valid = itr.Next() {
+		if !f(itr.Key()[len(bucket.id):], itr.Value()) {
+			break
+		}
+	}
+	return itr.Close()
+}
+
+//----------------------------------------
+// Batch
+
+var _ Batch = (*pebbleBatch)(nil)
+
+// pebbleBatch keeps writes until Write like rocksBatch, so that it doesn't
+// need to release the native batch if it's dropped.
+type pebbleBatch struct {
+	db *pebble.DB
+	batchOps
+}
+
+func (b *pebbleBatch) Write() error {
+	batch := b.db.NewBatch()
+	defer batch.Close()
+
+	for _, op := range b.batchOps {
+		var err error
+		if op.value == nil {
+			err = batch.Delete(internalKey(op.id, op.key), nil)
+		} else {
+			err = batch.Set(internalKey(op.id, op.key), op.value, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.Commit(pebble.NoSync); err != nil {
+		return err
+	}
+	b.batchOps = nil
+	return nil
+}
@@ -20,6 +20,7 @@
 package db
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"path"
@@ -246,6 +247,49 @@ func (db *RocksDB) deleteValue(cf *C.rocksdb_column_family_handle_t, k []byte) e
 	return nil
 }
 
+func (db *RocksDB) NewBatch() Batch {
+	return &rocksBatch{db: db}
+}
+
+// rocksBatch keeps writes until Write, so that it doesn't need to release
+// the native write batch if it's dropped.
+type rocksBatch struct {
+	db *RocksDB
+	batchOps
+}
+
+func (b *rocksBatch) Write() error {
+	wb := C.rocksdb_writebatch_create()
+	defer C.rocksdb_writebatch_destroy(wb)
+
+	for _, op := range b.batchOps {
+		bk, err := b.db.GetBucket(op.id)
+		if err != nil {
+			return err
+		}
+		cf := bk.(*RocksBucket).cf
+		cKey := (*C.char)(unsafe.Pointer(&op.key[0]))
+		if op.value == nil {
+			C.rocksdb_writebatch_delete_cf(wb, cf, cKey, C.size_t(len(op.key)))
+			continue
+		}
+		var cValue *C.char
+		if len(op.value) > 0 {
+			cValue = (*C.char)(unsafe.Pointer(&op.value[0]))
+		}
+		C.rocksdb_writebatch_put_cf(wb, cf, cKey, C.size_t(len(op.key)), cValue, C.size_t(len(op.value)))
+	}
+
+	var cErr *C.char
+	C.rocksdb_write(b.db.db, b.db.wo, wb, &cErr)
+	if cErr != nil {
+		defer C.rocksdb_free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	b.batchOps = nil
+	return nil
+}
+
 type RocksBucket struct {
 	cf *C.rocksdb_column_family_handle_t
 	db *RocksDB
@@ -266,3 +310,35 @@ func (b *RocksBucket) Set(key []byte, value []byte) error {
 func (b *RocksBucket) Delete(key []byte) error {
 	return b.db.deleteValue(b.cf, key)
 }
+
+func (b *RocksBucket) Iterate(start, limit []byte, f func(key []byte, value []byte) bool) error {
+	itr := C.rocksdb_create_iterator_cf(b.db.db, b.db.ro, b.cf)
+	defer C.rocksdb_iter_destroy(itr)
+
+	if len(start) > 0 {
+		C.rocksdb_iter_seek(itr, (*C.char)(unsafe.Pointer(&start[0])), C.size_t(len(start)))
+	} else {
+		C.rocksdb_iter_seek_to_first(itr)
+	}
+	for ; C.rocksdb_iter_valid(itr) != 0; C.rocksdb_iter_next(itr) {
+		var cKeyLen, cValLen C.size_t
+		cKey := C.rocksdb_iter_key(itr, &cKeyLen)
+		key := C.GoBytes(unsafe.Pointer(cKey), C.int(cKeyLen))
+		if len(limit) > 0 && bytes.Compare(key, limit) >= 0 {
+			break
+		}
+		cValue := C.rocksdb_iter_value(itr, &cValLen)
+		value := C.GoBytes(unsafe.Pointer(cValue), C.int(cValLen))
+		if !f(key, value) {
+			break
+		}
+	}
+
+	var cErr *C.char
+	C.rocksdb_iter_get_error(itr, &cErr)
+	if cErr != nil {
+		defer C.rocksdb_free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
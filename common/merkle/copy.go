@@ -13,6 +13,7 @@ type CopyContext struct {
 	builder Builder
 	src     db.Database
 	dst     db.Database
+	batch   db.BatchDB
 	stopped uint32
 }
 
@@ -58,6 +59,10 @@ func (e *CopyContext) Run() error {
 				break
 			}
 		}
+		// Write copied items of the row at once.
+		if err := e.batch.Write(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -98,9 +103,11 @@ func (e *CopyContext) TargetDB() db.Database {
 }
 
 func NewCopyContext(src db.Database, dst db.Database) *CopyContext {
+	batch := db.NewBatchDB(dst)
 	return &CopyContext{
-		builder: NewBuilderWithRawDatabase(dst),
+		builder: NewBuilderWithRawDatabase(batch),
 		src:     src,
 		dst:     dst,
+		batch:   batch,
 	}
 }
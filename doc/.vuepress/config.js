@@ -27,6 +27,7 @@ module.exports = {
                 children: [
                     '/jsonrpc_v3',
                     '/btp_extension',
+                    ['/rosetta_api', "Rosetta API"],
                 ]
             },
             {
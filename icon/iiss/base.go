@@ -404,6 +404,10 @@ func (tx *baseV3) Nonce() *big.Int {
 	return nil
 }
 
+func (tx *baseV3) StepLimit() *big.Int {
+	return nil
+}
+
 func (tx *baseV3) To() module.Address {
 	return state.SystemAddress
 }
@@ -260,6 +260,18 @@ func (sm *ServiceManager) HasTransaction(id []byte) bool {
 	return false
 }
 
+func (sm *ServiceManager) GetPendingTransactions(g module.TransactionGroup, from module.Address, skip, limit int) ([]module.PendingTransaction, int) {
+	return nil, 0
+}
+
+func (sm *ServiceManager) GetPoolStatus(g module.TransactionGroup) (int, int) {
+	return 0, 0
+}
+
+func (sm *ServiceManager) SubscribeTransactionPool(size int) (<-chan module.TransactionPoolEvent, func(), error) {
+	return nil, nil, errors.ErrInvalidState
+}
+
 func (sm *ServiceManager) SendTransactionAndWait(result []byte, height int64, tx interface{}) ([]byte, <-chan interface{}, error) {
 	return nil, nil, errors.ErrInvalidState
 }
@@ -280,6 +292,10 @@ func (sm *ServiceManager) ExecuteTransaction(result []byte, vh []byte, js []byte
 	return nil, errors.ErrInvalidState
 }
 
+func (sm *ServiceManager) SimulateTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, module.StateDiff, error) {
+	return nil, nil, errors.ErrInvalidState
+}
+
 func (sm *ServiceManager) AddSyncRequest(id db.BucketID, key []byte) error {
 	return errors.ErrInvalidState
 }
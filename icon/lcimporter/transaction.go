@@ -94,6 +94,10 @@ func (tx *BlockTransaction) Nonce() *big.Int {
 	return nil
 }
 
+func (tx *BlockTransaction) StepLimit() *big.Int {
+	return nil
+}
+
 func (tx *BlockTransaction) To() module.Address {
 	return nil
 }
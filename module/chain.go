@@ -26,6 +26,9 @@ type Chain interface {
 	ConcurrencyLevel() int
 	NormalTxPoolSize() int
 	PatchTxPoolSize() int
+	TxPoolOrder() string
+	TxPoolSenderLimit() int
+	TxPoolEviction() string
 	MaxBlockTxBytes() int
 	DefaultWaitTimeout() time.Duration
 	MaxWaitTimeout() time.Duration
@@ -33,6 +36,8 @@ type Chain interface {
 	ChildrenLimit() int
 	NephewsLimit() int
 	ValidateTxOnSend() bool
+	EventIndex() bool
+	Archive() bool
 	Genesis() []byte
 	GenesisStorage() GenesisStorage
 	CommitVoteSetDecoder() CommitVoteSetDecoder
@@ -63,7 +68,11 @@ type Chain interface {
 	// height and the function cleans up database and file systems for the chain
 	// and prepare pruned genesis block of the height.
 	Reset(gs string, height int64, blockHash []byte) error
-	Verify() error
+
+	// Verify verifies blocks from the height to the last. If height == 0,
+	// it starts from the genesis. If execute is true, it also re-executes
+	// transactions to compare the results.
+	Verify(height int64, execute bool) error
 
 	MetricContext() context.Context
 	Logger() log.Logger
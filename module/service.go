@@ -3,6 +3,7 @@ package module
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/icon-project/goloop/common/db"
 )
@@ -175,6 +176,11 @@ type Receipt interface {
 	GetProofOfEvent(int) ([][]byte, error)
 }
 
+// StateDiff is changes of the state by a transaction.
+type StateDiff interface {
+	ToJSON(version JSONVersion) (interface{}, error)
+}
+
 type ReceiptIterator interface {
 	Has() bool
 	Next() error
@@ -291,6 +297,36 @@ type TransitionManager interface {
 	WaitForTransaction(parent Transition, bi BlockInfo, cb func()) bool
 }
 
+// PendingTransaction is information of a transaction in the pool.
+type PendingTransaction struct {
+	ID       []byte
+	From     Address
+	Nonce    *big.Int
+	Size     int
+	Received time.Time
+}
+
+type TransactionPoolEventType int
+
+const (
+	// TransactionAccepted is for the transaction added to the pool.
+	TransactionAccepted TransactionPoolEventType = iota
+	// TransactionDropped is for the transaction dropped from the pool
+	// without being committed.
+	TransactionDropped
+)
+
+// TransactionPoolEvent is a notification about a change of the pool.
+// Error is the reason of the drop for TransactionDropped. JSON returns the
+// JSON object of Tx, which is encoded once and shared by all subscribers,
+// so it must not be modified.
+type TransactionPoolEvent struct {
+	Type  TransactionPoolEventType
+	Tx    Transaction
+	Error error
+	JSON  func() (interface{}, error)
+}
+
 type ServiceManager interface {
 	TransitionManager
 
@@ -362,6 +398,22 @@ type ServiceManager interface {
 	// HasTransaction returns whether it has specified transaction in the pool
 	HasTransaction(id []byte) bool
 
+	// GetPendingTransactions returns transactions in the pool of the group.
+	// If from is not nil, it returns transactions of the sender only.
+	// It skips the first skip transactions and returns up to limit
+	// transactions along with the total number of matching transactions.
+	GetPendingTransactions(g TransactionGroup, from Address, skip, limit int) ([]PendingTransaction, int)
+
+	// GetPoolStatus returns the size and the number of used entries of
+	// the pool of the group.
+	GetPoolStatus(g TransactionGroup) (size int, used int)
+
+	// SubscribeTransactionPool returns a channel for events of the pools
+	// and a function to cancel the subscription. The channel is closed
+	// on cancel, or if the subscriber doesn't consume events in time and
+	// size events are pending.
+	SubscribeTransactionPool(size int) (<-chan TransactionPoolEvent, func(), error)
+
 	// SendTransactionAndWait send transaction and return channel for result
 	SendTransactionAndWait(result []byte, height int64, tx interface{}) ([]byte, <-chan interface{}, error)
 
@@ -381,6 +433,11 @@ type ServiceManager interface {
 	// It ignores supplied step limit.
 	ExecuteTransaction(result []byte, vh []byte, js []byte, bi BlockInfo) (Receipt, error)
 
+	// SimulateTransaction executes the transaction like ExecuteTransaction.
+	// Then it returns the expected result of the transaction and the
+	// changes of the state by the transaction.
+	SimulateTransaction(result []byte, vh []byte, js []byte, bi BlockInfo) (Receipt, StateDiff, error)
+
 	// AddSyncRequest add sync request for specified data.
 	AddSyncRequest(id db.BucketID, key []byte) error
 }
@@ -41,6 +41,7 @@ const (
 	TraceModeNone TraceMode = iota
 	TraceModeInvoke
 	TraceModeBalanceChange
+	TraceModeCallTree
 )
 
 type OpType int
@@ -81,3 +82,21 @@ type TraceCallback interface {
 	OnFrameExit(success bool) error
 	OnBalanceChange(opType OpType, from, to Address, amount *big.Int) error
 }
+
+// FrameCall is the call made by a frame.
+type FrameCall struct {
+	From   Address
+	To     Address
+	Value  *big.Int
+	Method string
+	Params interface{}
+}
+
+// CallTreeCallback is implemented by TraceCallback for TraceModeCallTree.
+// Details of the current frame are passed between OnFrameEnter and
+// OnFrameExit.
+type CallTreeCallback interface {
+	OnFrameCall(call *FrameCall) error
+	OnFrameResult(stepUsed *big.Int, result interface{}, reason error) error
+	OnFrameEvent(addr Address, indexed, data [][]byte) error
+}
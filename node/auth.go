@@ -32,6 +32,11 @@ type Auth struct {
 	prefix string
 	SkipIfEmptyUsers bool
 	mtx   sync.Mutex
+
+	actions   map[string]map[string]string
+	roles     map[string][]string
+	userRoles map[string]string
+	rolesPath string
 }
 
 func (a *Auth) MiddlewareFunc() echo.MiddlewareFunc {
@@ -144,8 +149,12 @@ func (a *Auth) validator(s string, ctx echo.Context) (b bool, err error) {
 	defer a.mtx.Unlock()
 	if id, ok := a.addrs[addr]; ok {
 		if ts := a.users[id]; ts < timestamp {
-			a.users[id] = timestamp
 			log.Traceln("valid signature", ts, timestamp)
+			if !a._permitted(id, ctx) {
+				log.Traceln("not permitted", id, a._roleOf(id), a._actionOf(ctx))
+				return false, echo.NewHTTPError(http.StatusForbidden, "not permitted")
+			}
+			a.users[id] = timestamp
 			return true, nil
 		}
 		log.Traceln("old signature", a.users[id], timestamp)
@@ -159,6 +168,23 @@ func (a *Auth) AddUser(id string) error {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
+	first := len(a.users) == 0
+	if err := a._addUser(id); err != nil {
+		return err
+	}
+	if err := a._export(); err != nil {
+		panic(err)
+	}
+	if first {
+		a.userRoles[id] = RoleAdmin
+		if err := a._exportRoles(); err != nil {
+			panic(err)
+		}
+	}
+	return nil
+}
+
+func (a *Auth) _addUser(id string) error {
 	if _, ok := a.users[id]; ok {
 		return errors.Wrapf(ErrAlreadyExists, "User(id=%s) already exists", id)
 	}
@@ -174,9 +200,6 @@ func (a *Auth) AddUser(id string) error {
 
 	a.users[id] = time.Now().Unix()
 	a.addrs[addr.String()] = id
-	if err := a._export(); err != nil {
-		panic(err)
-	}
 	return nil
 }
 
@@ -200,6 +223,12 @@ func (a *Auth) RemoveUser(id string) error {
 	if err := a._export(); err != nil {
 		panic(err)
 	}
+	if _, ok := a.userRoles[id]; ok {
+		delete(a.userRoles, id)
+		if err := a._exportRoles(); err != nil {
+			panic(err)
+		}
+	}
 	return nil
 }
 
@@ -238,13 +267,17 @@ func (a *Auth) _export() error {
 	return nil
 }
 
-func NewAuth(filePath, prefix string) *Auth {
+func NewAuth(filePath, rolesPath, prefix string) *Auth {
 	a := &Auth{
 		skips: make(map[string]map[string]bool),
 		users: make(map[string]int64),
 		addrs: make(map[string]string),
 		filePath: filePath,
 		prefix: prefix,
+		actions:   make(map[string]map[string]string),
+		roles:     make(map[string][]string),
+		userRoles: make(map[string]string),
+		rolesPath: rolesPath,
 	}
 	if a.filePath != "" {
 		if _, err := os.Stat(filePath); err != nil {
@@ -262,11 +295,14 @@ func NewAuth(filePath, prefix string) *Auth {
 				panic(err)
 			}
 			for _, user := range users {
-				if err = a.AddUser(user); err != nil {
+				if err = a._addUser(user); err != nil {
 					panic(err)
 				}
 			}
 		}
 	}
+	if err := a._importRoles(); err != nil {
+		panic(err)
+	}
 	return a
 }
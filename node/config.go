@@ -24,6 +24,7 @@ type StaticConfig struct {
 	P2PListenAddr string `json:"p2p_listen"`
 	RPCAddr       string `json:"rpc_addr"`
 	RPCDump       bool   `json:"rpc_dump"`
+	GRPCAddr      string `json:"grpc_addr,omitempty"`
 	EESocket      string `json:"ee_socket"`
 	Engines       string `json:"engines"`
 	BackupDir     string `json:"backup_dir"`
@@ -111,10 +112,22 @@ type RuntimeConfig struct {
 	RPCIncludeDebug   bool   `json:"rpcIncludeDebug"`
 	RPCRosetta        bool   `json:"rpcRosetta"`
 	RPCBatchLimit     int    `json:"rpcBatchLimit"`
+	RPCRateLimitRead  int    `json:"rpcRateLimitRead"`
+	RPCRateLimitSend  int    `json:"rpcRateLimitSend"`
+	RPCRateLimitDebug int    `json:"rpcRateLimitDebug"`
+	RPCAPIKeys        string `json:"rpcAPIKeys,omitempty"`
 
 	FilePath string `json:"-"` // absolute path
 }
 
+// redacted returns a copy of the configuration without the API keys, which
+// shouldn't be exposed through the admin API.
+func (c *RuntimeConfig) redacted() *RuntimeConfig {
+	rcfg := *c
+	rcfg.RPCAPIKeys = ""
+	return &rcfg
+}
+
 func (c *RuntimeConfig) load() error {
 	log.Println("load ", c.FilePath)
 	if _, err := os.Stat(c.FilePath); err != nil {
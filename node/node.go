@@ -21,6 +21,7 @@ import (
 	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/server"
 	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/service"
 	"github.com/icon-project/goloop/service/eeproxy"
 )
 
@@ -391,29 +392,37 @@ func (n *Node) JoinChain(
 	cfgFile, _ := filepath.Abs(path.Join(chainDir, ChainConfigFileName))
 
 	cfg := &chain.Config{
-		NID:              nid,
-		DBType:           p.DBType,
-		Platform:         p.Platform,
-		Channel:          channel,
-		SecureSuites:     p.SecureSuites,
-		SecureAeads:      p.SecureAeads,
-		SeedAddr:         p.SeedAddr,
-		Role:             p.Role,
-		GenesisStorage:   genesisStorage,
-		ConcurrencyLevel: p.ConcurrencyLevel,
-		NormalTxPoolSize: p.NormalTxPoolSize,
-		PatchTxPoolSize:  p.PatchTxPoolSize,
-		MaxBlockTxBytes:  p.MaxBlockTxBytes,
-		NodeCache:        p.NodeCache,
-		DefWaitTimeout:   p.DefWaitTimeout,
-		MaxWaitTimeout:   p.MaxWaitTimeout,
-		TxTimeout:        p.TxTimeout,
-		AutoStart:        p.AutoStart,
-		FilePath:         cfgFile,
-		NIDForP2P:        n.cfg.NIDForP2P,
-		ChildrenLimit:    p.ChildrenLimit,
-		NephewsLimit:     p.NephewsLimit,
-		ValidateTxOnSend: p.ValidateTxOnSend,
+		NID:                nid,
+		DBType:             p.DBType,
+		Platform:           p.Platform,
+		Channel:            channel,
+		SecureSuites:       p.SecureSuites,
+		SecureAeads:        p.SecureAeads,
+		SeedAddr:           p.SeedAddr,
+		Role:               p.Role,
+		GenesisStorage:     genesisStorage,
+		ConcurrencyLevel:   p.ConcurrencyLevel,
+		NormalTxPoolSize:   p.NormalTxPoolSize,
+		PatchTxPoolSize:    p.PatchTxPoolSize,
+		TxPoolOrder:        p.TxPoolOrder,
+		TxPoolSenderLimit:  p.TxPoolSenderLimit,
+		TxPoolEviction:     p.TxPoolEviction,
+		MaxBlockTxBytes:    p.MaxBlockTxBytes,
+		NodeCache:          p.NodeCache,
+		DefWaitTimeout:     p.DefWaitTimeout,
+		MaxWaitTimeout:     p.MaxWaitTimeout,
+		TxTimeout:          p.TxTimeout,
+		AutoStart:          p.AutoStart,
+		FilePath:           cfgFile,
+		NIDForP2P:          n.cfg.NIDForP2P,
+		ChildrenLimit:      p.ChildrenLimit,
+		NephewsLimit:       p.NephewsLimit,
+		ValidateTxOnSend:   p.ValidateTxOnSend,
+		EventIndex:         p.EventIndex,
+		Archive:            p.Archive,
+		StateGC:            p.StateGC,
+		StateGCInterval:    p.StateGCInterval,
+		StateGCCheckpoints: p.StateGCCheckpoints,
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -495,7 +504,7 @@ func (n *Node) ResetChain(cid int, height int64, blockHash []byte) error {
 	return c.Reset(gs, height, blockHash)
 }
 
-func (n *Node) VerifyChain(cid int) error {
+func (n *Node) VerifyChain(cid int, height int64, execute bool) error {
 	defer n.mtx.RUnlock()
 	n.mtx.RLock()
 
@@ -503,7 +512,7 @@ func (n *Node) VerifyChain(cid int) error {
 	if err != nil {
 		return err
 	}
-	return c.Verify()
+	return c.Verify(height, execute)
 }
 
 func (n *Node) ImportChain(cid int, s string, height int64) error {
@@ -688,6 +697,22 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 			} else {
 				c.cfg.PatchTxPoolSize = intVal
 			}
+		case "txPoolOrder":
+			if !service.IsTxPoolOrder(value) {
+				return errors.Errorf("InvalidTxPoolOrder(%s)", value)
+			}
+			c.cfg.TxPoolOrder = value
+		case "txPoolSenderLimit":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.TxPoolSenderLimit = intVal
+			}
+		case "txPoolEviction":
+			if !service.IsTxPoolEviction(value) {
+				return errors.Errorf("InvalidTxPoolEviction(%s)", value)
+			}
+			c.cfg.TxPoolEviction = value
 		case "maxBlockTxBytes":
 			if intVal, err := strconv.Atoi(value); err != nil {
 				return errors.Wrapf(err, "invalid value type")
@@ -767,6 +792,12 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 			} else {
 				c.cfg.ValidateTxOnSend = bc
 			}
+		case "eventIndex":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.EventIndex = bc
+			}
 		default:
 			return errors.Errorf("not found key %s", key)
 		}
@@ -886,6 +917,21 @@ func (n *Node) Configure(key string, value string) error {
 			n.rcfg.RPCBatchLimit = intVal
 		}
 		n.srv.SetBatchLimit(n.rcfg.RPCBatchLimit)
+	case "rpcRateLimitRead":
+		if err := n.configureRateLimit(&n.rcfg.RPCRateLimitRead, server.RateClassRead, value); err != nil {
+			return err
+		}
+	case "rpcRateLimitSend":
+		if err := n.configureRateLimit(&n.rcfg.RPCRateLimitSend, server.RateClassSend, value); err != nil {
+			return err
+		}
+	case "rpcRateLimitDebug":
+		if err := n.configureRateLimit(&n.rcfg.RPCRateLimitDebug, server.RateClassDebug, value); err != nil {
+			return err
+		}
+	case "rpcAPIKeys":
+		n.rcfg.RPCAPIKeys = value
+		n.srv.SetRateLimitAPIKeys(splitAPIKeys(n.rcfg.RPCAPIKeys))
 	default:
 		return errors.Errorf("not found key")
 	}
@@ -895,6 +941,29 @@ func (n *Node) Configure(key string, value string) error {
 	return nil
 }
 
+func (n *Node) configureRateLimit(limit *int, class server.RateClass, value string) error {
+	intVal, err := strconv.Atoi(value)
+	if err != nil {
+		return errors.Wrapf(err, "invalid value type")
+	}
+	if intVal < 0 {
+		return errors.Errorf("negative rate limit")
+	}
+	*limit = intVal
+	n.srv.SetRateLimit(class, *limit)
+	return nil
+}
+
+func splitAPIKeys(s string) []string {
+	var keys []string
+	for _, k := range strings.Split(s, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
 func NewNode(
 	w module.Wallet,
 	cfg *StaticConfig,
@@ -920,6 +989,12 @@ func NewNode(
 	srv := server.NewManager(
 		cfg.RPCAddr, cfg.RPCDump,
 		rcfg.RPCIncludeDebug, rcfg.RPCRosetta, rcfg.RPCDefaultChannel, rcfg.RPCBatchLimit, w, l)
+	srv.SetRateLimit(server.RateClassRead, rcfg.RPCRateLimitRead)
+	srv.SetRateLimit(server.RateClassSend, rcfg.RPCRateLimitSend)
+	srv.SetRateLimit(server.RateClassDebug, rcfg.RPCRateLimitDebug)
+	srv.SetRateLimitAPIKeys(splitAPIKeys(rcfg.RPCAPIKeys))
+	srv.SetGRPCAddr(cfg.GRPCAddr)
+	srv.SetVersion(cfg.BuildVersion)
 
 	ee, err := eeproxy.AllocEngines(l, strings.Split(cfg.Engines, ",")...)
 	if err != nil {
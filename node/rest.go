@@ -36,11 +36,17 @@ const (
 	UrlChainRes = "/:" + ParamCID
 	ParamID     = "id"
 	UrlUserRes  = "/:" + ParamID
+	UrlRole     = "/role"
+	ParamRole   = "role"
+	UrlRoleRes  = "/:" + ParamRole
 	TaskID      = "task"
 
 	UrlDB    = "/db"
 	ParamBK  = "bucket"
 	ParamKey = "key"
+
+	DefaultBucketListCount = 100
+	MaxBucketListCount     = 1000
 )
 
 type Rest struct {
@@ -61,6 +67,16 @@ type SystemView struct {
 	Config interface{} `json:"config"`
 }
 
+type BucketEntryView struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type BucketListView struct {
+	Entries []BucketEntryView `json:"entries"`
+	Next    string            `json:"next,omitempty"`
+}
+
 type StatsView struct {
 	Chains    []map[string]interface{} `json:"chains"`
 	Timestamp time.Time                `json:"timestamp"`
@@ -84,25 +100,33 @@ type ChainInspectView struct {
 }
 
 type ChainConfig struct {
-	DBType           string `json:"dbType"`
-	Platform         string `json:"platform"`
-	SeedAddr         string `json:"seedAddress"`
-	Role             uint   `json:"role"`
-	ConcurrencyLevel int    `json:"concurrencyLevel,omitempty"`
-	NormalTxPoolSize int    `json:"normalTxPool,omitempty"`
-	PatchTxPoolSize  int    `json:"patchTxPool,omitempty"`
-	MaxBlockTxBytes  int    `json:"maxBlockTxBytes,omitempty"`
-	NodeCache        string `json:"nodeCache,omitempty"`
-	Channel          string `json:"channel"`
-	SecureSuites     string `json:"secureSuites"`
-	SecureAeads      string `json:"secureAeads"`
-	DefWaitTimeout   int64  `json:"defaultWaitTimeout"`
-	MaxWaitTimeout   int64  `json:"maxWaitTimeout"`
-	TxTimeout        int64  `json:"txTimeout"`
-	AutoStart        bool   `json:"autoStart"`
-	ChildrenLimit    *int   `json:"childrenLimit,omitempty"`
-	NephewsLimit     *int   `json:"nephewsLimit,omitempty"`
-	ValidateTxOnSend bool   `json:"validateTxOnSend,omitempty"`
+	DBType             string  `json:"dbType"`
+	Platform           string  `json:"platform"`
+	SeedAddr           string  `json:"seedAddress"`
+	Role               uint    `json:"role"`
+	ConcurrencyLevel   int     `json:"concurrencyLevel,omitempty"`
+	NormalTxPoolSize   int     `json:"normalTxPool,omitempty"`
+	PatchTxPoolSize    int     `json:"patchTxPool,omitempty"`
+	TxPoolOrder        string  `json:"txPoolOrder,omitempty"`
+	TxPoolSenderLimit  int     `json:"txPoolSenderLimit,omitempty"`
+	TxPoolEviction     string  `json:"txPoolEviction,omitempty"`
+	MaxBlockTxBytes    int     `json:"maxBlockTxBytes,omitempty"`
+	NodeCache          string  `json:"nodeCache,omitempty"`
+	Channel            string  `json:"channel"`
+	SecureSuites       string  `json:"secureSuites"`
+	SecureAeads        string  `json:"secureAeads"`
+	DefWaitTimeout     int64   `json:"defaultWaitTimeout"`
+	MaxWaitTimeout     int64   `json:"maxWaitTimeout"`
+	TxTimeout          int64   `json:"txTimeout"`
+	AutoStart          bool    `json:"autoStart"`
+	ChildrenLimit      *int    `json:"childrenLimit,omitempty"`
+	NephewsLimit       *int    `json:"nephewsLimit,omitempty"`
+	ValidateTxOnSend   bool    `json:"validateTxOnSend,omitempty"`
+	EventIndex         bool    `json:"eventIndex,omitempty"`
+	Archive            bool    `json:"archive,omitempty"`
+	StateGC            int64   `json:"stateGC,omitempty"`
+	StateGCInterval    int64   `json:"stateGCInterval,omitempty"`
+	StateGCCheckpoints []int64 `json:"stateGCCheckpoints,omitempty"`
 }
 
 type ChainResetParam struct {
@@ -120,6 +144,11 @@ type ChainPruneParam struct {
 	Height int64  `json:"height"`
 }
 
+type ChainVerifyParam struct {
+	Height  int64 `json:"height,omitempty"`
+	Execute bool  `json:"execute,omitempty"`
+}
+
 type ChainBackupParam struct {
 	Manual bool `json:"manual,omitempty"`
 }
@@ -166,25 +195,33 @@ func NewChainInspectView(c *Chain) *ChainInspectView {
 
 func NewChainConfig(cfg *chain.Config) *ChainConfig {
 	v := &ChainConfig{
-		DBType:           cfg.DBType,
-		Platform:         cfg.Platform,
-		SeedAddr:         cfg.SeedAddr,
-		Role:             cfg.Role,
-		ConcurrencyLevel: cfg.ConcurrencyLevel,
-		NormalTxPoolSize: cfg.NormalTxPoolSize,
-		PatchTxPoolSize:  cfg.PatchTxPoolSize,
-		MaxBlockTxBytes:  cfg.MaxBlockTxBytes,
-		NodeCache:        cfg.NodeCache,
-		Channel:          cfg.Channel,
-		SecureSuites:     cfg.SecureSuites,
-		SecureAeads:      cfg.SecureAeads,
-		DefWaitTimeout:   cfg.DefWaitTimeout,
-		MaxWaitTimeout:   cfg.MaxWaitTimeout,
-		TxTimeout:        cfg.TxTimeout,
-		AutoStart:        cfg.AutoStart,
-		ChildrenLimit:    cfg.ChildrenLimit,
-		NephewsLimit:     cfg.NephewsLimit,
-		ValidateTxOnSend: cfg.ValidateTxOnSend,
+		DBType:             cfg.DBType,
+		Platform:           cfg.Platform,
+		SeedAddr:           cfg.SeedAddr,
+		Role:               cfg.Role,
+		ConcurrencyLevel:   cfg.ConcurrencyLevel,
+		NormalTxPoolSize:   cfg.NormalTxPoolSize,
+		PatchTxPoolSize:    cfg.PatchTxPoolSize,
+		TxPoolOrder:        cfg.TxPoolOrder,
+		TxPoolSenderLimit:  cfg.TxPoolSenderLimit,
+		TxPoolEviction:     cfg.TxPoolEviction,
+		MaxBlockTxBytes:    cfg.MaxBlockTxBytes,
+		NodeCache:          cfg.NodeCache,
+		Channel:            cfg.Channel,
+		SecureSuites:       cfg.SecureSuites,
+		SecureAeads:        cfg.SecureAeads,
+		DefWaitTimeout:     cfg.DefWaitTimeout,
+		MaxWaitTimeout:     cfg.MaxWaitTimeout,
+		TxTimeout:          cfg.TxTimeout,
+		AutoStart:          cfg.AutoStart,
+		ChildrenLimit:      cfg.ChildrenLimit,
+		NephewsLimit:       cfg.NephewsLimit,
+		ValidateTxOnSend:   cfg.ValidateTxOnSend,
+		EventIndex:         cfg.EventIndex,
+		Archive:            cfg.Archive,
+		StateGC:            cfg.StateGC,
+		StateGCInterval:    cfg.StateGCInterval,
+		StateGCCheckpoints: cfg.StateGCCheckpoints,
 	}
 	return v
 }
@@ -200,7 +237,8 @@ func RegisterInspectFunc(name string, f InspectFunc) error {
 func RegisterRest(n *Node) {
 	r := Rest{
 		n: n,
-		a: NewAuth(path.Join(n.cfg.ResolveAbsolute(n.cfg.BaseDir), "auth.json"), server.UrlAdmin),
+		a: NewAuth(path.Join(n.cfg.ResolveAbsolute(n.cfg.BaseDir), "auth.json"),
+			path.Join(n.cfg.ResolveAbsolute(n.cfg.BaseDir), "roles.json"), server.UrlAdmin),
 	}
 	r.a.SkipIfEmptyUsers = n.cfg.AuthSkipIfEmptyUsers
 	ag := n.srv.AdminEchoGroup(r.a.MiddlewareFunc())
@@ -216,6 +254,7 @@ func RegisterRest(n *Node) {
 	_ = RegisterInspectFunc("metrics", metric.Inspect)
 	_ = RegisterInspectFunc("network", network.Inspect)
 	_ = RegisterInspectFunc("service", service.Inspect)
+	_ = RegisterInspectFunc("chain", chain.Inspect)
 
 	// json rpc
 	n.srv.RegisterAPIHandler(n.cliSrv.e.Group("/api"))
@@ -226,24 +265,31 @@ func RegisterRest(n *Node) {
 
 func (r *Rest) RegisterChainHandlers(g *echo.Group) {
 	g.GET("", r.GetChains)
-	g.POST("", r.JoinChain)
+	r.permit(g.POST("", r.JoinChain), ActionJoin)
 
 	g.GET(UrlChainRes, r.GetChain, r.ChainInjector)
-	g.DELETE(UrlChainRes, r.LeaveChain, r.ChainInjector)
-	g.POST(UrlChainRes+"/start", r.StartChain, r.ChainInjector)
-	g.POST(UrlChainRes+"/stop", r.StopChain, r.ChainInjector)
-	g.POST(UrlChainRes+"/reset", r.ResetChain, r.ChainInjector)
-	g.POST(UrlChainRes+"/verify", r.VerifyChain, r.ChainInjector)
-	g.POST(UrlChainRes+"/import", r.ImportChain, r.ChainInjector)
-	g.POST(UrlChainRes+"/prune", r.PruneChain, r.ChainInjector)
-	g.POST(UrlChainRes+"/backup", r.BackupChain, r.ChainInjector)
+	r.permit(g.DELETE(UrlChainRes, r.LeaveChain, r.ChainInjector), ActionLeave)
+	r.permit(g.POST(UrlChainRes+"/start", r.StartChain, r.ChainInjector), ActionStart)
+	r.permit(g.POST(UrlChainRes+"/stop", r.StopChain, r.ChainInjector), ActionStop)
+	r.permit(g.POST(UrlChainRes+"/reset", r.ResetChain, r.ChainInjector), ActionReset)
+	r.permit(g.POST(UrlChainRes+"/verify", r.VerifyChain, r.ChainInjector), ActionVerify)
+	r.permit(g.POST(UrlChainRes+"/import", r.ImportChain, r.ChainInjector), ActionImport)
+	r.permit(g.POST(UrlChainRes+"/prune", r.PruneChain, r.ChainInjector), ActionPrune)
+	r.permit(g.POST(UrlChainRes+"/backup", r.BackupChain, r.ChainInjector), ActionBackup)
 	route := g.GET(UrlChainRes+"/genesis", r.GetChainGenesis, r.ChainInjector)
 	if r.a != nil {
 		r.a.SetSkip(route, false)
 	}
 	g.GET(UrlChainRes+"/configure", r.GetChainConfig, r.ChainInjector)
-	g.POST(UrlChainRes+"/configure", r.ConfigureChain, r.ChainInjector)
-	g.POST(UrlChainRes+"/:"+TaskID, r.RunChainTask, r.ChainInjector)
+	r.permit(g.POST(UrlChainRes+"/configure", r.ConfigureChain, r.ChainInjector), ActionConfigure)
+	r.permit(g.POST(UrlChainRes+"/:"+TaskID, r.RunChainTask, r.ChainInjector), ActionTask)
+}
+
+// permit sets the action of the route for checking the role of the user.
+func (r *Rest) permit(route *echo.Route, action string) {
+	if r.a != nil {
+		r.a.SetAction(route, action)
+	}
 }
 
 func (r *Rest) ChainInjector(next echo.HandlerFunc) echo.HandlerFunc {
@@ -382,7 +428,11 @@ func (r *Rest) ResetChain(ctx echo.Context) error {
 
 func (r *Rest) VerifyChain(ctx echo.Context) error {
 	c := ctx.Get("chain").(*Chain)
-	if err := r.n.VerifyChain(c.CID()); err != nil {
+	param := &ChainVerifyParam{}
+	if err := ctx.Bind(param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if err := r.n.VerifyChain(c.CID(), param.Height, param.Execute); err != nil {
 		return err
 	}
 	return ctx.String(http.StatusOK, "OK")
@@ -471,7 +521,7 @@ func (r *Rest) RunChainTask(ctx echo.Context) error {
 func (r *Rest) RegisterSystemHandlers(g *echo.Group) {
 	g.GET("", r.GetSystem)
 	g.GET("/configure", r.GetSystemConfig)
-	g.POST("/configure", r.ConfigureSystem)
+	r.permit(g.POST("/configure", r.ConfigureSystem), ActionConfigure)
 	r.RegistryBackupHandlers(g.Group("/backup"))
 	r.RegistryRestoreHandlers(g.Group("/restore"))
 }
@@ -486,7 +536,7 @@ func (r *Rest) GetSystem(ctx echo.Context) error {
 	v.Setting.P2PListenAddr = r.n.nt.GetListenAddress()
 	v.Setting.RPCAddr = r.n.cfg.RPCAddr
 	v.Setting.RPCDump = r.n.cfg.RPCDump
-	v.Config = r.n.rcfg
+	v.Config = r.n.rcfg.redacted()
 
 	format := ctx.QueryParam("format")
 	if format != "" {
@@ -496,7 +546,7 @@ func (r *Rest) GetSystem(ctx echo.Context) error {
 }
 
 func (r *Rest) GetSystemConfig(ctx echo.Context) error {
-	return ctx.JSON(http.StatusOK, r.n.rcfg)
+	return ctx.JSON(http.StatusOK, r.n.rcfg.redacted())
 }
 
 func (r *Rest) ConfigureSystem(ctx echo.Context) error {
@@ -524,9 +574,9 @@ func (r *Rest) GetBackups(ctx echo.Context) error {
 }
 
 func (r *Rest) RegistryRestoreHandlers(g *echo.Group) {
-	g.POST("", r.RestoreBackup)
+	r.permit(g.POST("", r.RestoreBackup), ActionRestore)
 	g.GET("", r.GetRestore)
-	g.DELETE("", r.StopRestore)
+	r.permit(g.DELETE("", r.StopRestore), ActionRestore)
 }
 
 func (r *Rest) GetRestore(ctx echo.Context) error {
@@ -556,6 +606,11 @@ func (r *Rest) RegisterUserHandlers(g *echo.Group) {
 	g.GET("", r.Users)
 	g.POST("", r.AddUser)
 	g.DELETE(UrlUserRes, r.RemoveUser)
+	g.POST(UrlUserRes+UrlRole, r.AssignRole)
+
+	g.GET(UrlRole, r.GetRoles)
+	g.POST(UrlRole, r.SetRole)
+	g.DELETE(UrlRole+UrlRoleRes, r.RemoveRole)
 }
 
 func (r *Rest) Users(ctx echo.Context) error {
@@ -592,6 +647,56 @@ func (r *Rest) RemoveUser(ctx echo.Context) error {
 	return ctx.String(http.StatusOK, "OK")
 }
 
+func roleErrorResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.NotFoundError.Equals(err):
+		return ctx.String(http.StatusNotFound, err.Error())
+	case errors.IllegalArgumentError.Equals(err):
+		return ctx.String(http.StatusBadRequest, err.Error())
+	case errors.InvalidStateError.Equals(err):
+		return ctx.String(http.StatusConflict, err.Error())
+	}
+	return err
+}
+
+func (r *Rest) GetRoles(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, r.a.GetRoles())
+}
+
+func (r *Rest) SetRole(ctx echo.Context) error {
+	param := struct {
+		Name    string   `json:"name"`
+		Actions []string `json:"actions"`
+	}{}
+	if err := ctx.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if err := r.a.SetRole(param.Name, param.Actions); err != nil {
+		return roleErrorResponse(ctx, err)
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (r *Rest) RemoveRole(ctx echo.Context) error {
+	if err := r.a.RemoveRole(ctx.Param(ParamRole)); err != nil {
+		return roleErrorResponse(ctx, err)
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (r *Rest) AssignRole(ctx echo.Context) error {
+	param := struct {
+		Role string `json:"role"`
+	}{}
+	if err := ctx.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if err := r.a.AssignRole(ctx.Param(ParamID), param.Role); err != nil {
+		return roleErrorResponse(ctx, err)
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
 func (r *Rest) RegisterStatsHandlers(g *echo.Group) {
 	g.GET("", r.StreamStats)
 }
@@ -664,7 +769,8 @@ func (r *Rest) ResponseStatsView(resp *echo.Response) error {
 
 func (r *Rest) RegisterDBHandlers(g *echo.Group) {
 	bg := g.Group("/:"+ParamCID+"/:"+ParamBK, r.ChainInjector, r.BucketInjector)
-	bg.GET("/:"+ParamKey, r.BucketGetValue)
+	r.permit(bg.GET("", r.BucketList), ActionDB)
+	r.permit(bg.GET("/:"+ParamKey, r.BucketGetValue), ActionDB)
 }
 
 func (r *Rest) BucketInjector(next echo.HandlerFunc) echo.HandlerFunc {
@@ -707,6 +813,52 @@ func (r *Rest) BucketGetValue(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, value)
 }
 
+// BucketList returns entries of the bucket having the prefix from the start.
+// If there are more entries than the count, next has the key to start for
+// the next entries.
+func (r *Rest) BucketList(ctx echo.Context) error {
+	bk := ctx.Get("bucket").(db.Bucket)
+	prefixStr := ctx.QueryParam("prefix")
+	prefix, err := hex.DecodeString(prefixStr)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "InvalidPrefix(prefix:"+prefixStr+")")
+	}
+	startStr := ctx.QueryParam("start")
+	start, err := hex.DecodeString(startStr)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "InvalidStart(start:"+startStr+")")
+	}
+	count := DefaultBucketListCount
+	if param := ctx.QueryParam("count"); param != "" {
+		count, err = strconv.Atoi(param)
+		if err != nil || count < 1 || count > MaxBucketListCount {
+			return ctx.String(http.StatusBadRequest, "InvalidCount(count:"+param+")")
+		}
+	}
+
+	v := &BucketListView{
+		Entries: make([]BucketEntryView, 0),
+	}
+	err = db.Iterate(bk, prefix, start, func(key []byte, value []byte) bool {
+		if len(v.Entries) == count {
+			v.Next = hex.EncodeToString(key)
+			return false
+		}
+		v.Entries = append(v.Entries, BucketEntryView{
+			Key:   hex.EncodeToString(key),
+			Value: hex.EncodeToString(value),
+		})
+		return true
+	})
+	if err != nil {
+		if errors.UnsupportedError.Equals(err) {
+			return ctx.String(http.StatusNotImplemented, err.Error())
+		}
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	return ctx.JSON(http.StatusOK, v)
+}
+
 func EqualsSyscallErrno(err error, sen syscall.Errno) bool {
 	if oe, ok := err.(*net.OpError); ok {
 		if se, ok := oe.Err.(*os.SyscallError); ok {
@@ -0,0 +1,44 @@
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/network"
+)
+
+func TestRest_SystemConfigWithoutAPIKeys(t *testing.T) {
+	w := wallet.New()
+	n := &Node{
+		w:  w,
+		nt: network.NewTransport("127.0.0.1:8080", w, log.New()),
+		rcfg: &RuntimeConfig{
+			RPCRateLimitRead: 10,
+			RPCAPIKeys:       "secret1,secret2",
+		},
+	}
+	r := &Rest{n: n}
+	e := echo.New()
+
+	for name, handler := range map[string]echo.HandlerFunc{
+		"GetSystem":       r.GetSystem,
+		"GetSystemConfig": r.GetSystemConfig,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, handler(e.NewContext(req, rec)), name)
+		assert.Equal(t, http.StatusOK, rec.Code, name)
+
+		body := rec.Body.String()
+		assert.Contains(t, body, "rpcRateLimitRead", name)
+		assert.NotContains(t, body, "secret1", name)
+		assert.NotContains(t, body, "rpcAPIKeys", name)
+	}
+	assert.Equal(t, "secret1,secret2", n.rcfg.RPCAPIKeys)
+}
@@ -0,0 +1,267 @@
+package node
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// Actions of admin API permitted by roles.
+const (
+	ActionAll       = "*"
+	ActionView      = "view"
+	ActionJoin      = "join"
+	ActionLeave     = "leave"
+	ActionStart     = "start"
+	ActionStop      = "stop"
+	ActionReset     = "reset"
+	ActionVerify    = "verify"
+	ActionImport    = "import"
+	ActionPrune     = "prune"
+	ActionBackup    = "backup"
+	ActionTask      = "task"
+	ActionConfigure = "configure"
+	ActionRestore   = "restore"
+	ActionDB        = "db"
+)
+
+var allActions = map[string]bool{
+	ActionAll:       true,
+	ActionView:      true,
+	ActionJoin:      true,
+	ActionLeave:     true,
+	ActionStart:     true,
+	ActionStop:      true,
+	ActionReset:     true,
+	ActionVerify:    true,
+	ActionImport:    true,
+	ActionPrune:     true,
+	ActionBackup:    true,
+	ActionTask:      true,
+	ActionConfigure: true,
+	ActionRestore:   true,
+	ActionDB:        true,
+}
+
+// Built-in roles. Users without role aren't permitted any action.
+// Existing users get RoleAdmin on migration from the node without roles,
+// and so does the first user of the node.
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+var builtinRoles = map[string][]string{
+	RoleViewer:   {ActionView},
+	RoleOperator: {ActionView, ActionStart, ActionStop, ActionVerify, ActionPrune, ActionBackup, ActionTask},
+	RoleAdmin:    {ActionAll},
+}
+
+type RoleView struct {
+	Name    string   `json:"name"`
+	Actions []string `json:"actions"`
+	Builtin bool     `json:"builtin,omitempty"`
+	Users   []string `json:"users"`
+}
+
+type roleStore struct {
+	Roles map[string][]string `json:"roles"`
+	Users map[string]string   `json:"users"`
+}
+
+// SetAction sets the action of the route to be checked with the role of
+// the user. Routes without action require ActionView for GET, otherwise
+// ActionAll.
+func (a *Auth) SetAction(r *echo.Route, action string) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	m, ok := a.actions[r.Method]
+	if !ok {
+		m = make(map[string]string)
+		a.actions[r.Method] = m
+	}
+	m[r.Path] = action
+}
+
+func (a *Auth) _actionOf(ctx echo.Context) string {
+	method := ctx.Request().Method
+	if m, ok := a.actions[method]; ok {
+		if action, has := m[ctx.Path()]; has {
+			return action
+		}
+	}
+	if method == http.MethodGet {
+		return ActionView
+	}
+	return ActionAll
+}
+
+func (a *Auth) _roleActions(role string) []string {
+	if actions, ok := builtinRoles[role]; ok {
+		return actions
+	}
+	return a.roles[role]
+}
+
+func (a *Auth) _roleOf(id string) string {
+	return a.userRoles[id]
+}
+
+// _permitted returns whether the user may request the route.
+func (a *Auth) _permitted(id string, ctx echo.Context) bool {
+	action := a._actionOf(ctx)
+	for _, act := range a._roleActions(a._roleOf(id)) {
+		if act == ActionAll || act == action {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRole adds or updates the role with the actions. Built-in roles can't
+// be modified.
+func (a *Auth) SetRole(name string, actions []string) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if name == "" {
+		return errors.IllegalArgumentError.New("EmptyRoleName")
+	}
+	if _, ok := builtinRoles[name]; ok {
+		return errors.IllegalArgumentError.Errorf("BuiltinRole(name=%s)", name)
+	}
+	for _, action := range actions {
+		if !allActions[action] {
+			return errors.IllegalArgumentError.Errorf("UnknownAction(action=%s)", action)
+		}
+	}
+	a.roles[name] = append([]string{}, actions...)
+	return a._exportRoles()
+}
+
+func (a *Auth) RemoveRole(name string) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if _, ok := builtinRoles[name]; ok {
+		return errors.IllegalArgumentError.Errorf("BuiltinRole(name=%s)", name)
+	}
+	if _, ok := a.roles[name]; !ok {
+		return errors.NotFoundError.Errorf("RoleNotFound(name=%s)", name)
+	}
+	for id, role := range a.userRoles {
+		if role == name {
+			return errors.InvalidStateError.Errorf("RoleInUse(name=%s,user=%s)", name, id)
+		}
+	}
+	delete(a.roles, name)
+	return a._exportRoles()
+}
+
+// AssignRole assigns the role to the user. Empty role revokes the role of
+// the user.
+func (a *Auth) AssignRole(id string, role string) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if _, ok := a.users[id]; !ok {
+		return errors.NotFoundError.Errorf("UserNotFound(id=%s)", id)
+	}
+	if role == "" {
+		delete(a.userRoles, id)
+	} else {
+		if a._roleActions(role) == nil {
+			return errors.NotFoundError.Errorf("RoleNotFound(name=%s)", role)
+		}
+		a.userRoles[id] = role
+	}
+	return a._exportRoles()
+}
+
+func (a *Auth) GetRoles() []*RoleView {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	views := make(map[string]*RoleView)
+	for name, actions := range builtinRoles {
+		views[name] = &RoleView{Name: name, Actions: actions, Builtin: true, Users: []string{}}
+	}
+	for name, actions := range a.roles {
+		views[name] = &RoleView{Name: name, Actions: actions, Users: []string{}}
+	}
+	for id := range a.users {
+		if v, ok := views[a._roleOf(id)]; ok {
+			v.Users = append(v.Users, id)
+		}
+	}
+	roles := make([]*RoleView, 0, len(views))
+	for _, v := range views {
+		sort.Strings(v.Users)
+		roles = append(roles, v)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+	return roles
+}
+
+func (a *Auth) _exportRoles() error {
+	if a.rolesPath == "" {
+		return nil
+	}
+	b, err := json.Marshal(&roleStore{Roles: a.roles, Users: a.userRoles})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(a.rolesPath, b, 0644)
+}
+
+func (a *Auth) _importRoles() error {
+	var b []byte
+	if a.rolesPath != "" {
+		var err error
+		if b, err = ioutil.ReadFile(a.rolesPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if b == nil {
+		// users of the node without roles had all permissions
+		for id := range a.users {
+			a.userRoles[id] = RoleAdmin
+		}
+		return a._exportRoles()
+	}
+	var rs roleStore
+	if err := json.Unmarshal(b, &rs); err != nil {
+		return err
+	}
+	for name, actions := range rs.Roles {
+		if _, ok := builtinRoles[name]; ok {
+			return errors.IllegalArgumentError.Errorf("BuiltinRole(name=%s)", name)
+		}
+		for _, action := range actions {
+			if !allActions[action] {
+				return errors.IllegalArgumentError.Errorf(
+					"UnknownAction(role=%s,action=%s)", name, action)
+			}
+		}
+		a.roles[name] = actions
+	}
+	for id, role := range rs.Users {
+		if a._roleActions(role) == nil {
+			return errors.NotFoundError.Errorf("RoleNotFound(name=%s,user=%s)", role, id)
+		}
+		if _, ok := a.users[id]; ok {
+			a.userRoles[id] = role
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package node
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+)
+
+func newTestUser() (string, *crypto.PrivateKey) {
+	priv, pub := crypto.GenerateKeyPair()
+	return common.NewAccountAddressFromPublicKey(pub).String(), priv
+}
+
+func newTestAuth(t *testing.T) (*Auth, string) {
+	dir, err := ioutil.TempDir("", "auth")
+	assert.NoError(t, err)
+	return NewAuth(path.Join(dir, "auth.json"), path.Join(dir, "roles.json"), ""), dir
+}
+
+func signedContext(e *echo.Echo, method, url string, priv *crypto.PrivateKey, ts int64) (echo.Context, string) {
+	req := httptest.NewRequest(method, url, nil)
+	serialized := fmt.Sprintf("Method=%s,Url=%s,Timestamp=%d", method, url, ts)
+	sig, _ := crypto.NewSignature(crypto.SHA3Sum256([]byte(serialized)), priv)
+	bs, _ := sig.SerializeRSV()
+	key := fmt.Sprintf("Timestamp=%d,Signature=%s", ts, hex.EncodeToString(bs))
+	ctx := e.NewContext(req, httptest.NewRecorder())
+	e.Router().Find(method, req.URL.EscapedPath(), ctx)
+	return ctx, key
+}
+
+func TestAuth_UserWithoutRole(t *testing.T) {
+	a, dir := newTestAuth(t)
+	defer os.RemoveAll(dir)
+
+	id1, _ := newTestUser()
+	id2, priv2 := newTestUser()
+	assert.NoError(t, a.AddUser(id1))
+	assert.NoError(t, a.AddUser(id2))
+
+	// the first user can manage the node, others need roles
+	assert.Equal(t, RoleAdmin, a._roleOf(id1))
+	assert.Equal(t, "", a._roleOf(id2))
+
+	e := echo.New()
+	ts := a.users[id2] + 1
+	ctx, key := signedContext(e, http.MethodGet, "/", priv2, ts)
+	ok, err := a.validator(key, ctx)
+	assert.False(t, ok)
+	if he, isHTTP := err.(*echo.HTTPError); assert.True(t, isHTTP) {
+		assert.Equal(t, http.StatusForbidden, he.Code)
+	}
+	// denied request doesn't consume the timestamp
+	assert.NotEqual(t, ts, a.users[id2])
+
+	assert.NoError(t, a.AssignRole(id2, RoleViewer))
+	ok, err = a.validator(key, ctx)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	// roles are kept after restart
+	a = NewAuth(path.Join(dir, "auth.json"), path.Join(dir, "roles.json"), "")
+	assert.Equal(t, RoleAdmin, a._roleOf(id1))
+	assert.Equal(t, RoleViewer, a._roleOf(id2))
+}
+
+func TestAuth_MigrateRoles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	id1, _ := newTestUser()
+	id2, _ := newTestUser()
+	users := fmt.Sprintf(`["%s","%s"]`, id1, id2)
+	assert.NoError(t, ioutil.WriteFile(path.Join(dir, "auth.json"), []byte(users), 0644))
+
+	// existing users keep all permissions without roles
+	a := NewAuth(path.Join(dir, "auth.json"), path.Join(dir, "roles.json"), "")
+	assert.Equal(t, RoleAdmin, a._roleOf(id1))
+	assert.Equal(t, RoleAdmin, a._roleOf(id2))
+	_, err = os.Stat(path.Join(dir, "roles.json"))
+	assert.NoError(t, err)
+}
+
+func TestAuth_ImportInvalidRoles(t *testing.T) {
+	cases := map[string]string{
+		"UnknownAction": `{"roles":{"custom":["view","unknown"]},"users":{}}`,
+		"BuiltinRole":   `{"roles":{"admin":["view"]},"users":{}}`,
+		"UnknownRole":   `{"roles":{},"users":{"hx0000000000000000000000000000000000000001":"custom"}}`,
+	}
+	for name, roles := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "auth")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			rolesPath := path.Join(dir, "roles.json")
+			assert.NoError(t, ioutil.WriteFile(rolesPath, []byte(roles), 0644))
+			assert.Panics(t, func() {
+				NewAuth(path.Join(dir, "auth.json"), rolesPath, "")
+			})
+		})
+	}
+}
+
+func TestAuth_RouteActions(t *testing.T) {
+	a, dir := newTestAuth(t)
+	defer os.RemoveAll(dir)
+
+	admin, adminPriv := newTestUser()
+	operator, operatorPriv := newTestUser()
+	viewer, viewerPriv := newTestUser()
+	for _, id := range []string{admin, operator, viewer} {
+		assert.NoError(t, a.AddUser(id))
+	}
+	assert.NoError(t, a.AssignRole(operator, RoleOperator))
+	assert.NoError(t, a.AssignRole(viewer, RoleViewer))
+
+	e := echo.New()
+	r := &Rest{a: a}
+	r.RegisterChainHandlers(e.Group(UrlChain))
+	r.RegisterDBHandlers(e.Group(UrlDB))
+
+	cases := []struct {
+		name      string
+		priv      *crypto.PrivateKey
+		method    string
+		url       string
+		permitted bool
+	}{
+		{"OperatorStart", operatorPriv, http.MethodPost, "/chain/0x1/start", true},
+		{"OperatorStop", operatorPriv, http.MethodPost, "/chain/0x1/stop", true},
+		{"OperatorTask", operatorPriv, http.MethodPost, "/chain/0x1/task", true},
+		{"OperatorReset", operatorPriv, http.MethodPost, "/chain/0x1/reset", false},
+		{"OperatorGetConfig", operatorPriv, http.MethodGet, "/chain/0x1/configure", true},
+		{"OperatorConfigure", operatorPriv, http.MethodPost, "/chain/0x1/configure", false},
+		{"OperatorLeave", operatorPriv, http.MethodDelete, "/chain/0x1", false},
+		{"OperatorJoin", operatorPriv, http.MethodPost, "/chain", false},
+		{"ViewerChain", viewerPriv, http.MethodGet, "/chain/0x1", true},
+		{"ViewerStart", viewerPriv, http.MethodPost, "/chain/0x1/start", false},
+		{"ViewerBucket", viewerPriv, http.MethodGet, "/db/0x1/bucket", false},
+		{"ViewerValue", viewerPriv, http.MethodGet, "/db/0x1/bucket/key", false},
+		{"AdminReset", adminPriv, http.MethodPost, "/chain/0x1/reset", true},
+		{"AdminValue", adminPriv, http.MethodGet, "/db/0x1/bucket/key", true},
+	}
+	// signatures should be newer than the ones of the users
+	var ts int64
+	for _, id := range []string{admin, operator, viewer} {
+		if a.users[id] > ts {
+			ts = a.users[id]
+		}
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ts++
+			ctx, key := signedContext(e, c.method, c.url, c.priv, ts)
+			ok, err := a.validator(key, ctx)
+			assert.Equal(t, c.permitted, ok)
+			if c.permitted {
+				assert.NoError(t, err)
+			} else if he, isHTTP := err.(*echo.HTTPError); assert.True(t, isHTTP, "err=%+v", err) {
+				assert.Equal(t, http.StatusForbidden, he.Code)
+			}
+		})
+	}
+}
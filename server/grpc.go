@@ -0,0 +1,583 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/iconpb"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/v3"
+)
+
+// Metadata keys of gRPC requests.
+const (
+	GRPCMetaChannel     = "channel"
+	GRPCMetaIconOptions = "icon-options"
+	GRPCMetaAPIKey      = "x-api-key"
+)
+
+// grpcServer serves iconpb.IconV3Server with the handlers of JSON-RPC v3.
+type grpcServer struct {
+	srv *Manager
+	mr  *jsonrpc.MethodRepository
+}
+
+func (srv *Manager) startGRPC() error {
+	l, err := net.Listen("tcp", srv.grpcAddr)
+	if err != nil {
+		return err
+	}
+	s := grpc.NewServer()
+	iconpb.RegisterIconV3Server(s, &grpcServer{
+		srv: srv,
+		mr:  v3.MethodRepository(srv.mtr),
+	})
+	srv.grpc = s
+	go func() {
+		if err := s.Serve(l); err != nil {
+			srv.logger.Warnf("gRPC server stopped err=%+v", err)
+		}
+	}()
+	return nil
+}
+
+func firstOfMD(md metadata.MD, key string) string {
+	if vs := md.Get(key); len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+func (s *grpcServer) chain(ctx context.Context) (module.Chain, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	c := s.srv.Chain(firstOfMD(md, GRPCMetaChannel))
+	if c == nil {
+		return nil, status.Error(codes.NotFound, "chain not found")
+	}
+	return c, nil
+}
+
+// echoContext returns the context of the HTTP request equivalent to the
+// gRPC request.
+func (s *grpcServer) echoContext(ctx context.Context) (echo.Context, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if opts := firstOfMD(md, GRPCMetaIconOptions); opts != "" {
+		req.Header.Set(jsonrpc.HeaderKeyIconOptions, opts)
+	}
+	if key := firstOfMD(md, GRPCMetaAPIKey); key != "" {
+		req.Header.Set(HeaderAPIKey, key)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		req.RemoteAddr = p.Addr.String()
+	}
+	ec := s.srv.e.NewContext(req, nil)
+	ec.Set("includeDebug", s.srv.IncludeDebug())
+	ec.Set("rateLimiter", &clientRateLimiter{
+		rl:     s.srv.rateLimiter,
+		client: s.srv.rateLimiter.ClientOf(ec),
+	})
+	return ec, nil
+}
+
+// context returns the context for JSON-RPC handlers from the context of
+// gRPC request.
+func (s *grpcServer) context(ctx context.Context) (*jsonrpc.Context, error) {
+	c, err := s.chain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := s.echoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ec.Set("chain", c)
+	return jsonrpc.NewContext(ec), nil
+}
+
+// allow applies the rate limit of the client to the method which isn't
+// handled by JSON-RPC handlers. Streams are counted once on open.
+func (s *grpcServer) allow(ctx context.Context, method string) error {
+	ec, err := s.echoContext(ctx)
+	if err != nil {
+		return err
+	}
+	jctx := jsonrpc.NewContext(ec)
+	if rl := jctx.RateLimiter(); rl != nil && !rl.Allow(jctx, method) {
+		return grpcError(jsonrpc.ErrorCodeRateLimited.Errorf("method=%s", method))
+	}
+	return nil
+}
+
+func grpcError(e *jsonrpc.Error) error {
+	var c codes.Code
+	switch code := e.Code; {
+	case code == jsonrpc.ErrorCodeJsonParse,
+		code == jsonrpc.ErrorCodeInvalidRequest,
+		code == jsonrpc.ErrorCodeInvalidParams:
+		c = codes.InvalidArgument
+	case code == jsonrpc.ErrorCodeMethodNotFound:
+		c = codes.Unimplemented
+	case code == jsonrpc.ErrorCodeNotFound:
+		c = codes.NotFound
+	case code == jsonrpc.ErrorCodePending,
+		code == jsonrpc.ErrorCodeExecuting,
+		code == jsonrpc.ErrorCodeServer:
+		c = codes.Unavailable
+	case code == jsonrpc.ErrorCodeStateNotAvailable,
+		code == jsonrpc.ErrorCodeUnderpriced:
+		c = codes.FailedPrecondition
+	case code == jsonrpc.ErrorCodeTxPoolOverflow,
+		code == jsonrpc.ErrorLackOfResource,
+		code == jsonrpc.ErrorCodeRateLimited:
+		c = codes.ResourceExhausted
+	case code == jsonrpc.ErrorCodeTimeout,
+		code == jsonrpc.ErrorCodeSystemTimeout:
+		c = codes.DeadlineExceeded
+	case code <= jsonrpc.ErrorCodeScore && code > jsonrpc.ErrorCodeSystem:
+		c = codes.Aborted
+	default:
+		c = codes.Internal
+	}
+	return status.Errorf(c, "%s (code=%d)", e.Message, e.Code)
+}
+
+// invoke calls the JSON-RPC method, then it returns the result in JSON.
+// If v isn't nil, the result is also decoded to v.
+func (s *grpcServer) invoke(ctx context.Context, method string, params interface{}, v interface{}) (string, error) {
+	jctx, err := s.context(ctx)
+	if err != nil {
+		return "", err
+	}
+	res, je := s.mr.Invoke(jctx, method, params)
+	if je != nil {
+		return "", grpcError(je)
+	}
+	bs, err := json.Marshal(res)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	if v != nil {
+		if err := json.Unmarshal(bs, v); err != nil {
+			return "", status.Error(codes.Internal, err.Error())
+		}
+	}
+	return string(bs), nil
+}
+
+func rawParam(name, value string) (json.RawMessage, error) {
+	if !json.Valid([]byte(value)) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid JSON for %s", name)
+	}
+	return json.RawMessage(value), nil
+}
+
+func (s *grpcServer) GetStatus(ctx context.Context, _ *iconpb.Empty) (*iconpb.Status, error) {
+	c, err := s.chain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st, _, err := c.State()
+	if err != nil {
+		return nil, grpcError(jsonrpc.ErrorCodeServer.Wrap(err, s.srv.IncludeDebug()))
+	}
+	res := &iconpb.Status{
+		Channel: c.Channel(),
+		Nid:     "0x" + strconv.FormatInt(int64(c.NID()), 16),
+		State:   st,
+	}
+	if bm := c.BlockManager(); bm != nil {
+		if blk, err := bm.GetLastBlock(); err == nil {
+			res.Height = blk.Height()
+			res.BlockHash = "0x" + hex.EncodeToString(blk.ID())
+		}
+	}
+	return res, nil
+}
+
+type blockJSON struct {
+	Height       int64  `json:"height"`
+	Hash         string `json:"block_hash"`
+	PrevHash     string `json:"prev_block_hash"`
+	Timestamp    int64  `json:"time_stamp"`
+	Transactions []struct {
+		TxHash   string `json:"txHash"`
+		TxHashV2 string `json:"tx_hash"`
+	} `json:"confirmed_transaction_list"`
+}
+
+func (s *grpcServer) block(ctx context.Context, method string, params interface{}) (*iconpb.Block, error) {
+	var b blockJSON
+	js, err := s.invoke(ctx, method, params, &b)
+	if err != nil {
+		return nil, err
+	}
+	res := &iconpb.Block{
+		Height:    b.Height,
+		Hash:      "0x" + b.Hash,
+		PrevHash:  "0x" + b.PrevHash,
+		Timestamp: b.Timestamp,
+		Json:      js,
+	}
+	for _, tx := range b.Transactions {
+		if tx.TxHash != "" {
+			res.TxHashes = append(res.TxHashes, tx.TxHash)
+		} else {
+			res.TxHashes = append(res.TxHashes, "0x"+tx.TxHashV2)
+		}
+	}
+	return res, nil
+}
+
+func (s *grpcServer) GetLastBlock(ctx context.Context, _ *iconpb.Empty) (*iconpb.Block, error) {
+	return s.block(ctx, "icx_getLastBlock", nil)
+}
+
+func (s *grpcServer) GetBlockByHeight(ctx context.Context, req *iconpb.HeightRequest) (*iconpb.Block, error) {
+	if req.Height == "" {
+		return s.GetLastBlock(ctx, nil)
+	}
+	return s.block(ctx, "icx_getBlockByHeight", &v3.BlockHeightParam{
+		Height: jsonrpc.HexInt(req.Height),
+	})
+}
+
+func (s *grpcServer) GetBlockByHash(ctx context.Context, req *iconpb.HashRequest) (*iconpb.Block, error) {
+	return s.block(ctx, "icx_getBlockByHash", &v3.BlockHashParam{
+		Hash: jsonrpc.HexBytes(req.Hash),
+	})
+}
+
+func (s *grpcServer) GetTransactionByHash(ctx context.Context, req *iconpb.HashRequest) (*iconpb.Transaction, error) {
+	var tx struct {
+		TxHash      string `json:"txHash"`
+		From        string `json:"from"`
+		To          string `json:"to"`
+		BlockHeight string `json:"blockHeight"`
+		BlockHash   string `json:"blockHash"`
+	}
+	js, err := s.invoke(ctx, "icx_getTransactionByHash", &v3.TransactionHashParam{
+		Hash: jsonrpc.HexBytes(req.Hash),
+	}, &tx)
+	if err != nil {
+		return nil, err
+	}
+	return &iconpb.Transaction{
+		Hash:        tx.TxHash,
+		From:        tx.From,
+		To:          tx.To,
+		BlockHeight: tx.BlockHeight,
+		BlockHash:   tx.BlockHash,
+		Json:        js,
+	}, nil
+}
+
+type eventLogJSON struct {
+	ScoreAddress string    `json:"scoreAddress"`
+	Indexed      []*string `json:"indexed"`
+	Data         []*string `json:"data"`
+}
+
+func stringsOf(ptrs []*string) []string {
+	values := make([]string, len(ptrs))
+	for i, p := range ptrs {
+		if p != nil {
+			values[i] = *p
+		}
+	}
+	return values
+}
+
+func (e *eventLogJSON) toPB() *iconpb.EventLog {
+	return &iconpb.EventLog{
+		ScoreAddress: e.ScoreAddress,
+		Indexed:      stringsOf(e.Indexed),
+		Data:         stringsOf(e.Data),
+	}
+}
+
+type resultJSON struct {
+	TxHash       string          `json:"txHash"`
+	Status       string          `json:"status"`
+	BlockHeight  string          `json:"blockHeight"`
+	BlockHash    string          `json:"blockHash"`
+	ScoreAddress string          `json:"scoreAddress"`
+	StepUsed     string          `json:"stepUsed"`
+	EventLogs    []*eventLogJSON `json:"eventLogs"`
+}
+
+func (s *grpcServer) result(ctx context.Context, method string, params interface{}) (*iconpb.TransactionResult, error) {
+	var r resultJSON
+	js, err := s.invoke(ctx, method, params, &r)
+	if err != nil {
+		return nil, err
+	}
+	res := &iconpb.TransactionResult{
+		TxHash:       r.TxHash,
+		Status:       r.Status,
+		BlockHeight:  r.BlockHeight,
+		BlockHash:    r.BlockHash,
+		ScoreAddress: r.ScoreAddress,
+		StepUsed:     r.StepUsed,
+		Json:         js,
+	}
+	for _, el := range r.EventLogs {
+		res.EventLogs = append(res.EventLogs, el.toPB())
+	}
+	return res, nil
+}
+
+func (s *grpcServer) GetTransactionResult(ctx context.Context, req *iconpb.HashRequest) (*iconpb.TransactionResult, error) {
+	return s.result(ctx, "icx_getTransactionResult", &v3.TransactionHashParam{
+		Hash: jsonrpc.HexBytes(req.Hash),
+	})
+}
+
+// SendTransaction sends the transaction. If it waits for the result, the
+// hash of the transaction is returned after the result is available.
+func (s *grpcServer) SendTransaction(ctx context.Context, req *iconpb.SendTransactionRequest) (*iconpb.HashResponse, error) {
+	tx, err := rawParam("transaction", req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	if req.Wait {
+		r, err := s.result(ctx, "icx_sendTransactionAndWait", tx)
+		if err != nil {
+			return nil, err
+		}
+		return &iconpb.HashResponse{Hash: r.TxHash}, nil
+	}
+	var hash string
+	if _, err := s.invoke(ctx, "icx_sendTransaction", tx, &hash); err != nil {
+		return nil, err
+	}
+	return &iconpb.HashResponse{Hash: hash}, nil
+}
+
+func (s *grpcServer) Call(ctx context.Context, req *iconpb.CallRequest) (*iconpb.JSONResponse, error) {
+	data, err := rawParam("data", req.Data)
+	if err != nil {
+		return nil, err
+	}
+	js, err := s.invoke(ctx, "icx_call", &v3.CallParam{
+		FromAddress: jsonrpc.Address(req.From),
+		ToAddress:   jsonrpc.Address(req.To),
+		DataType:    "call",
+		Data:        data,
+		Height:      jsonrpc.HexInt(req.Height),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &iconpb.JSONResponse{Json: js}, nil
+}
+
+func (s *grpcServer) GetBalance(ctx context.Context, req *iconpb.AddressRequest) (*iconpb.IntResponse, error) {
+	var value string
+	if _, err := s.invoke(ctx, "icx_getBalance", &v3.AddressParam{
+		Address: jsonrpc.Address(req.Address),
+		Height:  jsonrpc.HexInt(req.Height),
+	}, &value); err != nil {
+		return nil, err
+	}
+	return &iconpb.IntResponse{Value: value}, nil
+}
+
+func (s *grpcServer) GetTotalSupply(ctx context.Context, req *iconpb.HeightRequest) (*iconpb.IntResponse, error) {
+	var value string
+	var param *v3.HeightParam
+	if req.Height != "" {
+		param = &v3.HeightParam{Height: jsonrpc.HexInt(req.Height)}
+	}
+	if _, err := s.invoke(ctx, "icx_getTotalSupply", param, &value); err != nil {
+		return nil, err
+	}
+	return &iconpb.IntResponse{Value: value}, nil
+}
+
+func (s *grpcServer) GetScoreApi(ctx context.Context, req *iconpb.AddressRequest) (*iconpb.JSONResponse, error) {
+	js, err := s.invoke(ctx, "icx_getScoreApi", &v3.ScoreAddressParam{
+		Address: jsonrpc.Address(req.Address),
+		Height:  jsonrpc.HexInt(req.Height),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &iconpb.JSONResponse{Json: js}, nil
+}
+
+// managersOf returns managers of the chain for monitoring from the height.
+func managersOf(c module.Chain, height int64) (module.BlockManager, module.ServiceManager, error) {
+	bm := c.BlockManager()
+	sm := c.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, nil, status.Error(codes.Unavailable, "Stopped")
+	}
+	if gh := c.GenesisStorage().Height(); gh > height {
+		return nil, nil, status.Errorf(codes.InvalidArgument,
+			"given height(%d) is lower than genesis height(%d)", height, gh)
+	}
+	return bm, sm, nil
+}
+
+func (s *grpcServer) MonitorBlocks(req *iconpb.MonitorBlocksRequest, stream iconpb.IconV3_MonitorBlocksServer) error {
+	ctx := stream.Context()
+	if err := s.allow(ctx, "MonitorBlocks"); err != nil {
+		return err
+	}
+	c, err := s.chain(ctx)
+	if err != nil {
+		return err
+	}
+	bm, _, err := managersOf(c, req.Height)
+	if err != nil {
+		return err
+	}
+	for h := req.Height; ; h++ {
+		bch, err := bm.WaitForBlock(h)
+		if err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case blk := <-bch:
+			if err := stream.Send(&iconpb.BlockNotification{
+				Height: h,
+				Hash:   "0x" + hex.EncodeToString(blk.ID()),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newEventRequest(req *iconpb.MonitorEventsRequest) (*EventRequest, error) {
+	filters := make([]*EventFilter, len(req.Filters))
+	for i, f := range req.Filters {
+		ef := &EventFilter{Signature: f.Event}
+		for _, s := range f.Addrs {
+			addr, err := common.NewAddressFromString(s)
+			if err != nil {
+				return nil, err
+			}
+			ef.Addrs = append(ef.Addrs, addr)
+		}
+		ef.Indexed = eventValues(f.Indexed)
+		ef.Data = eventValues(f.Data)
+		filters[i] = ef
+	}
+	er := &EventRequest{EventFilters: filters}
+	if _, err := er.addFilters(filters); err != nil {
+		return nil, err
+	}
+	return er, nil
+}
+
+// eventValues returns values of the filter, nil is for any value.
+func eventValues(values []*iconpb.EventValue) []*string {
+	if len(values) == 0 {
+		return nil
+	}
+	ptrs := make([]*string, len(values))
+	for i, v := range values {
+		if v != nil && !v.Any {
+			value := v.Value
+			ptrs[i] = &value
+		}
+	}
+	return ptrs
+}
+
+func (s *grpcServer) MonitorEvents(req *iconpb.MonitorEventsRequest, stream iconpb.IconV3_MonitorEventsServer) error {
+	ctx := stream.Context()
+	if err := s.allow(ctx, "MonitorEvents"); err != nil {
+		return err
+	}
+	if len(req.Filters) == 0 {
+		return status.Error(codes.InvalidArgument, "no filters")
+	}
+	er, err := newEventRequest(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	c, err := s.chain(ctx)
+	if err != nil {
+		return err
+	}
+	bm, sm, err := managersOf(c, req.Height)
+	if err != nil {
+		return err
+	}
+	var idx *block.EventIndex
+	if c.EventIndex() {
+		if idx, err = block.NewEventIndex(c.Database()); err != nil {
+			s.srv.logger.Warnf("fail to open event index err:%+v\n", err)
+			idx = nil
+		}
+	}
+	send := func(en *EventNotification) error {
+		n := &iconpb.EventNotification{
+			Height: en.Height.Value,
+			Hash:   "0x" + hex.EncodeToString(en.Hash),
+			Index:  en.Index.Value,
+		}
+		if en.Filter != nil {
+			n.Filter = en.Filter.Value
+		}
+		for _, e := range en.Events {
+			n.Events = append(n.Events, e.Value)
+		}
+		if req.Logs {
+			for _, el := range en.Logs {
+				bs, err := json.Marshal(el)
+				if err != nil {
+					return err
+				}
+				var elj eventLogJSON
+				if err := json.Unmarshal(bs, &elj); err != nil {
+					return err
+				}
+				n.Logs = append(n.Logs, elj.toPB())
+			}
+		}
+		return stream.Send(n)
+	}
+	for h := req.Height; ; h++ {
+		if h, err = er.nextHeight(idx, h); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		bch, err := bm.WaitForBlock(h)
+		if err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case blk := <-bch:
+			if err := er.forEachNotification(sm, blk, send); err != nil {
+				if _, ok := status.FromError(err); ok {
+					return err
+				}
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/server/iconpb"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/v3"
+)
+
+func TestGRPCError(t *testing.T) {
+	cases := []struct {
+		code jsonrpc.ErrorCode
+		exp  codes.Code
+	}{
+		{jsonrpc.ErrorCodeInvalidParams, codes.InvalidArgument},
+		{jsonrpc.ErrorCodeMethodNotFound, codes.Unimplemented},
+		{jsonrpc.ErrorCodeNotFound, codes.NotFound},
+		{jsonrpc.ErrorCodeExecuting, codes.Unavailable},
+		{jsonrpc.ErrorCodeStateNotAvailable, codes.FailedPrecondition},
+		{jsonrpc.ErrorCodeUnderpriced, codes.FailedPrecondition},
+		{jsonrpc.ErrorCodeRateLimited, codes.ResourceExhausted},
+		{jsonrpc.ErrorCodeTimeout, codes.DeadlineExceeded},
+		{jsonrpc.ErrorCodeScore - 1, codes.Aborted},
+		{jsonrpc.ErrorCodeInternal, codes.Internal},
+	}
+	for _, c := range cases {
+		err := grpcError(c.code.New("test"))
+		assert.Equal(t, c.exp, status.Code(err), "code=%d", c.code)
+	}
+}
+
+var testManager struct {
+	once sync.Once
+	srv  *Manager
+}
+
+// newTestGRPCClient serves gRPC API with the shared Manager, because the
+// Manager registers metric views which can't be registered twice.
+func newTestGRPCClient(t *testing.T) (*Manager, iconpb.IconV3Client, func()) {
+	testManager.once.Do(func() {
+		testManager.srv = NewManager("", false, false, false, "", 0, nil, log.New())
+	})
+	srv := testManager.srv
+
+	l := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	iconpb.RegisterIconV3Server(s, &grpcServer{
+		srv: srv,
+		mr:  v3.MethodRepository(srv.mtr),
+	})
+	go s.Serve(l)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return l.Dial()
+		}),
+		grpc.WithInsecure())
+	assert.NoError(t, err)
+	return srv, iconpb.NewIconV3Client(conn), func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func TestGRPCServer_NoChain(t *testing.T) {
+	_, client, closer := newTestGRPCClient(t)
+	defer closer()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		GRPCMetaChannel, "unknown")
+
+	_, err := client.GetStatus(ctx, &iconpb.Empty{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetLastBlock(ctx, &iconpb.Empty{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCServer_StreamRateLimit(t *testing.T) {
+	srv, client, closer := newTestGRPCClient(t)
+	defer closer()
+	srv.SetRateLimit(RateClassRead, 1)
+	defer srv.SetRateLimit(RateClassRead, 0)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		GRPCMetaChannel, "unknown")
+	// the first stream passes the limit, then fails with the unknown chain
+	stream, err := client.MonitorBlocks(ctx, &iconpb.MonitorBlocksRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// streams share the budget of the client
+	events, err := client.MonitorEvents(ctx, &iconpb.MonitorEventsRequest{})
+	assert.NoError(t, err)
+	_, err = events.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestNewEventRequest_Values(t *testing.T) {
+	req := &iconpb.MonitorEventsRequest{
+		Filters: []*iconpb.EventFilter{{
+			Addrs: []string{"cx0000000000000000000000000000000000000001"},
+			Event: "Transfer(Address,Address,int)",
+			Indexed: []*iconpb.EventValue{
+				{Any: true},
+				{Value: ""},
+				{Value: "0x1"},
+			},
+		}},
+	}
+	er, err := newEventRequest(req)
+	assert.NoError(t, err)
+	ef := er.EventFilters[0]
+	if assert.Len(t, ef.Indexed, 3) {
+		assert.Nil(t, ef.Indexed[0])
+		if assert.NotNil(t, ef.Indexed[1]) {
+			assert.Equal(t, "", *ef.Indexed[1])
+		}
+		if assert.NotNil(t, ef.Indexed[2]) {
+			assert.Equal(t, "0x1", *ef.Indexed[2])
+		}
+	}
+	assert.Nil(t, ef.Data)
+}
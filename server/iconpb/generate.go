@@ -0,0 +1,5 @@
+// Package iconpb has the protocol buffer messages and gRPC bindings of the
+// gRPC API mirroring JSON-RPC v3.
+package iconpb
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. icon.proto
@@ -0,0 +1,1788 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: icon.proto
+
+package iconpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Empty struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{0}
+}
+
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Empty.Unmarshal(m, b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+}
+func (m *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(m, src)
+}
+func (m *Empty) XXX_Size() int {
+	return xxx_messageInfo_Empty.Size(m)
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+type HeightRequest struct {
+	// T_INT, the last block if it's empty.
+	Height               string   `protobuf:"bytes,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeightRequest) Reset()         { *m = HeightRequest{} }
+func (m *HeightRequest) String() string { return proto.CompactTextString(m) }
+func (*HeightRequest) ProtoMessage()    {}
+func (*HeightRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{1}
+}
+
+func (m *HeightRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeightRequest.Unmarshal(m, b)
+}
+func (m *HeightRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeightRequest.Marshal(b, m, deterministic)
+}
+func (m *HeightRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeightRequest.Merge(m, src)
+}
+func (m *HeightRequest) XXX_Size() int {
+	return xxx_messageInfo_HeightRequest.Size(m)
+}
+func (m *HeightRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeightRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeightRequest proto.InternalMessageInfo
+
+func (m *HeightRequest) GetHeight() string {
+	if m != nil {
+		return m.Height
+	}
+	return ""
+}
+
+type HashRequest struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashRequest) Reset()         { *m = HashRequest{} }
+func (m *HashRequest) String() string { return proto.CompactTextString(m) }
+func (*HashRequest) ProtoMessage()    {}
+func (*HashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{2}
+}
+
+func (m *HashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashRequest.Unmarshal(m, b)
+}
+func (m *HashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashRequest.Marshal(b, m, deterministic)
+}
+func (m *HashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashRequest.Merge(m, src)
+}
+func (m *HashRequest) XXX_Size() int {
+	return xxx_messageInfo_HashRequest.Size(m)
+}
+func (m *HashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashRequest proto.InternalMessageInfo
+
+func (m *HashRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type AddressRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Height               string   `protobuf:"bytes,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressRequest) Reset()         { *m = AddressRequest{} }
+func (m *AddressRequest) String() string { return proto.CompactTextString(m) }
+func (*AddressRequest) ProtoMessage()    {}
+func (*AddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{3}
+}
+
+func (m *AddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressRequest.Unmarshal(m, b)
+}
+func (m *AddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressRequest.Marshal(b, m, deterministic)
+}
+func (m *AddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressRequest.Merge(m, src)
+}
+func (m *AddressRequest) XXX_Size() int {
+	return xxx_messageInfo_AddressRequest.Size(m)
+}
+func (m *AddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressRequest proto.InternalMessageInfo
+
+func (m *AddressRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AddressRequest) GetHeight() string {
+	if m != nil {
+		return m.Height
+	}
+	return ""
+}
+
+type CallRequest struct {
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// JSON of `data` of icx_call.
+	Data                 string   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Height               string   `protobuf:"bytes,4,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallRequest) Reset()         { *m = CallRequest{} }
+func (m *CallRequest) String() string { return proto.CompactTextString(m) }
+func (*CallRequest) ProtoMessage()    {}
+func (*CallRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{4}
+}
+
+func (m *CallRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallRequest.Unmarshal(m, b)
+}
+func (m *CallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallRequest.Marshal(b, m, deterministic)
+}
+func (m *CallRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallRequest.Merge(m, src)
+}
+func (m *CallRequest) XXX_Size() int {
+	return xxx_messageInfo_CallRequest.Size(m)
+}
+func (m *CallRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallRequest proto.InternalMessageInfo
+
+func (m *CallRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *CallRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *CallRequest) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *CallRequest) GetHeight() string {
+	if m != nil {
+		return m.Height
+	}
+	return ""
+}
+
+type SendTransactionRequest struct {
+	// JSON of the signed transaction (params of icx_sendTransaction).
+	Transaction string `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Wait for the result like icx_sendTransactionAndWait.
+	Wait                 bool     `protobuf:"varint,2,opt,name=wait,proto3" json:"wait,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendTransactionRequest) Reset()         { *m = SendTransactionRequest{} }
+func (m *SendTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendTransactionRequest) ProtoMessage()    {}
+func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{5}
+}
+
+func (m *SendTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendTransactionRequest.Unmarshal(m, b)
+}
+func (m *SendTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *SendTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendTransactionRequest.Merge(m, src)
+}
+func (m *SendTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SendTransactionRequest.Size(m)
+}
+func (m *SendTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendTransactionRequest proto.InternalMessageInfo
+
+func (m *SendTransactionRequest) GetTransaction() string {
+	if m != nil {
+		return m.Transaction
+	}
+	return ""
+}
+
+func (m *SendTransactionRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+type HashResponse struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashResponse) Reset()         { *m = HashResponse{} }
+func (m *HashResponse) String() string { return proto.CompactTextString(m) }
+func (*HashResponse) ProtoMessage()    {}
+func (*HashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{6}
+}
+
+func (m *HashResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashResponse.Unmarshal(m, b)
+}
+func (m *HashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashResponse.Marshal(b, m, deterministic)
+}
+func (m *HashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashResponse.Merge(m, src)
+}
+func (m *HashResponse) XXX_Size() int {
+	return xxx_messageInfo_HashResponse.Size(m)
+}
+func (m *HashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashResponse proto.InternalMessageInfo
+
+func (m *HashResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type IntResponse struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IntResponse) Reset()         { *m = IntResponse{} }
+func (m *IntResponse) String() string { return proto.CompactTextString(m) }
+func (*IntResponse) ProtoMessage()    {}
+func (*IntResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{7}
+}
+
+func (m *IntResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IntResponse.Unmarshal(m, b)
+}
+func (m *IntResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IntResponse.Marshal(b, m, deterministic)
+}
+func (m *IntResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IntResponse.Merge(m, src)
+}
+func (m *IntResponse) XXX_Size() int {
+	return xxx_messageInfo_IntResponse.Size(m)
+}
+func (m *IntResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IntResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IntResponse proto.InternalMessageInfo
+
+func (m *IntResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type JSONResponse struct {
+	Json                 string   `protobuf:"bytes,1,opt,name=json,proto3" json:"json,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JSONResponse) Reset()         { *m = JSONResponse{} }
+func (m *JSONResponse) String() string { return proto.CompactTextString(m) }
+func (*JSONResponse) ProtoMessage()    {}
+func (*JSONResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{8}
+}
+
+func (m *JSONResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JSONResponse.Unmarshal(m, b)
+}
+func (m *JSONResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JSONResponse.Marshal(b, m, deterministic)
+}
+func (m *JSONResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JSONResponse.Merge(m, src)
+}
+func (m *JSONResponse) XXX_Size() int {
+	return xxx_messageInfo_JSONResponse.Size(m)
+}
+func (m *JSONResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JSONResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JSONResponse proto.InternalMessageInfo
+
+func (m *JSONResponse) GetJson() string {
+	if m != nil {
+		return m.Json
+	}
+	return ""
+}
+
+type Status struct {
+	Channel              string   `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Nid                  string   `protobuf:"bytes,2,opt,name=nid,proto3" json:"nid,omitempty"`
+	State                string   `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Height               int64    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash            string   `protobuf:"bytes,5,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Status) Reset()         { *m = Status{} }
+func (m *Status) String() string { return proto.CompactTextString(m) }
+func (*Status) ProtoMessage()    {}
+func (*Status) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{9}
+}
+
+func (m *Status) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Status.Unmarshal(m, b)
+}
+func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+}
+func (m *Status) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Status.Merge(m, src)
+}
+func (m *Status) XXX_Size() int {
+	return xxx_messageInfo_Status.Size(m)
+}
+func (m *Status) XXX_DiscardUnknown() {
+	xxx_messageInfo_Status.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Status proto.InternalMessageInfo
+
+func (m *Status) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *Status) GetNid() string {
+	if m != nil {
+		return m.Nid
+	}
+	return ""
+}
+
+func (m *Status) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Status) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Status) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+type Block struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash                 string   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	PrevHash             string   `protobuf:"bytes,3,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TxHashes             []string `protobuf:"bytes,5,rep,name=tx_hashes,json=txHashes,proto3" json:"tx_hashes,omitempty"`
+	Json                 string   `protobuf:"bytes,6,opt,name=json,proto3" json:"json,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Block) Reset()         { *m = Block{} }
+func (m *Block) String() string { return proto.CompactTextString(m) }
+func (*Block) ProtoMessage()    {}
+func (*Block) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{10}
+}
+
+func (m *Block) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Block.Unmarshal(m, b)
+}
+func (m *Block) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Block.Marshal(b, m, deterministic)
+}
+func (m *Block) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Block.Merge(m, src)
+}
+func (m *Block) XXX_Size() int {
+	return xxx_messageInfo_Block.Size(m)
+}
+func (m *Block) XXX_DiscardUnknown() {
+	xxx_messageInfo_Block.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Block proto.InternalMessageInfo
+
+func (m *Block) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Block) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Block) GetPrevHash() string {
+	if m != nil {
+		return m.PrevHash
+	}
+	return ""
+}
+
+func (m *Block) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Block) GetTxHashes() []string {
+	if m != nil {
+		return m.TxHashes
+	}
+	return nil
+}
+
+func (m *Block) GetJson() string {
+	if m != nil {
+		return m.Json
+	}
+	return ""
+}
+
+type Transaction struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	From                 string   `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string   `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	BlockHeight          string   `protobuf:"bytes,4,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockHash            string   `protobuf:"bytes,5,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Json                 string   `protobuf:"bytes,6,opt,name=json,proto3" json:"json,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{11}
+}
+
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transaction.Unmarshal(m, b)
+}
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+}
+func (m *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(m, src)
+}
+func (m *Transaction) XXX_Size() int {
+	return xxx_messageInfo_Transaction.Size(m)
+}
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
+
+func (m *Transaction) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Transaction) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *Transaction) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *Transaction) GetBlockHeight() string {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return ""
+}
+
+func (m *Transaction) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *Transaction) GetJson() string {
+	if m != nil {
+		return m.Json
+	}
+	return ""
+}
+
+type EventLog struct {
+	ScoreAddress         string   `protobuf:"bytes,1,opt,name=score_address,json=scoreAddress,proto3" json:"score_address,omitempty"`
+	Indexed              []string `protobuf:"bytes,2,rep,name=indexed,proto3" json:"indexed,omitempty"`
+	Data                 []string `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventLog) Reset()         { *m = EventLog{} }
+func (m *EventLog) String() string { return proto.CompactTextString(m) }
+func (*EventLog) ProtoMessage()    {}
+func (*EventLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{12}
+}
+
+func (m *EventLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventLog.Unmarshal(m, b)
+}
+func (m *EventLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventLog.Marshal(b, m, deterministic)
+}
+func (m *EventLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventLog.Merge(m, src)
+}
+func (m *EventLog) XXX_Size() int {
+	return xxx_messageInfo_EventLog.Size(m)
+}
+func (m *EventLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventLog proto.InternalMessageInfo
+
+func (m *EventLog) GetScoreAddress() string {
+	if m != nil {
+		return m.ScoreAddress
+	}
+	return ""
+}
+
+func (m *EventLog) GetIndexed() []string {
+	if m != nil {
+		return m.Indexed
+	}
+	return nil
+}
+
+func (m *EventLog) GetData() []string {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type TransactionResult struct {
+	TxHash               string      `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Status               string      `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	BlockHeight          string      `protobuf:"bytes,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockHash            string      `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	ScoreAddress         string      `protobuf:"bytes,5,opt,name=score_address,json=scoreAddress,proto3" json:"score_address,omitempty"`
+	StepUsed             string      `protobuf:"bytes,6,opt,name=step_used,json=stepUsed,proto3" json:"step_used,omitempty"`
+	EventLogs            []*EventLog `protobuf:"bytes,7,rep,name=event_logs,json=eventLogs,proto3" json:"event_logs,omitempty"`
+	Json                 string      `protobuf:"bytes,8,opt,name=json,proto3" json:"json,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *TransactionResult) Reset()         { *m = TransactionResult{} }
+func (m *TransactionResult) String() string { return proto.CompactTextString(m) }
+func (*TransactionResult) ProtoMessage()    {}
+func (*TransactionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{13}
+}
+
+func (m *TransactionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionResult.Unmarshal(m, b)
+}
+func (m *TransactionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionResult.Marshal(b, m, deterministic)
+}
+func (m *TransactionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionResult.Merge(m, src)
+}
+func (m *TransactionResult) XXX_Size() int {
+	return xxx_messageInfo_TransactionResult.Size(m)
+}
+func (m *TransactionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionResult proto.InternalMessageInfo
+
+func (m *TransactionResult) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *TransactionResult) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *TransactionResult) GetBlockHeight() string {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return ""
+}
+
+func (m *TransactionResult) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *TransactionResult) GetScoreAddress() string {
+	if m != nil {
+		return m.ScoreAddress
+	}
+	return ""
+}
+
+func (m *TransactionResult) GetStepUsed() string {
+	if m != nil {
+		return m.StepUsed
+	}
+	return ""
+}
+
+func (m *TransactionResult) GetEventLogs() []*EventLog {
+	if m != nil {
+		return m.EventLogs
+	}
+	return nil
+}
+
+func (m *TransactionResult) GetJson() string {
+	if m != nil {
+		return m.Json
+	}
+	return ""
+}
+
+// Value of a parameter of the event to match.
+type EventValue struct {
+	// Matches any value if it's set, then value is ignored.
+	Any                  bool     `protobuf:"varint,1,opt,name=any,proto3" json:"any,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventValue) Reset()         { *m = EventValue{} }
+func (m *EventValue) String() string { return proto.CompactTextString(m) }
+func (*EventValue) ProtoMessage()    {}
+func (*EventValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{14}
+}
+
+func (m *EventValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventValue.Unmarshal(m, b)
+}
+func (m *EventValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventValue.Marshal(b, m, deterministic)
+}
+func (m *EventValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventValue.Merge(m, src)
+}
+func (m *EventValue) XXX_Size() int {
+	return xxx_messageInfo_EventValue.Size(m)
+}
+func (m *EventValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventValue proto.InternalMessageInfo
+
+func (m *EventValue) GetAny() bool {
+	if m != nil {
+		return m.Any
+	}
+	return false
+}
+
+func (m *EventValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type EventFilter struct {
+	Addrs []string `protobuf:"bytes,1,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	Event string   `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	// Values to match, missing values at the end match any value.
+	Indexed              []*EventValue `protobuf:"bytes,3,rep,name=indexed,proto3" json:"indexed,omitempty"`
+	Data                 []*EventValue `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *EventFilter) Reset()         { *m = EventFilter{} }
+func (m *EventFilter) String() string { return proto.CompactTextString(m) }
+func (*EventFilter) ProtoMessage()    {}
+func (*EventFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{15}
+}
+
+func (m *EventFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventFilter.Unmarshal(m, b)
+}
+func (m *EventFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventFilter.Marshal(b, m, deterministic)
+}
+func (m *EventFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventFilter.Merge(m, src)
+}
+func (m *EventFilter) XXX_Size() int {
+	return xxx_messageInfo_EventFilter.Size(m)
+}
+func (m *EventFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventFilter proto.InternalMessageInfo
+
+func (m *EventFilter) GetAddrs() []string {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *EventFilter) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *EventFilter) GetIndexed() []*EventValue {
+	if m != nil {
+		return m.Indexed
+	}
+	return nil
+}
+
+func (m *EventFilter) GetData() []*EventValue {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type MonitorBlocksRequest struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MonitorBlocksRequest) Reset()         { *m = MonitorBlocksRequest{} }
+func (m *MonitorBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*MonitorBlocksRequest) ProtoMessage()    {}
+func (*MonitorBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{16}
+}
+
+func (m *MonitorBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MonitorBlocksRequest.Unmarshal(m, b)
+}
+func (m *MonitorBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MonitorBlocksRequest.Marshal(b, m, deterministic)
+}
+func (m *MonitorBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MonitorBlocksRequest.Merge(m, src)
+}
+func (m *MonitorBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_MonitorBlocksRequest.Size(m)
+}
+func (m *MonitorBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MonitorBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MonitorBlocksRequest proto.InternalMessageInfo
+
+func (m *MonitorBlocksRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type MonitorEventsRequest struct {
+	Height  int64          `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Filters []*EventFilter `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+	// Include matched event logs in notifications.
+	Logs                 bool     `protobuf:"varint,3,opt,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MonitorEventsRequest) Reset()         { *m = MonitorEventsRequest{} }
+func (m *MonitorEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*MonitorEventsRequest) ProtoMessage()    {}
+func (*MonitorEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{17}
+}
+
+func (m *MonitorEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MonitorEventsRequest.Unmarshal(m, b)
+}
+func (m *MonitorEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MonitorEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *MonitorEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MonitorEventsRequest.Merge(m, src)
+}
+func (m *MonitorEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_MonitorEventsRequest.Size(m)
+}
+func (m *MonitorEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MonitorEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MonitorEventsRequest proto.InternalMessageInfo
+
+func (m *MonitorEventsRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *MonitorEventsRequest) GetFilters() []*EventFilter {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+func (m *MonitorEventsRequest) GetLogs() bool {
+	if m != nil {
+		return m.Logs
+	}
+	return false
+}
+
+type BlockNotification struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash                 string   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockNotification) Reset()         { *m = BlockNotification{} }
+func (m *BlockNotification) String() string { return proto.CompactTextString(m) }
+func (*BlockNotification) ProtoMessage()    {}
+func (*BlockNotification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{18}
+}
+
+func (m *BlockNotification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockNotification.Unmarshal(m, b)
+}
+func (m *BlockNotification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockNotification.Marshal(b, m, deterministic)
+}
+func (m *BlockNotification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockNotification.Merge(m, src)
+}
+func (m *BlockNotification) XXX_Size() int {
+	return xxx_messageInfo_BlockNotification.Size(m)
+}
+func (m *BlockNotification) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockNotification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockNotification proto.InternalMessageInfo
+
+func (m *BlockNotification) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockNotification) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type EventNotification struct {
+	Height int64  `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash   string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Index of the transaction in the block.
+	Index int32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// Index of the matched filter.
+	Filter               int32       `protobuf:"varint,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	Events               []int32     `protobuf:"varint,5,rep,packed,name=events,proto3" json:"events,omitempty"`
+	Logs                 []*EventLog `protobuf:"bytes,6,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *EventNotification) Reset()         { *m = EventNotification{} }
+func (m *EventNotification) String() string { return proto.CompactTextString(m) }
+func (*EventNotification) ProtoMessage()    {}
+func (*EventNotification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c40352892c18423, []int{19}
+}
+
+func (m *EventNotification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventNotification.Unmarshal(m, b)
+}
+func (m *EventNotification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventNotification.Marshal(b, m, deterministic)
+}
+func (m *EventNotification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventNotification.Merge(m, src)
+}
+func (m *EventNotification) XXX_Size() int {
+	return xxx_messageInfo_EventNotification.Size(m)
+}
+func (m *EventNotification) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventNotification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventNotification proto.InternalMessageInfo
+
+func (m *EventNotification) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *EventNotification) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *EventNotification) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *EventNotification) GetFilter() int32 {
+	if m != nil {
+		return m.Filter
+	}
+	return 0
+}
+
+func (m *EventNotification) GetEvents() []int32 {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *EventNotification) GetLogs() []*EventLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Empty)(nil), "icon.v3.Empty")
+	proto.RegisterType((*HeightRequest)(nil), "icon.v3.HeightRequest")
+	proto.RegisterType((*HashRequest)(nil), "icon.v3.HashRequest")
+	proto.RegisterType((*AddressRequest)(nil), "icon.v3.AddressRequest")
+	proto.RegisterType((*CallRequest)(nil), "icon.v3.CallRequest")
+	proto.RegisterType((*SendTransactionRequest)(nil), "icon.v3.SendTransactionRequest")
+	proto.RegisterType((*HashResponse)(nil), "icon.v3.HashResponse")
+	proto.RegisterType((*IntResponse)(nil), "icon.v3.IntResponse")
+	proto.RegisterType((*JSONResponse)(nil), "icon.v3.JSONResponse")
+	proto.RegisterType((*Status)(nil), "icon.v3.Status")
+	proto.RegisterType((*Block)(nil), "icon.v3.Block")
+	proto.RegisterType((*Transaction)(nil), "icon.v3.Transaction")
+	proto.RegisterType((*EventLog)(nil), "icon.v3.EventLog")
+	proto.RegisterType((*TransactionResult)(nil), "icon.v3.TransactionResult")
+	proto.RegisterType((*EventValue)(nil), "icon.v3.EventValue")
+	proto.RegisterType((*EventFilter)(nil), "icon.v3.EventFilter")
+	proto.RegisterType((*MonitorBlocksRequest)(nil), "icon.v3.MonitorBlocksRequest")
+	proto.RegisterType((*MonitorEventsRequest)(nil), "icon.v3.MonitorEventsRequest")
+	proto.RegisterType((*BlockNotification)(nil), "icon.v3.BlockNotification")
+	proto.RegisterType((*EventNotification)(nil), "icon.v3.EventNotification")
+}
+
+func init() {
+	proto.RegisterFile("icon.proto", fileDescriptor_0c40352892c18423)
+}
+
+var fileDescriptor_0c40352892c18423 = []byte{
+	// 1002 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdd, 0x6e, 0xdc, 0x44,
+	0x14, 0xd6, 0xae, 0xb3, 0x7f, 0xc7, 0x49, 0xda, 0x0c, 0x21, 0x5d, 0xa5, 0x54, 0xa4, 0xae, 0x50,
+	0x7b, 0xd1, 0x6e, 0xa2, 0x04, 0x71, 0x01, 0x02, 0xc4, 0xa2, 0x92, 0x04, 0x85, 0x20, 0x6d, 0x42,
+	0x2f, 0x90, 0xaa, 0x68, 0xe2, 0x9d, 0xec, 0xba, 0x78, 0x3d, 0xc6, 0x33, 0xbb, 0x24, 0x97, 0xbc,
+	0x00, 0x12, 0xd7, 0x5c, 0xf0, 0x02, 0x3c, 0x04, 0x8f, 0x86, 0xce, 0x99, 0xb1, 0x3d, 0xde, 0x1f,
+	0x0a, 0xbd, 0x3b, 0xe7, 0xf8, 0xfc, 0x7e, 0xe7, 0x67, 0x0c, 0x10, 0x85, 0x32, 0xe9, 0xa5, 0x99,
+	0xd4, 0x92, 0xb5, 0x88, 0x9e, 0x1d, 0x05, 0x2d, 0x68, 0xbc, 0x9c, 0xa4, 0xfa, 0x2e, 0x78, 0x0a,
+	0x1b, 0x27, 0x22, 0x1a, 0x8d, 0xf5, 0x40, 0xfc, 0x3c, 0x15, 0x4a, 0xb3, 0x1d, 0x68, 0x8e, 0x49,
+	0xd0, 0xad, 0xed, 0xd5, 0x9e, 0x75, 0x06, 0x96, 0x0b, 0x1e, 0x83, 0x7f, 0xc2, 0xd5, 0x38, 0x57,
+	0x63, 0xb0, 0x36, 0xe6, 0x6a, 0x6c, 0x95, 0x88, 0x0e, 0xfa, 0xb0, 0xf9, 0xd5, 0x70, 0x98, 0x09,
+	0xa5, 0x72, 0xad, 0x2e, 0xb4, 0xb8, 0x91, 0x58, 0xc5, 0x9c, 0x75, 0xc2, 0xd4, 0x2b, 0x61, 0x5e,
+	0x83, 0xff, 0x35, 0x8f, 0x63, 0x27, 0xcc, 0x4d, 0x26, 0x27, 0x79, 0x18, 0xa4, 0xd9, 0x26, 0xd4,
+	0xb5, 0xb4, 0x66, 0x75, 0x2d, 0x51, 0x67, 0xc8, 0x35, 0xef, 0x7a, 0x46, 0x07, 0x69, 0xc7, 0xfd,
+	0x5a, 0xc5, 0xfd, 0x39, 0xec, 0x5c, 0x88, 0x64, 0x78, 0x99, 0xf1, 0x44, 0xf1, 0x50, 0x47, 0x32,
+	0xc9, 0x23, 0xed, 0x81, 0xaf, 0x4b, 0xa9, 0x0d, 0xe8, 0x8a, 0x30, 0xce, 0x2f, 0x3c, 0x32, 0x09,
+	0xb7, 0x07, 0x44, 0x07, 0x01, 0xac, 0x1b, 0x54, 0x54, 0x2a, 0x13, 0x25, 0x96, 0xc2, 0xf2, 0x04,
+	0xfc, 0xd3, 0x44, 0x17, 0x2a, 0xdb, 0xd0, 0x98, 0xf1, 0x78, 0x2a, 0xac, 0x8e, 0x61, 0xd0, 0xd1,
+	0xb7, 0x17, 0xdf, 0x9f, 0xbb, 0x8e, 0xde, 0xa8, 0x22, 0x0f, 0xa2, 0x83, 0x5f, 0x6b, 0xd0, 0xbc,
+	0xd0, 0x5c, 0x4f, 0x15, 0x02, 0x1b, 0x8e, 0x79, 0x92, 0x88, 0x38, 0x07, 0xd6, 0xb2, 0xec, 0x3e,
+	0x78, 0x49, 0x34, 0xb4, 0xf0, 0x20, 0x89, 0x01, 0x95, 0xe6, 0x5a, 0x58, 0x80, 0x0c, 0x33, 0x87,
+	0x90, 0x97, 0x23, 0xc4, 0x1e, 0x01, 0x5c, 0xc7, 0x32, 0xfc, 0xe9, 0x8a, 0xea, 0x68, 0x90, 0x49,
+	0x87, 0x24, 0x58, 0x68, 0xf0, 0x67, 0x0d, 0x1a, 0x7d, 0xe4, 0xe6, 0x06, 0xa5, 0x74, 0x90, 0x43,
+	0x50, 0x2f, 0x21, 0x60, 0x0f, 0xa1, 0x93, 0x66, 0x62, 0x66, 0x7c, 0x9a, 0x34, 0xda, 0x28, 0x40,
+	0x97, 0xec, 0x03, 0xe8, 0xe8, 0x68, 0x22, 0x94, 0xe6, 0x93, 0xd4, 0x26, 0x53, 0x0a, 0xd0, 0x54,
+	0xdf, 0x92, 0xa1, 0x50, 0xdd, 0xc6, 0x9e, 0x87, 0xa6, 0xfa, 0xf6, 0x84, 0xf8, 0x02, 0xa5, 0xa6,
+	0x83, 0xd2, 0x1f, 0x35, 0xf0, 0x2f, 0xab, 0x6d, 0x9b, 0x6f, 0x49, 0x31, 0x56, 0xf5, 0x85, 0xb1,
+	0xf2, 0x8a, 0xb1, 0x7a, 0x0c, 0xeb, 0x16, 0x08, 0x77, 0x90, 0x7c, 0x03, 0xc5, 0x7f, 0xc1, 0x6a,
+	0x69, 0x76, 0xaf, 0xa1, 0xfd, 0x72, 0x26, 0x12, 0x7d, 0x26, 0x47, 0xec, 0x09, 0x6c, 0xa8, 0x50,
+	0x66, 0xe2, 0xaa, 0xba, 0x23, 0xeb, 0x24, 0xb4, 0x9b, 0x84, 0x9d, 0x8e, 0x92, 0xa1, 0xb8, 0x15,
+	0xd8, 0x53, 0xac, 0x3e, 0x67, 0x9d, 0xb9, 0xf7, 0xf2, 0xb9, 0x0f, 0x7e, 0xab, 0xc3, 0x56, 0x65,
+	0xb8, 0xd5, 0x34, 0xd6, 0xec, 0x01, 0xb4, 0x2c, 0x86, 0xf9, 0x52, 0x1b, 0x04, 0xb1, 0x87, 0x8a,
+	0x06, 0x2a, 0xdf, 0x42, 0xc3, 0x2d, 0xd4, 0xee, 0xbd, 0xad, 0xf6, 0xb5, 0xf9, 0xda, 0x17, 0x6a,
+	0x6b, 0x2c, 0xa9, 0xed, 0x21, 0x74, 0x94, 0x16, 0xe9, 0xd5, 0x54, 0x89, 0xa1, 0x45, 0xa9, 0x8d,
+	0x82, 0x1f, 0x94, 0x18, 0xb2, 0x03, 0x00, 0x81, 0x48, 0x5d, 0xc5, 0x72, 0xa4, 0xba, 0xad, 0x3d,
+	0xef, 0x99, 0x7f, 0xb8, 0xd5, 0xb3, 0x07, 0xac, 0x97, 0x83, 0x38, 0xe8, 0x08, 0x4b, 0x95, 0xd3,
+	0xd0, 0x76, 0xf0, 0xfe, 0x18, 0x80, 0x54, 0x5f, 0xe1, 0x96, 0xe1, 0x72, 0xf0, 0xe4, 0x8e, 0x40,
+	0x68, 0x0f, 0x90, 0x2c, 0xb7, 0xb1, 0xee, 0x6e, 0xe3, 0xef, 0x35, 0xf0, 0xc9, 0xec, 0x9b, 0x28,
+	0xd6, 0x22, 0x43, 0x2d, 0xac, 0x03, 0x3b, 0x84, 0x58, 0x1b, 0x06, 0xa5, 0x14, 0x3c, 0xb7, 0x25,
+	0x86, 0xbd, 0x28, 0x1b, 0xe6, 0x51, 0xd2, 0xef, 0x55, 0x93, 0xa6, 0x4c, 0xca, 0x2e, 0x3e, 0xb5,
+	0x5d, 0x5c, 0x5b, 0xad, 0x6b, 0x5a, 0xdb, 0x83, 0xed, 0xef, 0x64, 0x12, 0x69, 0x99, 0xd1, 0xfe,
+	0xa9, 0xe5, 0x07, 0xbb, 0xd8, 0xc3, 0x20, 0x2b, 0xf4, 0xc9, 0xd5, 0xdb, 0xf4, 0x59, 0x0f, 0x5a,
+	0x37, 0x54, 0xad, 0xa2, 0x41, 0xf3, 0x0f, 0xb7, 0xab, 0xb9, 0x18, 0x28, 0x06, 0xb9, 0x12, 0xa2,
+	0x4d, 0x9d, 0xf1, 0xcc, 0x39, 0x44, 0x3a, 0xf8, 0x12, 0xb6, 0x28, 0xb9, 0x73, 0xa9, 0xa3, 0x9b,
+	0x28, 0xe4, 0xb4, 0x80, 0xff, 0xe3, 0x50, 0x04, 0x7f, 0xd5, 0x60, 0x8b, 0xa2, 0xbd, 0xab, 0x07,
+	0x6c, 0x0a, 0x41, 0x4b, 0x79, 0x35, 0x06, 0x86, 0x41, 0x0f, 0x26, 0x6f, 0x9a, 0xd4, 0xc6, 0xc0,
+	0x72, 0x28, 0xa7, 0xae, 0x99, 0xd3, 0xd2, 0x18, 0x58, 0x8e, 0x7d, 0x64, 0x8b, 0x6b, 0xae, 0x1a,
+	0x3b, 0xfa, 0x7c, 0xf8, 0x77, 0x13, 0x9a, 0xa7, 0xa1, 0x4c, 0x5e, 0x1d, 0xb1, 0xe7, 0xd0, 0x39,
+	0x16, 0xda, 0x9e, 0xe7, 0xcd, 0xd2, 0x00, 0x5f, 0xd9, 0xdd, 0x7b, 0x05, 0x6f, 0x15, 0x7a, 0xb0,
+	0x7e, 0x2c, 0xf4, 0x19, 0x57, 0xda, 0x1c, 0xd3, 0x79, 0x83, 0x92, 0x37, 0xdf, 0x3f, 0x85, 0xfb,
+	0xc7, 0xc2, 0xe8, 0xf6, 0xef, 0xec, 0x06, 0xee, 0x14, 0x3a, 0x95, 0x17, 0x7c, 0xc1, 0xf6, 0x13,
+	0xd8, 0x74, 0x6c, 0x09, 0xa3, 0xd2, 0xb2, 0x7c, 0xd2, 0x17, 0xec, 0xfa, 0xb0, 0x7d, 0x2c, 0xb4,
+	0x73, 0x4d, 0xfe, 0xd5, 0xba, 0x94, 0x3a, 0x16, 0xec, 0x64, 0xde, 0x87, 0xbd, 0x48, 0xcb, 0x7d,
+	0xec, 0x2e, 0xf3, 0x61, 0x2d, 0x4e, 0xe1, 0xde, 0xdc, 0xcb, 0xcd, 0x3e, 0x2c, 0x51, 0x5d, 0xfa,
+	0xa6, 0xef, 0xbe, 0x3f, 0x17, 0xc5, 0xbe, 0xad, 0x47, 0xb0, 0x86, 0xff, 0x18, 0x4e, 0x12, 0xce,
+	0x2f, 0x87, 0x63, 0x54, 0x79, 0x90, 0x3f, 0x03, 0x40, 0x14, 0x79, 0xcc, 0x93, 0x50, 0xb0, 0x07,
+	0x85, 0x52, 0xf5, 0x8f, 0xc7, 0x81, 0xc1, 0x7d, 0xf3, 0xbf, 0xa0, 0x16, 0x5c, 0x4a, 0xcd, 0xe3,
+	0x8b, 0x69, 0x9a, 0xc6, 0x77, 0x2b, 0x9b, 0xb7, 0xdc, 0xfe, 0x73, 0xf0, 0x71, 0xb8, 0xe8, 0x76,
+	0xa6, 0xd1, 0xea, 0xe8, 0x2b, 0x72, 0x3f, 0x83, 0x8d, 0xca, 0xe9, 0x60, 0x8f, 0x0a, 0xbd, 0x65,
+	0x27, 0xc5, 0xe9, 0xc3, 0xc2, 0x36, 0x1f, 0xd4, 0x1c, 0x6f, 0xe6, 0xb0, 0x2c, 0x7a, 0xab, 0x1c,
+	0x1c, 0xc7, 0xdb, 0xc2, 0x66, 0x1f, 0xd4, 0xfa, 0xbd, 0x1f, 0x9f, 0x8f, 0x22, 0x3d, 0x9e, 0x5e,
+	0xf7, 0x42, 0x39, 0xd9, 0x47, 0xcd, 0x17, 0x69, 0x26, 0xdf, 0x88, 0x50, 0xef, 0x8f, 0x64, 0x2c,
+	0x65, 0xba, 0xaf, 0x44, 0x36, 0x13, 0x19, 0x7d, 0x4a, 0xaf, 0xaf, 0x9b, 0xf4, 0x27, 0x7b, 0xf4,
+	0xcf, 0x00, 0x32, 0xd9, 0x96, 0xa8, 0xd7, 0x0a, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// IconV3Client is the client API for IconV3 service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IconV3Client interface {
+	GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Status, error)
+	GetLastBlock(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Block, error)
+	GetBlockByHeight(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*Block, error)
+	GetBlockByHash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*Block, error)
+	GetTransactionByHash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*Transaction, error)
+	GetTransactionResult(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*TransactionResult, error)
+	SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*HashResponse, error)
+	Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*JSONResponse, error)
+	GetBalance(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*IntResponse, error)
+	GetTotalSupply(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*IntResponse, error)
+	GetScoreApi(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*JSONResponse, error)
+	MonitorBlocks(ctx context.Context, in *MonitorBlocksRequest, opts ...grpc.CallOption) (IconV3_MonitorBlocksClient, error)
+	MonitorEvents(ctx context.Context, in *MonitorEventsRequest, opts ...grpc.CallOption) (IconV3_MonitorEventsClient, error)
+}
+
+type iconV3Client struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIconV3Client(cc grpc.ClientConnInterface) IconV3Client {
+	return &iconV3Client{cc}
+}
+
+func (c *iconV3Client) GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) GetLastBlock(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetLastBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) GetBlockByHeight(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetBlockByHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) GetBlockByHash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetBlockByHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) GetTransactionByHash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetTransactionByHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) GetTransactionResult(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*TransactionResult, error) {
+	out := new(TransactionResult)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetTransactionResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*HashResponse, error) {
+	out := new(HashResponse)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/SendTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*JSONResponse, error) {
+	out := new(JSONResponse)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/Call", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) GetBalance(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*IntResponse, error) {
+	out := new(IntResponse)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) GetTotalSupply(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*IntResponse, error) {
+	out := new(IntResponse)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetTotalSupply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) GetScoreApi(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*JSONResponse, error) {
+	out := new(JSONResponse)
+	err := c.cc.Invoke(ctx, "/icon.v3.IconV3/GetScoreApi", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iconV3Client) MonitorBlocks(ctx context.Context, in *MonitorBlocksRequest, opts ...grpc.CallOption) (IconV3_MonitorBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_IconV3_serviceDesc.Streams[0], "/icon.v3.IconV3/MonitorBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &iconV3MonitorBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IconV3_MonitorBlocksClient interface {
+	Recv() (*BlockNotification, error)
+	grpc.ClientStream
+}
+
+type iconV3MonitorBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *iconV3MonitorBlocksClient) Recv() (*BlockNotification, error) {
+	m := new(BlockNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *iconV3Client) MonitorEvents(ctx context.Context, in *MonitorEventsRequest, opts ...grpc.CallOption) (IconV3_MonitorEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_IconV3_serviceDesc.Streams[1], "/icon.v3.IconV3/MonitorEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &iconV3MonitorEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IconV3_MonitorEventsClient interface {
+	Recv() (*EventNotification, error)
+	grpc.ClientStream
+}
+
+type iconV3MonitorEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *iconV3MonitorEventsClient) Recv() (*EventNotification, error) {
+	m := new(EventNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IconV3Server is the server API for IconV3 service.
+type IconV3Server interface {
+	GetStatus(context.Context, *Empty) (*Status, error)
+	GetLastBlock(context.Context, *Empty) (*Block, error)
+	GetBlockByHeight(context.Context, *HeightRequest) (*Block, error)
+	GetBlockByHash(context.Context, *HashRequest) (*Block, error)
+	GetTransactionByHash(context.Context, *HashRequest) (*Transaction, error)
+	GetTransactionResult(context.Context, *HashRequest) (*TransactionResult, error)
+	SendTransaction(context.Context, *SendTransactionRequest) (*HashResponse, error)
+	Call(context.Context, *CallRequest) (*JSONResponse, error)
+	GetBalance(context.Context, *AddressRequest) (*IntResponse, error)
+	GetTotalSupply(context.Context, *HeightRequest) (*IntResponse, error)
+	GetScoreApi(context.Context, *AddressRequest) (*JSONResponse, error)
+	MonitorBlocks(*MonitorBlocksRequest, IconV3_MonitorBlocksServer) error
+	MonitorEvents(*MonitorEventsRequest, IconV3_MonitorEventsServer) error
+}
+
+// UnimplementedIconV3Server can be embedded to have forward compatible implementations.
+type UnimplementedIconV3Server struct {
+}
+
+func (*UnimplementedIconV3Server) GetStatus(ctx context.Context, req *Empty) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (*UnimplementedIconV3Server) GetLastBlock(ctx context.Context, req *Empty) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastBlock not implemented")
+}
+func (*UnimplementedIconV3Server) GetBlockByHeight(ctx context.Context, req *HeightRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHeight not implemented")
+}
+func (*UnimplementedIconV3Server) GetBlockByHash(ctx context.Context, req *HashRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHash not implemented")
+}
+func (*UnimplementedIconV3Server) GetTransactionByHash(ctx context.Context, req *HashRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionByHash not implemented")
+}
+func (*UnimplementedIconV3Server) GetTransactionResult(ctx context.Context, req *HashRequest) (*TransactionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionResult not implemented")
+}
+func (*UnimplementedIconV3Server) SendTransaction(ctx context.Context, req *SendTransactionRequest) (*HashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
+}
+func (*UnimplementedIconV3Server) Call(ctx context.Context, req *CallRequest) (*JSONResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
+func (*UnimplementedIconV3Server) GetBalance(ctx context.Context, req *AddressRequest) (*IntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (*UnimplementedIconV3Server) GetTotalSupply(ctx context.Context, req *HeightRequest) (*IntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTotalSupply not implemented")
+}
+func (*UnimplementedIconV3Server) GetScoreApi(ctx context.Context, req *AddressRequest) (*JSONResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScoreApi not implemented")
+}
+func (*UnimplementedIconV3Server) MonitorBlocks(req *MonitorBlocksRequest, srv IconV3_MonitorBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method MonitorBlocks not implemented")
+}
+func (*UnimplementedIconV3Server) MonitorEvents(req *MonitorEventsRequest, srv IconV3_MonitorEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method MonitorEvents not implemented")
+}
+
+func RegisterIconV3Server(s *grpc.Server, srv IconV3Server) {
+	s.RegisterService(&_IconV3_serviceDesc, srv)
+}
+
+func _IconV3_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_GetLastBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetLastBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetLastBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetLastBlock(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_GetBlockByHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetBlockByHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetBlockByHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetBlockByHeight(ctx, req.(*HeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_GetBlockByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetBlockByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetBlockByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetBlockByHash(ctx, req.(*HashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_GetTransactionByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetTransactionByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetTransactionByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetTransactionByHash(ctx, req.(*HashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_GetTransactionResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetTransactionResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetTransactionResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetTransactionResult(ctx, req.(*HashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).SendTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/SendTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).SendTransaction(ctx, req.(*SendTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).Call(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetBalance(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_GetTotalSupply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetTotalSupply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetTotalSupply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetTotalSupply(ctx, req.(*HeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_GetScoreApi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IconV3Server).GetScoreApi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/icon.v3.IconV3/GetScoreApi",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IconV3Server).GetScoreApi(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IconV3_MonitorBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MonitorBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IconV3Server).MonitorBlocks(m, &iconV3MonitorBlocksServer{stream})
+}
+
+type IconV3_MonitorBlocksServer interface {
+	Send(*BlockNotification) error
+	grpc.ServerStream
+}
+
+type iconV3MonitorBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *iconV3MonitorBlocksServer) Send(m *BlockNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IconV3_MonitorEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MonitorEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IconV3Server).MonitorEvents(m, &iconV3MonitorEventsServer{stream})
+}
+
+type IconV3_MonitorEventsServer interface {
+	Send(*EventNotification) error
+	grpc.ServerStream
+}
+
+type iconV3MonitorEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *iconV3MonitorEventsServer) Send(m *EventNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _IconV3_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "icon.v3.IconV3",
+	HandlerType: (*IconV3Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _IconV3_GetStatus_Handler,
+		},
+		{
+			MethodName: "GetLastBlock",
+			Handler:    _IconV3_GetLastBlock_Handler,
+		},
+		{
+			MethodName: "GetBlockByHeight",
+			Handler:    _IconV3_GetBlockByHeight_Handler,
+		},
+		{
+			MethodName: "GetBlockByHash",
+			Handler:    _IconV3_GetBlockByHash_Handler,
+		},
+		{
+			MethodName: "GetTransactionByHash",
+			Handler:    _IconV3_GetTransactionByHash_Handler,
+		},
+		{
+			MethodName: "GetTransactionResult",
+			Handler:    _IconV3_GetTransactionResult_Handler,
+		},
+		{
+			MethodName: "SendTransaction",
+			Handler:    _IconV3_SendTransaction_Handler,
+		},
+		{
+			MethodName: "Call",
+			Handler:    _IconV3_Call_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _IconV3_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetTotalSupply",
+			Handler:    _IconV3_GetTotalSupply_Handler,
+		},
+		{
+			MethodName: "GetScoreApi",
+			Handler:    _IconV3_GetScoreApi_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MonitorBlocks",
+			Handler:       _IconV3_MonitorBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "MonitorEvents",
+			Handler:       _IconV3_MonitorEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "icon.proto",
+}
@@ -72,6 +72,12 @@ func (c ErrorCode) String() string {
 		return "Timeout"
 	case ErrorCodeSystemTimeout:
 		return "SystemTimeout"
+	case ErrorCodeRateLimited:
+		return "RateLimited"
+	case ErrorCodeStateNotAvailable:
+		return "StateNotAvailable"
+	case ErrorCodeUnderpriced:
+		return "Underpriced"
 	default:
 		switch {
 		case c < ErrorCodeServer && c > ErrorCodeServer-1000:
@@ -97,13 +103,16 @@ const (
 )
 
 const (
-	ErrorCodeTxPoolOverflow ErrorCode = -31001
-	ErrorCodePending        ErrorCode = -31002
-	ErrorCodeExecuting      ErrorCode = -31003
-	ErrorCodeNotFound       ErrorCode = -31004
-	ErrorLackOfResource     ErrorCode = -31005
-	ErrorCodeTimeout        ErrorCode = -31006
-	ErrorCodeSystemTimeout  ErrorCode = -31007
+	ErrorCodeTxPoolOverflow    ErrorCode = -31001
+	ErrorCodePending           ErrorCode = -31002
+	ErrorCodeExecuting         ErrorCode = -31003
+	ErrorCodeNotFound          ErrorCode = -31004
+	ErrorLackOfResource        ErrorCode = -31005
+	ErrorCodeTimeout           ErrorCode = -31006
+	ErrorCodeSystemTimeout     ErrorCode = -31007
+	ErrorCodeRateLimited       ErrorCode = -31008
+	ErrorCodeStateNotAvailable ErrorCode = -31009
+	ErrorCodeUnderpriced       ErrorCode = -31010
 )
 
 type Error struct {
@@ -142,6 +142,17 @@ func (ctx *Context) BatchLimit() int {
 	return batchLimit
 }
 
+// RateLimiter decides whether the client may call the method.
+// server puts it in the context with "rateLimiter" key.
+type RateLimiter interface {
+	Allow(ctx *Context, method string) bool
+}
+
+func (ctx *Context) RateLimiter() RateLimiter {
+	rl, _ := ctx.Get("rateLimiter").(RateLimiter)
+	return rl
+}
+
 func (ctx *Context) GetTimeout(t time.Duration) time.Duration {
 	if v, err := ctx.opts.GetInt(IconOptionsTimeout); err != nil {
 		return t
@@ -244,33 +255,20 @@ func UnmarshalWithValidate(data []byte, v interface{}, vd echo.Validator) error
 	if err := vd.Validate(v); err != nil {
 		var msg string
 		if ve, ok := err.(validator.ValidationErrors); ok {
-			val := reflect.ValueOf(v)
-			if val.Kind() == reflect.Ptr && !val.IsNil() {
-				val = val.Elem()
-			}
-			vt := val.Type()
+			vt := reflect.TypeOf(v)
 			m := make(map[string][]string)
+			tags := make([]string, 0)
 			for _, fe := range ve {
-				sf, _ := vt.FieldByName(fe.StructField())
-				jt := sf.Tag.Get("json")
-				if jt == "" {
-					jt = fe.Field()
-				}
-				if idx := strings.Index(jt, ","); idx >= 0 {
-					jt = jt[:idx]
-				}
-				jt = "'" + jt + "'"
+				jt := "'" + jsonPathOf(vt, fe.Namespace()) + "'"
 				l, has := m[fe.Tag()]
 				if !has {
-					l = make([]string, 0)
+					tags = append(tags, fe.Tag())
 				}
-				l = append(l, jt)
-				m[fe.Tag()] = l
+				m[fe.Tag()] = append(l, jt)
 			}
-			sl := make([]string, len(m))
-			idx := 0
-			for k, l := range m {
-				sl[idx] = fmt.Sprintf("%s(%s)", k, strings.Join(l, ","))
+			sl := make([]string, len(tags))
+			for idx, k := range tags {
+				sl[idx] = fmt.Sprintf("%s(%s)", k, strings.Join(m[k], ","))
 			}
 			msg = strings.Join(sl, ",")
 		} else {
@@ -281,3 +279,48 @@ func UnmarshalWithValidate(data []byte, v interface{}, vd echo.Validator) error
 	}
 	return nil
 }
+
+// jsonPathOf returns the path of the field in JSON for the namespace of
+// the validation error, which is made of the names of struct fields.
+// e.g. "TraceBlocksParam.Addresses[1]" => "addresses[1]"
+func jsonPathOf(t reflect.Type, ns string) string {
+	segs := strings.Split(ns, ".")
+	path := make([]string, 0, len(segs))
+	for _, seg := range segs[1:] {
+		name, index := seg, ""
+		if idx := strings.Index(seg, "["); idx >= 0 {
+			name, index = seg[:idx], seg[idx:]
+		}
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t != nil && t.Kind() == reflect.Struct {
+			if sf, ok := t.FieldByName(name); ok {
+				t = sf.Type
+				if sf.Anonymous && index == "" {
+					continue
+				}
+				if jt := strings.SplitN(sf.Tag.Get("json"), ",", 2)[0]; jt != "" && jt != "-" {
+					name = jt
+				}
+			} else {
+				t = nil
+			}
+		} else {
+			t = nil
+		}
+		for i := strings.Count(index, "["); i > 0 && t != nil; i-- {
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				t = nil
+			}
+		}
+		path = append(path, name+index)
+	}
+	return strings.Join(path, ".")
+}
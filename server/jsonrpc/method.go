@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"encoding/json"
 	"net/http"
+	"reflect"
 	"sync"
 	"time"
 
@@ -17,6 +18,8 @@ type MethodRepository struct {
 	mtx     sync.RWMutex
 	methods map[string]Handler
 	allowed map[string]bool
+	params  map[string]reflect.Type
+	info    *OpenRPCInfo
 	mtr     *metric.JsonrpcMetric
 }
 
@@ -24,6 +27,7 @@ func NewMethodRepository(mtr *metric.JsonrpcMetric) *MethodRepository {
 	return &MethodRepository{
 		methods: make(map[string]Handler),
 		allowed: make(map[string]bool),
+		params:  make(map[string]reflect.Type),
 		mtr: mtr,
 	}
 }
@@ -93,6 +97,14 @@ func (mr *MethodRepository) handle(ctx *Context, raw json.RawMessage) *Response
 		return resp
 	}
 
+	if rl := ctx.RateLimiter(); rl != nil && !rl.Allow(ctx, *req.Method) {
+		resp.Error = ErrorCodeRateLimited.Errorf("method=%s", *req.Method)
+		if req.ID == nil {
+			return nil
+		}
+		return resp
+	}
+
 	if req.ID == nil && !mr.IsAllowedNotification(*req.Method) {
 		//Ignore not-allowed notification request
 		resp.Error = ErrorCodeInvalidRequest.Wrap(
@@ -124,6 +136,49 @@ func (mr *MethodRepository) handle(ctx *Context, raw json.RawMessage) *Response
 	return resp
 }
 
+// Invoke calls the handler of the method with params without JSON-RPC
+// envelope, so that other transports can share the handlers. params is
+// marshalled to JSON unless it's json.RawMessage. Returned error is *Error.
+func (mr *MethodRepository) Invoke(ctx *Context, method string, params interface{}) (res interface{}, rerr *Error) {
+	debug := ctx.IncludeDebug()
+	start := time.Now()
+	defer func() {
+		var err error
+		if rerr != nil {
+			err = rerr
+		}
+		mr.mtr.OnHandle(ctx.MetricContext(), method, start, err)
+	}()
+
+	handler := mr.GetMethod(method)
+	if handler == nil {
+		return nil, ErrMethodNotFound()
+	}
+	if rl := ctx.RateLimiter(); rl != nil && !rl.Allow(ctx, method) {
+		return nil, ErrorCodeRateLimited.Errorf("method=%s", method)
+	}
+	raw, ok := params.(json.RawMessage)
+	if !ok && params != nil {
+		bs, err := json.Marshal(params)
+		if err != nil {
+			return nil, ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		raw = bs
+	}
+	p := &Params{
+		rawMessage: raw,
+		validator:  ctx.Validator(),
+	}
+	res, err := handler(ctx, p)
+	if err != nil {
+		if je, ok := err.(*Error); ok {
+			return nil, je
+		}
+		return nil, ErrorCodeInternal.Wrap(err, debug)
+	}
+	return res, nil
+}
+
 func (mr *MethodRepository) Handle(c echo.Context) error {
 	ctx := NewContext(c)
 	raw := c.Get("raw").(json.RawMessage)
@@ -168,6 +223,9 @@ func (mr *MethodRepository) Handle(c echo.Context) error {
 		resp := mr.handle(ctx, raw)
 		if resp != nil {
 			if resp.Error != nil {
+				if resp.Error.Code == ErrorCodeRateLimited {
+					return c.JSON(http.StatusTooManyRequests, resp)
+				}
 				return c.JSON(http.StatusBadRequest, resp)
 			} else {
 				return c.JSON(http.StatusOK, resp)
@@ -195,6 +195,40 @@ func TestMethodRepository(t *testing.T) {
 	invokeTest(t, mr, exceedLimitBatch, exceedLimitBatchResp, http.StatusServiceUnavailable)
 }
 
+type denyRateLimiter map[string]bool
+
+func (d denyRateLimiter) Allow(ctx *Context, method string) bool {
+	return !d[method]
+}
+
+func TestMethodRepository_RateLimit(t *testing.T) {
+	mtr := metric.NewJsonrpcMetric(metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, true)
+	mr := NewMethodRepository(mtr)
+	mr.RegisterMethod("hello", hello)
+	mr.RegisterMethod("noArgs", noArgs)
+
+	invoke := func(req string) *httptest.ResponseRecorder {
+		c, rec, err := prepare(req)
+		assert.NoError(t, err)
+		c.Set("rateLimiter", denyRateLimiter{"noArgs": true})
+		assert.NoError(t, mr.Handle(c))
+		return rec
+	}
+
+	noArgsReq := `{"jsonrpc":"2.0","method":"noArgs","id":"1001"}`
+	rec := invoke(noArgsReq)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, `{"jsonrpc":"2.0","error":{"code":-31008,"message":"RateLimited: method=noArgs"},"id":"1001"}`+"\n",
+		rec.Body.String())
+
+	helloReq := `{"jsonrpc":"2.0","method":"hello","params":{"name":"icon"},"id":"1002"}`
+	rec = invoke("[" + helloReq + "," + noArgsReq + "]")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `[{"jsonrpc":"2.0","result":"hello, icon","id":"1002"},`+
+		`{"jsonrpc":"2.0","error":{"code":-31008,"message":"RateLimited: method=noArgs"},"id":"1001"}]`+"\n",
+		rec.Body.String())
+}
+
 type HelloParam struct {
 	Name string `json:"name" validate:"required"`
 }
@@ -0,0 +1,256 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	OpenRPCVersion = "1.2.6"
+	DiscoverMethod = "rpc.discover"
+)
+
+// Schema is JSON schema of the value.
+type Schema map[string]interface{}
+
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type ContentDescriptor struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required,omitempty"`
+	Schema   Schema `json:"schema"`
+}
+
+type OpenRPCMethod struct {
+	Name           string               `json:"name"`
+	ParamStructure string               `json:"paramStructure,omitempty"`
+	Params         []*ContentDescriptor `json:"params"`
+	Result         *ContentDescriptor   `json:"result"`
+}
+
+type OpenRPCDocument struct {
+	OpenRPC string           `json:"openrpc"`
+	Info    *OpenRPCInfo     `json:"info"`
+	Methods []*OpenRPCMethod `json:"methods"`
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+func patternOf(re *regexp.Regexp) Schema {
+	return Schema{"pattern": re.String()}
+}
+
+// SetParams sets the type of the parameters of the method, which is used
+// to describe the method in OpenRPC document.
+func (mr *MethodRepository) SetParams(method string, params interface{}) {
+	defer mr.mtx.Unlock()
+	mr.mtx.Lock()
+
+	mr.params[method] = reflect.TypeOf(params)
+}
+
+// OpenRPC returns OpenRPC document of the registered methods. Schemas of the
+// parameters are generated from the types set by SetParams with the tags
+// of the validator.
+func (mr *MethodRepository) OpenRPC(vd echo.Validator) *OpenRPCDocument {
+	defer mr.mtx.RUnlock()
+	mr.mtx.RLock()
+
+	v, _ := vd.(*Validator)
+	names := make([]string, 0, len(mr.methods))
+	for name := range mr.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	doc := &OpenRPCDocument{
+		OpenRPC: OpenRPCVersion,
+		Info:    mr.info,
+		Methods: make([]*OpenRPCMethod, 0, len(names)),
+	}
+	for _, name := range names {
+		m := &OpenRPCMethod{
+			Name:   name,
+			Params: []*ContentDescriptor{},
+			Result: &ContentDescriptor{Name: "result", Schema: Schema{}},
+		}
+		if t, ok := mr.params[name]; ok {
+			m.ParamStructure = "by-name"
+			m.Params = v.paramsOf(t, m.Params)
+		}
+		doc.Methods = append(doc.Methods, m)
+	}
+	return doc
+}
+
+// HandleOpenRPC serves OpenRPC document of the repository.
+func (mr *MethodRepository) HandleOpenRPC(c echo.Context) error {
+	return c.JSON(http.StatusOK, mr.OpenRPC(c.Echo().Validator))
+}
+
+// RegisterDiscover registers DiscoverMethod returning OpenRPC document of
+// the repository with the info.
+func (mr *MethodRepository) RegisterDiscover(info *OpenRPCInfo) {
+	mr.mtx.Lock()
+	mr.info = info
+	mr.mtx.Unlock()
+
+	mr.RegisterMethod(DiscoverMethod, func(ctx *Context, params *Params) (interface{}, error) {
+		var param struct{}
+		if err := params.Convert(&param); err != nil {
+			return nil, ErrorCodeInvalidParams.Wrap(err, ctx.IncludeDebug())
+		}
+		return mr.OpenRPC(ctx.Validator()), nil
+	})
+	mr.SetParams(DiscoverMethod, struct{}{})
+}
+
+func jsonNameOf(sf reflect.StructField) string {
+	name := strings.SplitN(sf.Tag.Get("json"), ",", 2)[0]
+	if name == "" {
+		return sf.Name
+	}
+	return name
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// paramsOf appends the descriptors of the fields of the struct type.
+// Fields of embedded structs are flattened like encoding/json.
+func (v *Validator) paramsOf(t reflect.Type, cds []*ContentDescriptor) []*ContentDescriptor {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return cds
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			cds = v.paramsOf(sf.Type, cds)
+			continue
+		}
+		name := jsonNameOf(sf)
+		if sf.PkgPath != "" || name == "-" {
+			continue
+		}
+		tag := sf.Tag.Get("validate")
+		cds = append(cds, &ContentDescriptor{
+			Name:     name,
+			Required: hasTag(strings.Split(tag, ","), "required"),
+			Schema:   v.schemaOf(sf.Type, tag),
+		})
+	}
+	return cds
+}
+
+// schemaOf returns the schema for the type with the validation tag.
+func (v *Validator) schemaOf(t reflect.Type, tag string) Schema {
+	tags := strings.Split(tag, ",")
+	var elemTag string
+	for i, tg := range tags {
+		if tg == "dive" {
+			elemTag = strings.Join(tags[i+1:], ",")
+			tags = tags[:i]
+			break
+		}
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == rawMessageType {
+		return Schema{}
+	}
+	s := Schema{}
+	switch t.Kind() {
+	case reflect.String:
+		s["type"] = "string"
+	case reflect.Bool:
+		s["type"] = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s["type"] = "integer"
+	case reflect.Float32, reflect.Float64:
+		s["type"] = "number"
+	case reflect.Slice, reflect.Array:
+		s["type"] = "array"
+		s["items"] = v.schemaOf(t.Elem(), elemTag)
+		if hasTag(tags, "gt=0") {
+			s["minItems"] = 1
+		}
+		return s
+	case reflect.Map:
+		s["type"] = "object"
+		return s
+	case reflect.Struct:
+		s["type"] = "object"
+		props := Schema{}
+		var required []string
+		for _, cd := range v.paramsOf(t, nil) {
+			props[cd.Name] = cd.Schema
+			if cd.Required {
+				required = append(required, cd.Name)
+			}
+		}
+		s["properties"] = props
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	default:
+		return s
+	}
+	for _, tg := range tags {
+		for k, value := range v.schemaOfTag(tg) {
+			s[k] = value
+		}
+	}
+	return s
+}
+
+// schemaOfTag returns the schema for the validation tag of the value.
+// Alternatives of constants (e.g. "call|deploy") are described by enum.
+func (v *Validator) schemaOfTag(tag string) Schema {
+	if strings.HasPrefix(tag, "oneof=") {
+		return Schema{"enum": strings.Fields(tag[len("oneof="):])}
+	}
+	if v == nil {
+		return nil
+	}
+	if !strings.Contains(tag, "|") {
+		return v.schemas[tag]
+	}
+	var enum []interface{}
+	var anyOf []Schema
+	for _, alt := range strings.Split(tag, "|") {
+		s, ok := v.schemas[alt]
+		if !ok {
+			return nil
+		}
+		if c, ok := s["const"]; ok && len(s) == 1 {
+			enum = append(enum, c)
+		}
+		anyOf = append(anyOf, s)
+	}
+	if len(enum) == len(anyOf) {
+		return Schema{"enum": enum}
+	}
+	return Schema{"anyOf": anyOf}
+}
@@ -0,0 +1,87 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/server/metric"
+)
+
+type openRPCTestBase struct {
+	Height HexInt `json:"height,omitempty" validate:"optional,t_int"`
+}
+
+type openRPCTestParam struct {
+	openRPCTestBase
+	Address   Address     `json:"address" validate:"required,t_addr"`
+	Mode      string      `json:"mode,omitempty" validate:"optional,oneof=a b"`
+	Addresses []Address   `json:"addresses,omitempty" validate:"optional,dive,t_addr_eoa"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+func TestMethodRepository_OpenRPC(t *testing.T) {
+	mtr := metric.NewJsonrpcMetric(metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, true)
+	mr := NewMethodRepository(mtr)
+	mr.RegisterMethod("test", hello)
+	mr.RegisterMethod("noArgs", noArgs)
+	mr.SetParams("test", openRPCTestParam{})
+	mr.RegisterDiscover(&OpenRPCInfo{Title: "test", Version: "1"})
+
+	doc := mr.OpenRPC(NewValidator())
+	bs, err := json.Marshal(doc)
+	assert.NoError(t, err)
+
+	var expected interface{}
+	err = json.Unmarshal([]byte(`{
+		"openrpc": "1.2.6",
+		"info": {"title": "test", "version": "1"},
+		"methods": [
+			{"name": "noArgs", "params": [], "result": {"name": "result", "schema": {}}},
+			{"name": "rpc.discover", "paramStructure": "by-name", "params": [], "result": {"name": "result", "schema": {}}},
+			{
+				"name": "test",
+				"paramStructure": "by-name",
+				"params": [
+					{"name": "height", "schema": {"type": "string", "pattern": "^0x(0|[1-9a-f][0-9a-f]*)$"}},
+					{"name": "address", "required": true, "schema": {"type": "string", "pattern": "^(hx|cx)[0-9a-f]{40}$"}},
+					{"name": "mode", "schema": {"type": "string", "enum": ["a", "b"]}},
+					{"name": "addresses", "schema": {"type": "array", "items": {"type": "string", "pattern": "^hx[0-9a-f]{40}$"}}},
+					{"name": "data", "schema": {}}
+				],
+				"result": {"name": "result", "schema": {}}
+			}
+		]
+	}`), &expected)
+	assert.NoError(t, err)
+	var actual interface{}
+	err = json.Unmarshal(bs, &actual)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	discoverReq := `{"jsonrpc":"2.0","method":"rpc.discover","id":"1001"}`
+	c, rec, err := prepare(discoverReq)
+	assert.NoError(t, err)
+	err = mr.Handle(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Result interface{} `json:"result"`
+	}
+	err = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp.Result)
+}
+
+func TestUnmarshalWithValidate_Path(t *testing.T) {
+	v := NewValidator()
+	var param openRPCTestParam
+	err := UnmarshalWithValidate([]byte(`{
+		"height": "0x01",
+		"address": "hx0000000000000000000000000000000000000001",
+		"addresses": ["hx0000000000000000000000000000000000000001", "cx0000000000000000000000000000000000000001"]
+	}`), &param, v)
+	assert.EqualError(t, err, ValidateFailPrefix+"t_int('height'),t_addr_eoa('addresses[1]')")
+}
@@ -13,15 +13,18 @@ var (
 	hexInt            = regexp.MustCompile("^0x(0|[1-9a-f][0-9a-f]*)$")
 	hashRegex         = regexp.MustCompile("^0x[0-9a-f]{64}$")
 	rosettaHashRegex  = regexp.MustCompile("^[0b]x[0-9a-f]{64}$")
+	binDataRegex      = regexp.MustCompile("^0x([0-9a-f]{2})+$")
 )
 
 type Validator struct {
 	validator *validator.Validate
+	schemas   map[string]Schema
 }
 
 func NewValidator() *Validator {
 	v := &Validator{
 		validator: validator.New(),
+		schemas:   make(map[string]Schema),
 	}
 
 	v.RegisterAlias("optional", "omitempty")
@@ -34,10 +37,22 @@ func NewValidator() *Validator {
 	v.RegisterValidation("t_int", isHexInt)
 	v.RegisterValidation("t_hash", isHash)
 	v.RegisterValidation("t_rhash", isRosettaHash)
+	v.RegisterValidation("t_bin_data", isBinData)
 
 	v.RegisterAlias("t_sig", "base64")
 	v.RegisterAlias("t_addr", "t_addr_eoa|t_addr_score")
 
+	v.RegisterSchema("version", Schema{"const": Version})
+	v.RegisterSchema("t_addr_eoa", patternOf(eoaAddressRegex))
+	v.RegisterSchema("t_addr_score", patternOf(scoreAddressRegex))
+	v.RegisterSchema("t_addr", Schema{"pattern": "^(hx|cx)[0-9a-f]{40}$"})
+	v.RegisterSchema("t_int", patternOf(hexInt))
+	v.RegisterSchema("t_hash", patternOf(hashRegex))
+	v.RegisterSchema("t_rhash", patternOf(rosettaHashRegex))
+	v.RegisterSchema("t_bin_data", patternOf(binDataRegex))
+	v.RegisterSchema("t_sig", Schema{"contentEncoding": "base64"})
+	v.RegisterSchema("base64", Schema{"contentEncoding": "base64"})
+
 	return v
 }
 
@@ -57,6 +72,12 @@ func (v *Validator) RegisterAlias(alias string, tags string) {
 	v.validator.RegisterAlias(alias, tags)
 }
 
+// RegisterSchema registers JSON schema for the values of the tag.
+// It's used to generate OpenRPC document of the methods.
+func (v *Validator) RegisterSchema(tag string, s Schema) {
+	v.schemas[tag] = s
+}
+
 func isJsonRpcVersion(fl validator.FieldLevel) bool {
 	return fl.Field().String() == Version
 }
@@ -85,3 +106,7 @@ func isHash(fl validator.FieldLevel) bool {
 func isRosettaHash(fl validator.FieldLevel) bool {
 	return rosettaHashRegex.MatchString(fl.Field().String())
 }
+
+func isBinData(fl validator.FieldLevel) bool {
+	return binDataRegex.MatchString(fl.Field().String())
+}
@@ -58,6 +58,7 @@ var (
 		"icx_getProofForResult":      msRetrieve,
 		"icx_getProofForEvents":      msRetrieve,
 		"icx_getScoreStatus":         msRetrieve,
+		"rpc.discover":               msRetrieve,
 		"debug_getTrace": {
 			stats.Int64("jsonrpc_get_trace", "jsonrpc debug_getTrace method", "ns"),
 			stats.Int64("jsonrpc_get_trace_avg", "moving average of jsonrpc debug_getTrace method", "ns"),
@@ -68,6 +69,16 @@ var (
 			stats.Int64("jsonrpc_estimate_step_avg", "moving average of jsonrpc debug_estimateStep method", "ns"),
 			emptyMks,
 		},
+		"debug_simulateTransaction": {
+			stats.Int64("jsonrpc_simulate_transaction", "jsonrpc debug_simulateTransaction method", "ns"),
+			stats.Int64("jsonrpc_simulate_transaction_avg", "moving average of jsonrpc debug_simulateTransaction method", "ns"),
+			emptyMks,
+		},
+		"debug_traceBlocks": {
+			stats.Int64("jsonrpc_trace_blocks", "jsonrpc debug_traceBlocks method", "ns"),
+			stats.Int64("jsonrpc_trace_blocks_avg", "moving average of jsonrpc debug_traceBlocks method", "ns"),
+			emptyMks,
+		},
 		"rosetta_getTrace": {
 			stats.Int64("jsonrpc_rosetta_trace_", "jsonrpc rosetta_getTrace method", "ns"),
 			stats.Int64("jsonrpc_rosetta_trace_avg", "moving average of jsonrpc rosetta_getTTrace method", "ns"),
@@ -78,6 +89,11 @@ var (
 	jmsMtx sync.RWMutex
 )
 
+var (
+	mkRateClass   = NewMetricKey("rate_class")
+	msRateLimited = stats.Int64("jsonrpc_rate_limited", "jsonrpc requests rejected by rate limit", "")
+)
+
 type measure struct {
 	ms    *stats.Int64Measure
 	msAvg *stats.Int64Measure
@@ -94,6 +110,7 @@ func RegisterJsonrpc() {
 	RegisterMetricView(msFailure.msAvg, view.LastValue(), emptyMks)
 	RegisterMetricView(msRetrieve.ms, view.Count(), msRetrieve.mks)
 	RegisterMetricView(msRetrieve.msAvg, view.LastValue(), emptyMks)
+	RegisterMetricView(msRateLimited, view.Count(), []tag.Key{mkRateClass, mkMethod})
 	for _, v := range msMap {
 		if v != msRetrieve {
 			RegisterMetricView(v.ms, view.Count(), v.mks)
@@ -191,6 +208,13 @@ func (m *JsonrpcMetric) OnHandle(ctx context.Context, method string, ts time.Tim
 	jm.RemoveAndRecord(ctx, ts, m.expire)
 }
 
+// OnRateLimited records the request rejected by the rate limit of the class.
+func OnRateLimited(ctx context.Context, class string, method string) {
+	ctx = GetMetricContext(ctx, &mkRateClass, class)
+	ctx = GetMetricContext(ctx, &mkMethod, method)
+	stats.Record(ctx, msRateLimited.M(1))
+}
+
 func NewJsonrpcMetric(expire time.Duration, durationsSize int, useDefault bool) *JsonrpcMetric {
 	jmsMtx.Lock()
 	defer jmsMtx.Unlock()
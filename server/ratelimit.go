@@ -0,0 +1,202 @@
+package server
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+)
+
+const (
+	HeaderAPIKey = "X-Api-Key"
+
+	rateBucketExpire = time.Minute
+)
+
+// RateClass is the class of JSON-RPC methods sharing the budget.
+type RateClass int
+
+const (
+	RateClassRead RateClass = iota
+	RateClassSend
+	RateClassDebug
+	numRateClasses
+)
+
+func (c RateClass) String() string {
+	switch c {
+	case RateClassRead:
+		return "read"
+	case RateClassSend:
+		return "send"
+	case RateClassDebug:
+		return "debug"
+	default:
+		return "unknown"
+	}
+}
+
+func RateClassOf(method string) RateClass {
+	switch {
+	case method == "icx_sendTransaction", method == "icx_sendTransactionAndWait":
+		return RateClassSend
+	case strings.HasPrefix(method, "debug_"):
+		return RateClassDebug
+	default:
+		return RateClassRead
+	}
+}
+
+type rateKey struct {
+	client string
+	class  RateClass
+}
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter limits the number of requests per second for each client and
+// class of methods with token buckets. Each bucket allows bursts up to the
+// limit. Zero limit means no limit.
+// Clients are identified by registered API keys, or by remote IPs.
+type RateLimiter struct {
+	mtx     sync.Mutex
+	limits  [numRateClasses]int
+	apiKeys map[string]bool
+	buckets map[rateKey]*rateBucket
+	lastGC  time.Time
+	now     func() time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		apiKeys: make(map[string]bool),
+		buckets: make(map[rateKey]*rateBucket),
+		now:     time.Now,
+	}
+}
+
+func (rl *RateLimiter) SetLimit(class RateClass, limit int) {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	if limit < 0 {
+		limit = 0
+	}
+	rl.limits[class] = limit
+	for k := range rl.buckets {
+		if k.class == class {
+			delete(rl.buckets, k)
+		}
+	}
+}
+
+func (rl *RateLimiter) Limit(class RateClass) int {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	return rl.limits[class]
+}
+
+func (rl *RateLimiter) SetAPIKeys(keys []string) {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	rl.apiKeys = make(map[string]bool)
+	for _, k := range keys {
+		if k != "" {
+			rl.apiKeys[k] = true
+		}
+	}
+	for k := range rl.buckets {
+		if strings.HasPrefix(k.client, "key:") {
+			delete(rl.buckets, k)
+		}
+	}
+}
+
+// ClientOf returns the client identifier of the request. Unknown API keys
+// are ignored not to let clients escape the limit with new keys.
+func (rl *RateLimiter) ClientOf(ctx echo.Context) string {
+	r := ctx.Request()
+	if key := r.Header.Get(HeaderAPIKey); key != "" {
+		rl.mtx.Lock()
+		known := rl.apiKeys[key]
+		rl.mtx.Unlock()
+		if known {
+			return "key:" + key
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// Allow consumes a token of the client for the class. It returns false if
+// the client exceeds the limit.
+func (rl *RateLimiter) Allow(client string, class RateClass) bool {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	limit := rl.limits[class]
+	if limit == 0 {
+		return true
+	}
+	now := rl.now()
+	rl._gc(now)
+
+	key := rateKey{client, class}
+	b, ok := rl.buckets[key]
+	if !ok {
+		b = &rateBucket{tokens: float64(limit), last: now}
+		rl.buckets[key] = b
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * float64(limit)
+		if b.tokens > float64(limit) {
+			b.tokens = float64(limit)
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens -= 1
+	return true
+}
+
+// _gc removes buckets not used for a while. They would be full anyway.
+func (rl *RateLimiter) _gc(now time.Time) {
+	if now.Sub(rl.lastGC) < rateBucketExpire {
+		return
+	}
+	for k, b := range rl.buckets {
+		if now.Sub(b.last) >= rateBucketExpire {
+			delete(rl.buckets, k)
+		}
+	}
+	rl.lastGC = now
+}
+
+// clientRateLimiter applies RateLimiter to the requests of a client.
+type clientRateLimiter struct {
+	rl     *RateLimiter
+	client string
+}
+
+func (c *clientRateLimiter) Allow(ctx *jsonrpc.Context, method string) bool {
+	class := RateClassOf(method)
+	if c.rl.Allow(c.client, class) {
+		return true
+	}
+	metric.OnRateLimited(ctx.MetricContext(), class.String(), method)
+	return false
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateClassOf(t *testing.T) {
+	assert.Equal(t, RateClassRead, RateClassOf("icx_call"))
+	assert.Equal(t, RateClassRead, RateClassOf("icx_getLastBlock"))
+	assert.Equal(t, RateClassSend, RateClassOf("icx_sendTransaction"))
+	assert.Equal(t, RateClassSend, RateClassOf("icx_sendTransactionAndWait"))
+	assert.Equal(t, RateClassDebug, RateClassOf("debug_estimateStep"))
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	rl := NewRateLimiter()
+	rl.now = func() time.Time { return now }
+
+	// no limit by default
+	for i := 0; i < 100; i++ {
+		assert.True(t, rl.Allow("c1", RateClassRead))
+	}
+
+	rl.SetLimit(RateClassSend, 2)
+	assert.True(t, rl.Allow("c1", RateClassSend))
+	assert.True(t, rl.Allow("c1", RateClassSend))
+	assert.False(t, rl.Allow("c1", RateClassSend))
+
+	// budgets are separated by client and class
+	assert.True(t, rl.Allow("c2", RateClassSend))
+	assert.True(t, rl.Allow("c1", RateClassRead))
+
+	// refilled with time
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, rl.Allow("c1", RateClassSend))
+	assert.False(t, rl.Allow("c1", RateClassSend))
+
+	// bursts are limited to the limit
+	now = now.Add(time.Hour)
+	assert.True(t, rl.Allow("c1", RateClassSend))
+	assert.True(t, rl.Allow("c1", RateClassSend))
+	assert.False(t, rl.Allow("c1", RateClassSend))
+	assert.Len(t, rl.buckets, 1)
+
+	rl.SetLimit(RateClassSend, 0)
+	assert.True(t, rl.Allow("c1", RateClassSend))
+	assert.Equal(t, 0, rl.Limit(RateClassSend))
+}
+
+func TestRateLimiter_ClientOf(t *testing.T) {
+	e := echo.New()
+	rl := NewRateLimiter()
+	rl.SetAPIKeys([]string{"key1"})
+
+	clientOf := func(key string) string {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set(echo.HeaderXForwardedFor, "10.0.0.2")
+		if key != "" {
+			req.Header.Set(HeaderAPIKey, key)
+		}
+		return rl.ClientOf(e.NewContext(req, httptest.NewRecorder()))
+	}
+	assert.Equal(t, "ip:10.0.0.1", clientOf(""))
+	assert.Equal(t, "key:key1", clientOf("key1"))
+	assert.Equal(t, "ip:10.0.0.1", clientOf("unknown"))
+}
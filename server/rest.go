@@ -0,0 +1,144 @@
+package server
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+const (
+	CacheControlImmutable = "public, max-age=31536000, immutable"
+	CacheControlNoCache   = "no-cache"
+	CacheControlNoStore   = "no-store"
+
+	HeaderETag        = "ETag"
+	HeaderIfNoneMatch = "If-None-Match"
+
+	restLatest = "latest"
+)
+
+// restHandler serves read only REST API with the handlers of JSON-RPC v3.
+// Responses for finalized data are cacheable with ETag, so that HTTP
+// caches in front of the node can keep them. Others should be revalidated.
+type restHandler struct {
+	mr *jsonrpc.MethodRepository
+}
+
+func (srv *Manager) registerRESTRoutes(g *echo.Group, mr *jsonrpc.MethodRepository) {
+	h := &restHandler{mr: mr}
+	g.GET("/v3/:channel/block/:id", h.getBlock, ChainInjector(srv))
+	g.GET("/v3/:channel/tx/:hash", h.getTransaction, ChainInjector(srv))
+	g.GET("/v3/:channel/tx/:hash/result", h.getTransactionResult, ChainInjector(srv))
+	g.GET("/v3/:channel/data/:hash", h.getData, ChainInjector(srv))
+}
+
+func restStatusOf(e *jsonrpc.Error) int {
+	switch code := e.Code; {
+	case code == jsonrpc.ErrorCodeJsonParse,
+		code == jsonrpc.ErrorCodeInvalidRequest,
+		code == jsonrpc.ErrorCodeInvalidParams:
+		return http.StatusBadRequest
+	case code == jsonrpc.ErrorCodeNotFound,
+		code == jsonrpc.ErrorCodePending,
+		code == jsonrpc.ErrorCodeExecuting:
+		return http.StatusNotFound
+	case code == jsonrpc.ErrorCodeStateNotAvailable:
+		return http.StatusGone
+	case code == jsonrpc.ErrorCodeRateLimited:
+		return http.StatusTooManyRequests
+	case code == jsonrpc.ErrorCodeServer,
+		code == jsonrpc.ErrorLackOfResource:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// etagMatches returns whether the value of If-None-Match header matches
+// the etag. Weak comparison is used as RFC7232 requires.
+func etagMatches(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// respond invokes the method, then it writes the result with ETag.
+// The result of the immutable one can be kept by caches without
+// revalidation.
+func (h *restHandler) respond(c echo.Context, method string, params interface{}, immutable bool) error {
+	hdr := c.Response().Header()
+	res, je := h.mr.Invoke(jsonrpc.NewContext(c), method, params)
+	if je != nil {
+		hdr.Set(echo.HeaderCacheControl, CacheControlNoStore)
+		return c.JSON(restStatusOf(je), je)
+	}
+	bs, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	etag := "\"" + hex.EncodeToString(crypto.SHA3Sum256(bs)) + "\""
+	hdr.Set(HeaderETag, etag)
+	if immutable {
+		hdr.Set(echo.HeaderCacheControl, CacheControlImmutable)
+	} else {
+		hdr.Set(echo.HeaderCacheControl, CacheControlNoCache)
+	}
+	if etagMatches(c.Request().Header.Get(HeaderIfNoneMatch), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSONBlob(http.StatusOK, bs)
+}
+
+// getBlock returns the block for the id, which is "latest", height in
+// decimal or T_INT, or hash of the block. Only the latest isn't cached.
+func (h *restHandler) getBlock(c echo.Context) error {
+	id := c.Param("id")
+	switch {
+	case id == restLatest:
+		return h.respond(c, "icx_getLastBlock", nil, false)
+	case strings.HasPrefix(id, "0x") && len(id) == 66:
+		return h.respond(c, "icx_getBlockByHash", &struct {
+			Hash string `json:"hash"`
+		}{id}, true)
+	case !strings.HasPrefix(id, "0x"):
+		height, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || height < 0 {
+			return c.JSON(http.StatusBadRequest,
+				jsonrpc.ErrorCodeInvalidParams.Errorf("invalid height=%s", id))
+		}
+		id = intconv.FormatInt(height)
+	}
+	return h.respond(c, "icx_getBlockByHeight", &struct {
+		Height string `json:"height"`
+	}{id}, true)
+}
+
+func (h *restHandler) getTransaction(c echo.Context) error {
+	return h.respond(c, "icx_getTransactionByHash", &struct {
+		Hash string `json:"txHash"`
+	}{c.Param("hash")}, true)
+}
+
+func (h *restHandler) getTransactionResult(c echo.Context) error {
+	return h.respond(c, "icx_getTransactionResult", &struct {
+		Hash string `json:"txHash"`
+	}{c.Param("hash")}, true)
+}
+
+func (h *restHandler) getData(c echo.Context) error {
+	return h.respond(c, "icx_getDataByHash", &struct {
+		Hash string `json:"hash"`
+	}{c.Param("hash")}, true)
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+)
+
+func TestEtagMatches(t *testing.T) {
+	etag := `"abcd"`
+	assert.True(t, etagMatches(`"abcd"`, etag))
+	assert.True(t, etagMatches(`W/"abcd"`, etag))
+	assert.True(t, etagMatches(`"1234", "abcd"`, etag))
+	assert.True(t, etagMatches(`*`, etag))
+	assert.False(t, etagMatches(``, etag))
+	assert.False(t, etagMatches(`"1234"`, etag))
+}
+
+func TestRestHandler_Respond(t *testing.T) {
+	mtr := metric.NewJsonrpcMetric(metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, true)
+	mr := jsonrpc.NewMethodRepository(mtr)
+	mr.RegisterMethod("test", func(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+		var param struct {
+			Hash string `json:"hash" validate:"required"`
+		}
+		if err := params.Convert(&param); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, false)
+		}
+		if param.Hash != "0x01" {
+			return nil, jsonrpc.ErrorCodeNotFound.New("not found")
+		}
+		return map[string]interface{}{"hash": param.Hash}, nil
+	})
+	h := &restHandler{mr: mr}
+
+	e := echo.New()
+	e.Validator = jsonrpc.NewValidator()
+	do := func(hash string, immutable bool, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if etag != "" {
+			req.Header.Set(HeaderIfNoneMatch, etag)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("includeDebug", false)
+		err := h.respond(c, "test", map[string]string{"hash": hash}, immutable)
+		assert.NoError(t, err)
+		return rec
+	}
+
+	rec := do("0x01", true, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"hash":"0x01"}`, rec.Body.String())
+	assert.Equal(t, CacheControlImmutable, rec.Header().Get(echo.HeaderCacheControl))
+	etag := rec.Header().Get(HeaderETag)
+	assert.NotEmpty(t, etag)
+
+	rec = do("0x01", true, etag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	rec = do("0x01", false, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, CacheControlNoCache, rec.Header().Get(echo.HeaderCacheControl))
+	assert.Equal(t, etag, rec.Header().Get(HeaderETag))
+
+	rec = do("0x02", true, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, CacheControlNoStore, rec.Header().Get(echo.HeaderCacheControl))
+	assert.Empty(t, rec.Header().Get(HeaderETag))
+}
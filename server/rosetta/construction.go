@@ -0,0 +1,379 @@
+package rosetta
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/v3"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+const (
+	OpTypeTransfer = "TRANSFER"
+
+	CurveSecp256k1        = "secp256k1"
+	SignatureECDSARecover = "ecdsa_recovery"
+)
+
+// parseTransfer returns the transfer of the operations, which are
+// the debit of the sender and the credit of the receiver.
+func parseTransfer(ops []*Operation) (*TransferOptions, *Error) {
+	if len(ops) != 2 {
+		return nil, ErrInvalidRequest.Errorf("transfer needs 2 operations")
+	}
+	var from, to string
+	sum := new(big.Int)
+	value := new(big.Int)
+	for _, op := range ops {
+		if op.Type != OpTypeTransfer {
+			return nil, ErrInvalidRequest.Errorf("unsupported operation type=%s", op.Type)
+		}
+		if op.Account == nil || op.Amount == nil || op.Amount.Currency == nil {
+			return nil, ErrInvalidRequest.Errorf("no account or amount")
+		}
+		if *op.Amount.Currency != *ICX {
+			return nil, ErrInvalidRequest.Errorf("unsupported currency=%s", op.Amount.Currency.Symbol)
+		}
+		if _, err := common.NewAddressFromString(op.Account.Address); err != nil {
+			return nil, ErrInvalidRequest.Errorf("invalid address=%s", op.Account.Address)
+		}
+		v, ok := new(big.Int).SetString(op.Amount.Value, 10)
+		if !ok {
+			return nil, ErrInvalidRequest.Errorf("invalid amount=%s", op.Amount.Value)
+		}
+		if v.Sign() < 0 {
+			from = op.Account.Address
+		} else {
+			to = op.Account.Address
+			value.Set(v)
+		}
+		sum.Add(sum, v)
+	}
+	if from == "" || to == "" || sum.Sign() != 0 {
+		return nil, ErrInvalidRequest.Errorf("operations aren't a pair of debit and credit")
+	}
+	if addr := common.MustNewAddressFromString(from); addr.IsContract() {
+		return nil, ErrInvalidRequest.Errorf("sender=%s isn't an account", from)
+	}
+	return &TransferOptions{
+		From:  from,
+		To:    to,
+		Value: intconv.FormatBigInt(value),
+	}, nil
+}
+
+// transferOperations returns the operations of the transfer, which are
+// the reverse of parseTransfer.
+func transferOperations(from, to module.Address, value *big.Int) []*Operation {
+	debit := &OperationIdentifier{Index: 0}
+	return []*Operation{
+		{
+			OperationIdentifier: debit,
+			Type:                OpTypeTransfer,
+			Account:             &AccountIdentifier{Address: from.String()},
+			Amount:              &Amount{Value: new(big.Int).Neg(value).String(), Currency: ICX},
+		},
+		{
+			OperationIdentifier: &OperationIdentifier{Index: 1},
+			RelatedOperations:   []*OperationIdentifier{debit},
+			Type:                OpTypeTransfer,
+			Account:             &AccountIdentifier{Address: to.String()},
+			Amount:              &Amount{Value: value.String(), Currency: ICX},
+		},
+	}
+}
+
+func parseTransaction(s string) (transaction.Transaction, *Error) {
+	tx, err := transaction.NewTransactionFromJSON([]byte(s))
+	if err != nil {
+		return nil, ErrInvalidTransaction.Errorf("%v", err)
+	}
+	if tx.Version() != module.TransactionVersion3 {
+		return nil, ErrInvalidTransaction.Errorf("unsupported version=%d", tx.Version())
+	}
+	return tx, nil
+}
+
+func timestampNow() string {
+	return intconv.FormatInt(time.Now().UnixNano() / int64(time.Microsecond))
+}
+
+func (h *Handler) constructionDerive(ctx echo.Context) (interface{}, *Error) {
+	var req ConstructionDeriveRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	if err := checkNetwork(req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	if req.PublicKey == nil || req.PublicKey.CurveType != CurveSecp256k1 {
+		return nil, ErrInvalidRequest.Errorf("public key of %s is required", CurveSecp256k1)
+	}
+	bs, err := bytesOf(req.PublicKey.HexBytes)
+	if err != nil {
+		return nil, ErrInvalidRequest.Errorf("invalid public key=%s", req.PublicKey.HexBytes)
+	}
+	pk, err := crypto.ParsePublicKey(bs)
+	if err != nil {
+		return nil, ErrInvalidRequest.Errorf("invalid public key=%s", req.PublicKey.HexBytes)
+	}
+	return &ConstructionDeriveResponse{
+		AccountIdentifier: &AccountIdentifier{
+			Address: common.NewAccountAddressFromPublicKey(pk).String(),
+		},
+	}, nil
+}
+
+func (h *Handler) constructionPreprocess(ctx echo.Context) (interface{}, *Error) {
+	var req ConstructionPreprocessRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	if err := checkNetwork(req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	opts, err := parseTransfer(req.Operations)
+	if err != nil {
+		return nil, err
+	}
+	return &ConstructionPreprocessResponse{
+		Options:            opts,
+		RequiredPublicKeys: []*AccountIdentifier{{Address: opts.From}},
+	}, nil
+}
+
+func (h *Handler) constructionMetadata(ctx echo.Context) (interface{}, *Error) {
+	var req ConstructionMetadataRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if req.Options == nil {
+		return nil, ErrInvalidRequest.Errorf("no options")
+	}
+	md := &TransferMetadata{
+		NID:       intconv.FormatInt(int64(c.NID())),
+		Timestamp: timestampNow(),
+	}
+	// A transfer without data costs the default step.
+	var stepCosts map[string]string
+	if err := h.invoke(ctx, h.mr, c, "icx_call", &v3.CallParam{
+		ToAddress: jsonrpc.Address(state.SystemAddress.String()),
+		DataType:  "call",
+		Data: map[string]interface{}{
+			"method": "getStepCosts",
+		},
+	}, &stepCosts); err != nil {
+		return nil, err
+	}
+	md.StepLimit = stepCosts[state.StepTypeDefault]
+	var stepPrice string
+	if err := h.invoke(ctx, h.mr, c, "icx_call", &v3.CallParam{
+		ToAddress: jsonrpc.Address(state.SystemAddress.String()),
+		DataType:  "call",
+		Data: map[string]interface{}{
+			"method": "getStepPrice",
+		},
+	}, &stepPrice); err != nil {
+		return nil, err
+	}
+	step, serr := jsonrpc.HexInt(md.StepLimit).BigInt()
+	if serr != nil {
+		return nil, ErrInternal.Errorf("invalid step=%s", md.StepLimit)
+	}
+	price, perr := jsonrpc.HexInt(stepPrice).BigInt()
+	if perr != nil {
+		return nil, ErrInternal.Errorf("invalid step price=%s", stepPrice)
+	}
+	return &ConstructionMetadataResponse{
+		Metadata: md,
+		SuggestedFee: []*Amount{
+			{Value: new(big.Int).Mul(step, price).String(), Currency: ICX},
+		},
+	}, nil
+}
+
+func (h *Handler) constructionPayloads(ctx echo.Context) (interface{}, *Error) {
+	var req ConstructionPayloadsRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	if err := checkNetwork(req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	opts, err := parseTransfer(req.Operations)
+	if err != nil {
+		return nil, err
+	}
+	md := req.Metadata
+	if md == nil || md.NID == "" || md.StepLimit == "" {
+		return nil, ErrInvalidRequest.Errorf("metadata needs nid and step_limit")
+	}
+	timestamp := md.Timestamp
+	if timestamp == "" {
+		timestamp = timestampNow()
+	}
+	js, jerr := json.Marshal(map[string]interface{}{
+		"version":   intconv.FormatInt(module.TransactionVersion3),
+		"from":      opts.From,
+		"to":        opts.To,
+		"value":     opts.Value,
+		"stepLimit": md.StepLimit,
+		"timestamp": timestamp,
+		"nid":       md.NID,
+	})
+	if jerr != nil {
+		return nil, ErrInternal.Errorf("%v", jerr)
+	}
+	tx, err := parseTransaction(string(js))
+	if err != nil {
+		return nil, err
+	}
+	return &ConstructionPayloadsResponse{
+		UnsignedTransaction: string(js),
+		Payloads: []*SigningPayload{
+			{
+				AccountIdentifier: &AccountIdentifier{Address: opts.From},
+				HexBytes:          hex.EncodeToString(tx.ID()),
+				SignatureType:     SignatureECDSARecover,
+			},
+		},
+	}, nil
+}
+
+func (h *Handler) constructionCombine(ctx echo.Context) (interface{}, *Error) {
+	var req ConstructionCombineRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	if err := checkNetwork(req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	tx, err := parseTransaction(req.UnsignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Signatures) != 1 {
+		return nil, ErrInvalidRequest.Errorf("transaction needs a signature")
+	}
+	s := req.Signatures[0]
+	if s.SignatureType != SignatureECDSARecover {
+		return nil, ErrInvalidRequest.Errorf("unsupported signature type=%s", s.SignatureType)
+	}
+	bs, berr := bytesOf(s.HexBytes)
+	if berr != nil || len(bs) != crypto.SignatureLenRawWithV {
+		return nil, ErrInvalidRequest.Errorf("invalid signature=%s", s.HexBytes)
+	}
+	sig, serr := crypto.ParseSignature(bs)
+	if serr != nil {
+		return nil, ErrInvalidRequest.Errorf("invalid signature=%s", s.HexBytes)
+	}
+	pk, perr := sig.RecoverPublicKey(tx.ID())
+	if perr != nil || !common.NewAccountAddressFromPublicKey(pk).Equal(tx.From()) {
+		return nil, ErrInvalidTransaction.Errorf("signature isn't signed by %s", tx.From())
+	}
+
+	var jso map[string]interface{}
+	if err := json.Unmarshal([]byte(req.UnsignedTransaction), &jso); err != nil {
+		return nil, ErrInvalidTransaction.Errorf("%v", err)
+	}
+	jso["signature"] = base64.StdEncoding.EncodeToString(bs)
+	js, jerr := json.Marshal(jso)
+	if jerr != nil {
+		return nil, ErrInternal.Errorf("%v", jerr)
+	}
+	return &ConstructionCombineResponse{SignedTransaction: string(js)}, nil
+}
+
+func (h *Handler) constructionParse(ctx echo.Context) (interface{}, *Error) {
+	var req ConstructionParseRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	if err := checkNetwork(req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	tx, err := parseTransaction(req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	var jso struct {
+		Value jsonrpc.HexInt `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(req.Transaction), &jso); err != nil {
+		return nil, ErrInvalidTransaction.Errorf("%v", err)
+	}
+	value := new(big.Int)
+	if jso.Value != "" {
+		v, verr := jso.Value.BigInt()
+		if verr != nil {
+			return nil, ErrInvalidTransaction.Errorf("invalid value=%s", jso.Value)
+		}
+		value = v
+	}
+	res := &ConstructionParseResponse{
+		Operations: transferOperations(tx.From(), tx.To(), value),
+	}
+	if req.Signed {
+		if verr := tx.Verify(); verr != nil {
+			return nil, ErrInvalidTransaction.Errorf("%v", verr)
+		}
+		res.AccountIdentifierSigners = []*AccountIdentifier{
+			{Address: tx.From().String()},
+		}
+	}
+	return res, nil
+}
+
+func (h *Handler) constructionHash(ctx echo.Context) (interface{}, *Error) {
+	var req ConstructionSignedRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	if err := checkNetwork(req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	tx, err := parseTransaction(req.SignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionIdentifierResponse{
+		TransactionIdentifier: &TransactionIdentifier{Hash: hexOf(tx.ID())},
+	}, nil
+}
+
+func (h *Handler) constructionSubmit(ctx echo.Context) (interface{}, *Error) {
+	var req ConstructionSignedRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid([]byte(req.SignedTransaction)) {
+		return nil, ErrInvalidTransaction.Errorf("invalid JSON")
+	}
+	var hash string
+	if err := h.invoke(ctx, h.mr, c, "icx_sendTransaction",
+		json.RawMessage(req.SignedTransaction), &hash); err != nil {
+		return nil, err
+	}
+	return &TransactionIdentifierResponse{
+		TransactionIdentifier: &TransactionIdentifier{Hash: hash},
+	}, nil
+}
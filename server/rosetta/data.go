@@ -0,0 +1,381 @@
+package rosetta
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/v3"
+	"github.com/icon-project/goloop/service/trace"
+)
+
+// traceJSON is the result of rosetta_getTrace.
+type traceJSON struct {
+	BlockHash      string `json:"blockHash"`
+	PrevBlockHash  string `json:"prevBlockHash"`
+	BlockHeight    string `json:"blockHeight"`
+	Timestamp      string `json:"timestamp"`
+	BalanceChanges []struct {
+		TxHash string `json:"txHash"`
+		Ops    []struct {
+			OpType string `json:"opType"`
+			From   string `json:"from"`
+			To     string `json:"to"`
+			Amount string `json:"amount"`
+		} `json:"ops"`
+	} `json:"balanceChanges"`
+}
+
+// transactions returns transactions with operations of balance changes.
+// An operation moving the balance from an account to another is split
+// into the debit and the credit operations related to each other.
+func (t *traceJSON) transactions() ([]*Transaction, *Error) {
+	status := StatusSuccess
+	txs := make([]*Transaction, 0, len(t.BalanceChanges))
+	for _, bc := range t.BalanceChanges {
+		tx := &Transaction{
+			TransactionIdentifier: &TransactionIdentifier{Hash: bc.TxHash},
+			Operations:            []*Operation{},
+		}
+		for _, op := range bc.Ops {
+			var debit *OperationIdentifier
+			if op.From != "" {
+				amount, err := amountOf(op.Amount, true)
+				if err != nil {
+					return nil, ErrInternal.Errorf("invalid amount=%s", op.Amount)
+				}
+				debit = &OperationIdentifier{Index: int64(len(tx.Operations))}
+				tx.Operations = append(tx.Operations, &Operation{
+					OperationIdentifier: debit,
+					Type:                op.OpType,
+					Status:              &status,
+					Account:             &AccountIdentifier{Address: op.From},
+					Amount:              amount,
+				})
+			}
+			if op.To != "" {
+				amount, err := amountOf(op.Amount, false)
+				if err != nil {
+					return nil, ErrInternal.Errorf("invalid amount=%s", op.Amount)
+				}
+				credit := &Operation{
+					OperationIdentifier: &OperationIdentifier{Index: int64(len(tx.Operations))},
+					Type:                op.OpType,
+					Status:              &status,
+					Account:             &AccountIdentifier{Address: op.To},
+					Amount:              amount,
+				}
+				if debit != nil {
+					credit.RelatedOperations = []*OperationIdentifier{debit}
+				}
+				tx.Operations = append(tx.Operations, credit)
+			}
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// finalizedBlock returns the block of the identifier, or the last
+// finalized block if it's empty. Transactions in the last block are not
+// finalized yet, so the previous one of the last block is considered
+// the last block like rosetta_getTrace.
+func finalizedBlock(bm module.BlockManager, pbi *PartialBlockIdentifier) (module.Block, *Error) {
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, ErrUnavailable.Errorf("%v", err)
+	}
+	if last.Height() < 1 {
+		return nil, ErrNotFound.Errorf("no finalized block")
+	}
+	var blk module.Block
+	switch {
+	case pbi == nil || (pbi.Index == nil && pbi.Hash == ""):
+		blk, err = bm.GetBlockByHeight(last.Height() - 1)
+	case pbi.Hash != "":
+		id, err2 := bytesOf(pbi.Hash)
+		if err2 != nil {
+			return nil, ErrInvalidRequest.Errorf("invalid hash=%s", pbi.Hash)
+		}
+		blk, err = bm.GetBlock(id)
+	default:
+		if *pbi.Index >= last.Height() {
+			return nil, ErrNotFound.Errorf("block index=%d", *pbi.Index)
+		}
+		blk, err = bm.GetBlockByHeight(*pbi.Index)
+	}
+	if err != nil {
+		return nil, ErrNotFound.Errorf("%v", err)
+	}
+	if pbi != nil && pbi.Index != nil && *pbi.Index != blk.Height() {
+		return nil, ErrInvalidRequest.Errorf("index=%d mismatches hash=%s",
+			*pbi.Index, pbi.Hash)
+	}
+	if blk.Height() >= last.Height() {
+		return nil, ErrNotFound.Errorf("block index=%d isn't finalized", blk.Height())
+	}
+	return blk, nil
+}
+
+func (h *Handler) networkList(ctx echo.Context) (interface{}, *Error) {
+	var req struct{}
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	res := &NetworkListResponse{NetworkIdentifiers: []*NetworkIdentifier{}}
+	for _, channel := range h.chains.Channels() {
+		res.NetworkIdentifiers = append(res.NetworkIdentifiers, &NetworkIdentifier{
+			Blockchain: Blockchain,
+			Network:    channel,
+		})
+	}
+	return res, nil
+}
+
+func (h *Handler) networkOptions(ctx echo.Context) (interface{}, *Error) {
+	var req NetworkRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	return &NetworkOptionsResponse{
+		Version: &Version{
+			RosettaVersion: APIVersion,
+			NodeVersion:    h.version,
+		},
+		Allow: &Allow{
+			OperationStatuses: []*OperationStatus{
+				{Status: StatusSuccess, Successful: true},
+			},
+			OperationTypes:          trace.OpTypeNames(),
+			Errors:                  Errors,
+			HistoricalBalanceLookup: c.Archive(),
+		},
+	}, nil
+}
+
+func (h *Handler) networkStatus(ctx echo.Context) (interface{}, *Error) {
+	var req NetworkRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	bm := c.BlockManager()
+	if bm == nil {
+		return nil, ErrUnavailable.Errorf("Stopped")
+	}
+	blk, err := finalizedBlock(bm, nil)
+	if err != nil {
+		return nil, err
+	}
+	genesis, gerr := bm.GetBlockByHeight(c.GenesisStorage().Height())
+	if gerr != nil {
+		return nil, ErrInternal.Errorf("%v", gerr)
+	}
+	res := &NetworkStatusResponse{
+		CurrentBlockIdentifier: identifierOf(blk),
+		CurrentBlockTimestamp:  blk.Timestamp() / 1000,
+		GenesisBlockIdentifier: identifierOf(genesis),
+		Peers:                  []*Peer{},
+	}
+	if nm := c.NetworkManager(); nm != nil {
+		for _, id := range nm.GetPeers() {
+			res.Peers = append(res.Peers, &Peer{PeerID: id.String()})
+		}
+	}
+	return res, nil
+}
+
+func (h *Handler) accountBalance(ctx echo.Context) (interface{}, *Error) {
+	var req AccountBalanceRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if req.AccountIdentifier == nil {
+		return nil, ErrInvalidRequest.Errorf("no account_identifier")
+	}
+	if _, aerr := common.NewAddressFromString(req.AccountIdentifier.Address); aerr != nil {
+		return nil, ErrInvalidRequest.Errorf("invalid address=%s", req.AccountIdentifier.Address)
+	}
+	bm := c.BlockManager()
+	if bm == nil {
+		return nil, ErrUnavailable.Errorf("Stopped")
+	}
+	blk, err := finalizedBlock(bm, req.BlockIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// the state after the transactions of the block is in the next block.
+	var balance string
+	if err := h.invoke(ctx, h.mr, c, "icx_getBalance", &v3.AddressParam{
+		Address: jsonrpc.Address(req.AccountIdentifier.Address),
+		Height:  jsonrpc.HexInt(intconv.FormatInt(blk.Height() + 1)),
+	}, &balance); err != nil {
+		return nil, err
+	}
+	amount, aerr := amountOf(balance, false)
+	if aerr != nil {
+		return nil, ErrInternal.Errorf("invalid balance=%s", balance)
+	}
+	return &AccountBalanceResponse{
+		BlockIdentifier: identifierOf(blk),
+		Balances:        []*Amount{amount},
+	}, nil
+}
+
+func (h *Handler) block(ctx echo.Context) (interface{}, *Error) {
+	var req BlockRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	bm := c.BlockManager()
+	if bm == nil {
+		return nil, ErrUnavailable.Errorf("Stopped")
+	}
+	blk, err := finalizedBlock(bm, req.BlockIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var tr traceJSON
+	if err := h.invoke(ctx, h.rmr, c, "rosetta_getTrace", &v3.RosettaTraceParam{
+		Height: jsonrpc.HexInt(intconv.FormatInt(blk.Height())),
+	}, &tr); err != nil {
+		return nil, err
+	}
+	txs, err := tr.transactions()
+	if err != nil {
+		return nil, err
+	}
+	parent := identifierOf(blk)
+	if blk.Height() > 0 {
+		parent = &BlockIdentifier{
+			Index: blk.Height() - 1,
+			Hash:  hexOf(blk.PrevID()),
+		}
+	}
+	return &BlockResponse{
+		Block: &Block{
+			BlockIdentifier:       identifierOf(blk),
+			ParentBlockIdentifier: parent,
+			Timestamp:             blk.Timestamp() / 1000,
+			Transactions:          txs,
+		},
+	}, nil
+}
+
+func (h *Handler) blockTransaction(ctx echo.Context) (interface{}, *Error) {
+	var req BlockTransactionRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if req.BlockIdentifier == nil || req.TransactionIdentifier == nil {
+		return nil, ErrInvalidRequest.Errorf("no block_identifier or transaction_identifier")
+	}
+
+	var tr traceJSON
+	if err := h.invoke(ctx, h.rmr, c, "rosetta_getTrace", &v3.RosettaTraceParam{
+		Tx: jsonrpc.HexBytes(req.TransactionIdentifier.Hash),
+	}, &tr); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(tr.BlockHash, req.BlockIdentifier.Hash) {
+		return nil, ErrNotFound.Errorf("transaction=%s isn't in block=%s",
+			req.TransactionIdentifier.Hash, req.BlockIdentifier.Hash)
+	}
+	txs, err := tr.transactions()
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		if strings.EqualFold(tx.TransactionIdentifier.Hash, req.TransactionIdentifier.Hash) {
+			return &TransactionResponse{Transaction: tx}, nil
+		}
+	}
+	// no balance changes by the transaction
+	return &TransactionResponse{
+		Transaction: &Transaction{
+			TransactionIdentifier: req.TransactionIdentifier,
+			Operations:            []*Operation{},
+		},
+	}, nil
+}
+
+func (h *Handler) mempool(ctx echo.Context) (interface{}, *Error) {
+	var req NetworkRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	sm := c.ServiceManager()
+	if sm == nil {
+		return nil, ErrUnavailable.Errorf("Stopped")
+	}
+	ptxs, _ := sm.GetPendingTransactions(module.TransactionGroupNormal, nil, 0, v3.ConfigMaxPendingTxLimit)
+	res := &MempoolResponse{
+		TransactionIdentifiers: make([]*TransactionIdentifier, len(ptxs)),
+	}
+	for i, ptx := range ptxs {
+		res.TransactionIdentifiers[i] = &TransactionIdentifier{Hash: hexOf(ptx.ID)}
+	}
+	return res, nil
+}
+
+// mempoolTransaction returns the pending transaction without operations.
+// Balance changes are known only after the transaction is executed.
+func (h *Handler) mempoolTransaction(ctx echo.Context) (interface{}, *Error) {
+	var req MempoolTransactionRequest
+	if err := bind(ctx, &req); err != nil {
+		return nil, err
+	}
+	c, err := h.chain(req.NetworkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if req.TransactionIdentifier == nil {
+		return nil, ErrInvalidRequest.Errorf("no transaction_identifier")
+	}
+	id, ierr := bytesOf(req.TransactionIdentifier.Hash)
+	if ierr != nil {
+		return nil, ErrInvalidRequest.Errorf("invalid hash=%s", req.TransactionIdentifier.Hash)
+	}
+	sm := c.ServiceManager()
+	if sm == nil {
+		return nil, ErrUnavailable.Errorf("Stopped")
+	}
+	if !sm.HasTransaction(id) {
+		return nil, ErrNotFound.Errorf("transaction=%s", req.TransactionIdentifier.Hash)
+	}
+	return &TransactionResponse{
+		Transaction: &Transaction{
+			TransactionIdentifier: req.TransactionIdentifier,
+			Operations:            []*Operation{},
+		},
+	}, nil
+}
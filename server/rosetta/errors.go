@@ -0,0 +1,86 @@
+package rosetta
+
+import (
+	"fmt"
+
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+type Error struct {
+	Code      int32                  `json:"code"`
+	Message   string                 `json:"message"`
+	Retriable bool                   `json:"retriable"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if reason, ok := e.Details["reason"]; ok {
+		return fmt.Sprintf("%s(%d): %v", e.Message, e.Code, reason)
+	}
+	return fmt.Sprintf("%s(%d)", e.Message, e.Code)
+}
+
+// Errorf returns a copy of the error with the reason in details.
+func (e *Error) Errorf(format string, args ...interface{}) *Error {
+	return &Error{
+		Code:      e.Code,
+		Message:   e.Message,
+		Retriable: e.Retriable,
+		Details: map[string]interface{}{
+			"reason": fmt.Sprintf(format, args...),
+		},
+	}
+}
+
+var (
+	ErrInvalidRequest      = &Error{Code: 1, Message: "Invalid request"}
+	ErrNetworkNotFound     = &Error{Code: 2, Message: "Network not found"}
+	ErrUnavailable         = &Error{Code: 3, Message: "Node unavailable", Retriable: true}
+	ErrNotFound            = &Error{Code: 4, Message: "Not found", Retriable: true}
+	ErrStateNotAvailable   = &Error{Code: 5, Message: "State not available"}
+	ErrInvalidTransaction  = &Error{Code: 6, Message: "Invalid transaction"}
+	ErrTransactionRejected = &Error{Code: 7, Message: "Transaction rejected"}
+	ErrInternal            = &Error{Code: 8, Message: "Internal error", Retriable: true}
+)
+
+// Errors is the list of errors for /network/options.
+var Errors = []*Error{
+	ErrInvalidRequest,
+	ErrNetworkNotFound,
+	ErrUnavailable,
+	ErrNotFound,
+	ErrStateNotAvailable,
+	ErrInvalidTransaction,
+	ErrTransactionRejected,
+	ErrInternal,
+}
+
+// errorOf returns the error for the error of JSON-RPC handler.
+func errorOf(e *jsonrpc.Error) *Error {
+	var re *Error
+	switch code := e.Code; {
+	case code == jsonrpc.ErrorCodeJsonParse,
+		code == jsonrpc.ErrorCodeInvalidRequest,
+		code == jsonrpc.ErrorCodeInvalidParams:
+		re = ErrInvalidRequest
+	case code == jsonrpc.ErrorCodeNotFound,
+		code == jsonrpc.ErrorCodePending,
+		code == jsonrpc.ErrorCodeExecuting:
+		re = ErrNotFound
+	case code == jsonrpc.ErrorCodeStateNotAvailable:
+		re = ErrStateNotAvailable
+	case code == jsonrpc.ErrorCodeServer,
+		code == jsonrpc.ErrorCodeTxPoolOverflow,
+		code == jsonrpc.ErrorLackOfResource,
+		code == jsonrpc.ErrorCodeRateLimited,
+		code == jsonrpc.ErrorCodeTimeout,
+		code == jsonrpc.ErrorCodeSystemTimeout:
+		re = ErrUnavailable
+	case code == jsonrpc.ErrorCodeUnderpriced,
+		code <= jsonrpc.ErrorCodeScore && code > jsonrpc.ErrorCodeSystem:
+		re = ErrTransactionRejected
+	default:
+		re = ErrInternal
+	}
+	return re.Errorf("%s (code=%d)", e.Message, e.Code)
+}
@@ -0,0 +1,159 @@
+package rosetta
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/server/v3"
+)
+
+const (
+	APIVersion = "1.4.10"
+	Blockchain = "ICON"
+
+	StatusSuccess = "SUCCESS"
+)
+
+var ICX = &Currency{Symbol: "ICX", Decimals: 18}
+
+// Chains gives the chains served by the handler. The network of
+// network_identifier is the channel of the chain.
+type Chains interface {
+	Chain(channel string) module.Chain
+	Channels() []string
+}
+
+// Handler serves Rosetta Data and Construction APIs with the handlers
+// of JSON-RPC v3.
+type Handler struct {
+	chains  Chains
+	version string
+	mr      *jsonrpc.MethodRepository
+	rmr     *jsonrpc.MethodRepository
+}
+
+func NewHandler(chains Chains, mtr *metric.JsonrpcMetric, version string) *Handler {
+	return &Handler{
+		chains:  chains,
+		version: version,
+		mr:      v3.MethodRepository(mtr),
+		rmr:     v3.RosettaMethodRepository(mtr),
+	}
+}
+
+// RegisterRoutes registers the endpoints to the group. The context of
+// requests should have "includeDebug" like JSON-RPC requests.
+func (h *Handler) RegisterRoutes(g *echo.Group) {
+	g.POST("/network/list", h.handle(h.networkList))
+	g.POST("/network/options", h.handle(h.networkOptions))
+	g.POST("/network/status", h.handle(h.networkStatus))
+	g.POST("/account/balance", h.handle(h.accountBalance))
+	g.POST("/block", h.handle(h.block))
+	g.POST("/block/transaction", h.handle(h.blockTransaction))
+	g.POST("/mempool", h.handle(h.mempool))
+	g.POST("/mempool/transaction", h.handle(h.mempoolTransaction))
+
+	g.POST("/construction/derive", h.handle(h.constructionDerive))
+	g.POST("/construction/preprocess", h.handle(h.constructionPreprocess))
+	g.POST("/construction/metadata", h.handle(h.constructionMetadata))
+	g.POST("/construction/payloads", h.handle(h.constructionPayloads))
+	g.POST("/construction/combine", h.handle(h.constructionCombine))
+	g.POST("/construction/parse", h.handle(h.constructionParse))
+	g.POST("/construction/hash", h.handle(h.constructionHash))
+	g.POST("/construction/submit", h.handle(h.constructionSubmit))
+}
+
+type handlerFunc func(ctx echo.Context) (interface{}, *Error)
+
+func (h *Handler) handle(f handlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		res, err := f(ctx)
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, err)
+		}
+		return ctx.JSON(http.StatusOK, res)
+	}
+}
+
+func bind(ctx echo.Context, req interface{}) *Error {
+	if err := json.NewDecoder(ctx.Request().Body).Decode(req); err != nil {
+		return ErrInvalidRequest.Errorf("fail to parse request err=%v", err)
+	}
+	return nil
+}
+
+// checkNetwork checks the identifier for the APIs not requiring the chain.
+func checkNetwork(ni *NetworkIdentifier) *Error {
+	if ni == nil || ni.Blockchain != Blockchain {
+		return ErrNetworkNotFound
+	}
+	return nil
+}
+
+func (h *Handler) chain(ni *NetworkIdentifier) (module.Chain, *Error) {
+	if err := checkNetwork(ni); err != nil {
+		return nil, err
+	}
+	c := h.chains.Chain(ni.Network)
+	if c == nil {
+		return nil, ErrNetworkNotFound.Errorf("network=%s", ni.Network)
+	}
+	return c, nil
+}
+
+// invoke calls the JSON-RPC method of the chain, then it decodes the
+// result to v.
+func (h *Handler) invoke(
+	ctx echo.Context, mr *jsonrpc.MethodRepository, c module.Chain,
+	method string, params interface{}, v interface{},
+) *Error {
+	ctx.Set("chain", c)
+	res, je := mr.Invoke(jsonrpc.NewContext(ctx), method, params)
+	if je != nil {
+		return errorOf(je)
+	}
+	bs, err := json.Marshal(res)
+	if err != nil {
+		return ErrInternal.Errorf("%v", err)
+	}
+	if err := json.Unmarshal(bs, v); err != nil {
+		return ErrInternal.Errorf("%v", err)
+	}
+	return nil
+}
+
+func hexOf(bs []byte) string {
+	return "0x" + hex.EncodeToString(bs)
+}
+
+func bytesOf(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+func identifierOf(blk module.Block) *BlockIdentifier {
+	return &BlockIdentifier{
+		Index: blk.Height(),
+		Hash:  hexOf(blk.ID()),
+	}
+}
+
+// amountOf returns the amount of ICX from the value in T_INT.
+func amountOf(value string, negative bool) (*Amount, error) {
+	v := new(big.Int)
+	if err := intconv.ParseBigInt(v, value); err != nil {
+		return nil, err
+	}
+	if negative {
+		v.Neg(v)
+	}
+	return &Amount{Value: v.String(), Currency: ICX}, nil
+}
@@ -0,0 +1,254 @@
+package rosetta
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+)
+
+type testChains struct{}
+
+func (testChains) Chain(channel string) module.Chain {
+	return nil
+}
+
+func (testChains) Channels() []string {
+	return []string{"icon"}
+}
+
+func newTestServer() *echo.Echo {
+	e := echo.New()
+	mtr := metric.NewJsonrpcMetric(metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, false)
+	NewHandler(testChains{}, mtr, "test").RegisterRoutes(e.Group(""))
+	return e
+}
+
+func post(t *testing.T, e *echo.Echo, path string, req, res interface{}) *Error {
+	bs, err := json.Marshal(req)
+	assert.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(bs))
+	r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		re := new(Error)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), re))
+		return re
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	return nil
+}
+
+var testNetwork = &NetworkIdentifier{Blockchain: Blockchain, Network: "icon"}
+
+func TestHandler_NetworkList(t *testing.T) {
+	e := newTestServer()
+
+	var res NetworkListResponse
+	assert.Nil(t, post(t, e, "/network/list", map[string]interface{}{}, &res))
+	assert.Equal(t, []*NetworkIdentifier{testNetwork}, res.NetworkIdentifiers)
+
+	rerr := post(t, e, "/network/status", &NetworkRequest{testNetwork}, nil)
+	assert.Equal(t, ErrNetworkNotFound.Code, rerr.Code)
+}
+
+func TestHandler_Construction(t *testing.T) {
+	e := newTestServer()
+	priv, pub := crypto.GenerateKeyPair()
+	to := common.MustNewAddressFromString("hx1234567890123456789012345678901234567890")
+
+	var derived ConstructionDeriveResponse
+	assert.Nil(t, post(t, e, "/construction/derive", &ConstructionDeriveRequest{
+		NetworkIdentifier: testNetwork,
+		PublicKey: &PublicKey{
+			HexBytes:  hex.EncodeToString(pub.SerializeCompressed()),
+			CurveType: CurveSecp256k1,
+		},
+	}, &derived))
+	from := derived.AccountIdentifier.Address
+	assert.Equal(t, common.NewAccountAddressFromPublicKey(pub).String(), from)
+
+	ops := []*Operation{
+		{
+			OperationIdentifier: &OperationIdentifier{Index: 0},
+			Type:                OpTypeTransfer,
+			Account:             &AccountIdentifier{Address: from},
+			Amount:              &Amount{Value: "-1000", Currency: ICX},
+		},
+		{
+			OperationIdentifier: &OperationIdentifier{Index: 1},
+			Type:                OpTypeTransfer,
+			Account:             &AccountIdentifier{Address: to.String()},
+			Amount:              &Amount{Value: "1000", Currency: ICX},
+		},
+	}
+
+	var pre ConstructionPreprocessResponse
+	assert.Nil(t, post(t, e, "/construction/preprocess", &ConstructionPreprocessRequest{
+		NetworkIdentifier: testNetwork,
+		Operations:        ops,
+	}, &pre))
+	assert.Equal(t, &TransferOptions{From: from, To: to.String(), Value: "0x3e8"}, pre.Options)
+	assert.Equal(t, from, pre.RequiredPublicKeys[0].Address)
+
+	var payloads ConstructionPayloadsResponse
+	assert.Nil(t, post(t, e, "/construction/payloads", &ConstructionPayloadsRequest{
+		NetworkIdentifier: testNetwork,
+		Operations:        ops,
+		Metadata: &TransferMetadata{
+			NID:       "0x1",
+			StepLimit: "0x186a0",
+			Timestamp: "0x5c7e1c9e0fbd0",
+		},
+	}, &payloads))
+	assert.Len(t, payloads.Payloads, 1)
+	hash, err := hex.DecodeString(payloads.Payloads[0].HexBytes)
+	assert.NoError(t, err)
+
+	var parsed ConstructionParseResponse
+	assert.Nil(t, post(t, e, "/construction/parse", &ConstructionParseRequest{
+		NetworkIdentifier: testNetwork,
+		Transaction:       payloads.UnsignedTransaction,
+	}, &parsed))
+	assert.Len(t, parsed.Operations, 2)
+	assert.Equal(t, "-1000", parsed.Operations[0].Amount.Value)
+	assert.Equal(t, to.String(), parsed.Operations[1].Account.Address)
+	assert.Empty(t, parsed.AccountIdentifierSigners)
+
+	sig, err := crypto.NewSignature(hash, priv)
+	assert.NoError(t, err)
+	sigBytes, err := sig.SerializeRSV()
+	assert.NoError(t, err)
+
+	// signature of other key is rejected
+	priv2, _ := crypto.GenerateKeyPair()
+	sig2, _ := crypto.NewSignature(hash, priv2)
+	sig2Bytes, _ := sig2.SerializeRSV()
+	rerr := post(t, e, "/construction/combine", &ConstructionCombineRequest{
+		NetworkIdentifier:   testNetwork,
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures: []*Signature{{
+			SigningPayload: payloads.Payloads[0],
+			SignatureType:  SignatureECDSARecover,
+			HexBytes:       hex.EncodeToString(sig2Bytes),
+		}},
+	}, nil)
+	assert.Equal(t, ErrInvalidTransaction.Code, rerr.Code)
+
+	var combined ConstructionCombineResponse
+	assert.Nil(t, post(t, e, "/construction/combine", &ConstructionCombineRequest{
+		NetworkIdentifier:   testNetwork,
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures: []*Signature{{
+			SigningPayload: payloads.Payloads[0],
+			PublicKey: &PublicKey{
+				HexBytes:  hex.EncodeToString(pub.SerializeCompressed()),
+				CurveType: CurveSecp256k1,
+			},
+			SignatureType: SignatureECDSARecover,
+			HexBytes:      hex.EncodeToString(sigBytes),
+		}},
+	}, &combined))
+
+	assert.Nil(t, post(t, e, "/construction/parse", &ConstructionParseRequest{
+		NetworkIdentifier: testNetwork,
+		Signed:            true,
+		Transaction:       combined.SignedTransaction,
+	}, &parsed))
+	assert.Equal(t, []*AccountIdentifier{{Address: from}}, parsed.AccountIdentifierSigners)
+
+	var hashed TransactionIdentifierResponse
+	assert.Nil(t, post(t, e, "/construction/hash", &ConstructionSignedRequest{
+		NetworkIdentifier: testNetwork,
+		SignedTransaction: combined.SignedTransaction,
+	}, &hashed))
+	assert.Equal(t, "0x"+payloads.Payloads[0].HexBytes, hashed.TransactionIdentifier.Hash)
+}
+
+func TestParseTransfer_Invalid(t *testing.T) {
+	from := "hx1111111111111111111111111111111111111111"
+	to := "hx2222222222222222222222222222222222222222"
+	op := func(addr, value string) *Operation {
+		return &Operation{
+			Type:    OpTypeTransfer,
+			Account: &AccountIdentifier{Address: addr},
+			Amount:  &Amount{Value: value, Currency: ICX},
+		}
+	}
+	cases := [][]*Operation{
+		{op(from, "-1000")},
+		{op(from, "-1000"), op(to, "999")},
+		{op(from, "1000"), op(to, "1000")},
+		{op("cx1111111111111111111111111111111111111111", "-1000"), op(to, "1000")},
+		{op(from, "-1000"), op("invalid", "1000")},
+	}
+	for i, ops := range cases {
+		_, err := parseTransfer(ops)
+		assert.NotNil(t, err, "case=%d", i)
+	}
+
+	_, err := parseTransfer([]*Operation{op(from, "-1000"), op(to, "1000")})
+	assert.Nil(t, err)
+}
+
+func TestErrorOf(t *testing.T) {
+	cases := []struct {
+		code jsonrpc.ErrorCode
+		err  *Error
+	}{
+		{jsonrpc.ErrorCodeInvalidParams, ErrInvalidRequest},
+		{jsonrpc.ErrorCodeNotFound, ErrNotFound},
+		{jsonrpc.ErrorCodeTxPoolOverflow, ErrUnavailable},
+		{jsonrpc.ErrorCodeUnderpriced, ErrTransactionRejected},
+		{jsonrpc.ErrorCodeScore, ErrTransactionRejected},
+		{jsonrpc.ErrorCodeSystem, ErrInternal},
+	}
+	for _, c := range cases {
+		re := errorOf(c.code.New("test"))
+		assert.Equal(t, c.err.Code, re.Code, "code=%d", c.code)
+	}
+}
+
+func TestTraceJSON_Transactions(t *testing.T) {
+	var tr traceJSON
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"blockHash": "0x01",
+		"balanceChanges": [{
+			"txIndex": "0x0",
+			"txHash": "0x02",
+			"ops": [
+				{"opType": "TRANSFER", "from": "hx11", "to": "hx22", "amount": "0x64"},
+				{"opType": "FEE", "from": "hx11", "amount": "0xa"},
+				{"opType": "ISSUE", "to": "hx33", "amount": "0x5"}
+			]
+		}]
+	}`), &tr))
+	txs, err := tr.transactions()
+	assert.Nil(t, err)
+	assert.Len(t, txs, 1)
+	ops := txs[0].Operations
+	assert.Len(t, ops, 4)
+	assert.Equal(t, "-100", ops[0].Amount.Value)
+	assert.Equal(t, "100", ops[1].Amount.Value)
+	assert.Equal(t, []*OperationIdentifier{{Index: 0}}, ops[1].RelatedOperations)
+	assert.Equal(t, "FEE", ops[2].Type)
+	assert.Equal(t, "-10", ops[2].Amount.Value)
+	assert.Equal(t, "hx33", ops[3].Account.Address)
+	assert.Nil(t, ops[3].RelatedOperations)
+	for _, op := range ops {
+		assert.Equal(t, StatusSuccess, *op.Status)
+	}
+}
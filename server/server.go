@@ -3,17 +3,20 @@ package server
 import (
 	"context"
 	"net/http"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"google.golang.org/grpc"
 
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/server/rosetta"
 	"github.com/icon-project/goloop/server/v3"
 )
 
@@ -35,9 +38,13 @@ type Manager struct {
 	jsonrpcRosetta        int32
 	jsonrpcIncludeDebug   int32
 	jsonrpcBatchLimit     int32
+	rateLimiter           *RateLimiter
 	logger                log.Logger
 	metricsHandler        echo.HandlerFunc
 	mtr                   *metric.JsonrpcMetric
+	grpcAddr              string
+	grpc                  *grpc.Server
+	version               string
 }
 
 func NewManager(addr string,
@@ -71,6 +78,7 @@ func NewManager(addr string,
 		mtx:                   sync.RWMutex{},
 		jsonrpcDefaultChannel: jsonrpcDefaultChannel,
 		jsonrpcBatchLimit:     int32(jsonrpcBatchLimit),
+		rateLimiter:           NewRateLimiter(),
 		logger:                logger,
 		metricsHandler:        echo.WrapHandler(metric.PrometheusExporter()),
 		mtr:                   mtr,
@@ -120,6 +128,31 @@ func (srv *Manager) Chain(channel string) module.Chain {
 	return srv.chains[channel]
 }
 
+// Channels returns the channels of the chains in order.
+func (srv *Manager) Channels() []string {
+	defer srv.mtx.RUnlock()
+	srv.mtx.RLock()
+
+	channels := make([]string, 0, len(srv.chains))
+	for channel := range srv.chains {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// SetVersion sets the version of the node for the APIs reporting it.
+// It should be set before Start.
+func (srv *Manager) SetVersion(version string) {
+	srv.version = version
+}
+
+// SetGRPCAddr sets the address for gRPC API. Empty address disables it.
+// It should be set before Start.
+func (srv *Manager) SetGRPCAddr(addr string) {
+	srv.grpcAddr = addr
+}
+
 func (srv *Manager) SetDefaultChannel(jsonrpcDefaultChannel string) {
 	defer srv.mtx.Unlock()
 	srv.mtx.Lock()
@@ -174,6 +207,22 @@ func (srv *Manager) BatchLimit() int {
 	return int(atomic.LoadInt32(&srv.jsonrpcBatchLimit))
 }
 
+// SetRateLimit sets the number of requests per second allowed for each
+// client in the class. Zero means no limit.
+func (srv *Manager) SetRateLimit(class RateClass, limit int) {
+	srv.rateLimiter.SetLimit(class, limit)
+}
+
+func (srv *Manager) RateLimit(class RateClass) int {
+	return srv.rateLimiter.Limit(class)
+}
+
+// SetRateLimitAPIKeys sets API keys identifying clients for rate limiting
+// instead of their IPs.
+func (srv *Manager) SetRateLimitAPIKeys(keys []string) {
+	srv.rateLimiter.SetAPIKeys(keys)
+}
+
 func (srv *Manager) Start() error {
 	srv.logger.Infoln("starting the server")
 	// CORS middleware
@@ -187,6 +236,13 @@ func (srv *Manager) Start() error {
 	// metric
 	srv.RegisterMetricsHandler(srv.e.Group("/metrics"))
 
+	// gRPC
+	if srv.grpcAddr != "" {
+		if err := srv.startGRPC(); err != nil {
+			return err
+		}
+	}
+
 	return srv.e.Start(srv.addr)
 }
 
@@ -206,6 +262,10 @@ func (srv *Manager) RegisterAPIHandler(g *echo.Group) {
 			ctx.Set("includeDebug", srv.IncludeDebug())
 			ctx.Set("batchLimit", srv.BatchLimit())
 			ctx.Set("rosetta", srv.Rosetta())
+			ctx.Set("rateLimiter", &clientRateLimiter{
+				rl:     srv.rateLimiter,
+				client: srv.rateLimiter.ClientOf(ctx),
+			})
 			return next(ctx)
 		}
 	})
@@ -217,6 +277,8 @@ func (srv *Manager) RegisterAPIHandler(g *echo.Group) {
 	v3api.POST("", mr.Handle, ChainInjector(srv))
 	v3api.POST("/", mr.Handle, ChainInjector(srv))
 	v3api.POST("/:channel", mr.Handle, ChainInjector(srv))
+	rpc.GET("/v3/openrpc.json", mr.HandleOpenRPC)
+	srv.registerRESTRoutes(rpc, mr)
 
 	dmr := v3.DebugMethodRepository(srv.mtr)
 	v3dbg := rpc.Group("/v3d")
@@ -225,18 +287,23 @@ func (srv *Manager) RegisterAPIHandler(g *echo.Group) {
 	v3dbg.POST("/", dmr.Handle, ChainInjector(srv))
 	v3dbg.POST("/:channel", dmr.Handle, ChainInjector(srv))
 
+	// Rosetta Data and Construction APIs
+	rapi := rpc.Group("/rosetta-api", srv.CheckRosetta())
+	rosetta.NewHandler(srv, srv.mtr, srv.version).RegisterRoutes(rapi)
+
 	// Rosetta APIs
 	rmr := v3.RosettaMethodRepository(srv.mtr)
-	rosetta := rpc.Group("/rosetta")
-	rosetta.Use(srv.CheckRosetta(), JsonRpc(), Chunk())
-	rosetta.POST("", rmr.Handle, ChainInjector(srv))
-	rosetta.POST("/", rmr.Handle, ChainInjector(srv))
-	rosetta.POST("/:channel", rmr.Handle, ChainInjector(srv))
+	rrpc := rpc.Group("/rosetta")
+	rrpc.Use(srv.CheckRosetta(), JsonRpc(), Chunk())
+	rrpc.POST("", rmr.Handle, ChainInjector(srv))
+	rrpc.POST("/", rmr.Handle, ChainInjector(srv))
+	rrpc.POST("/:channel", rmr.Handle, ChainInjector(srv))
 
 	// group for websocket
 	ws := g.Group("")
 	ws.GET("/v3/:channel/block", srv.wssm.RunBlockSession, ChainInjector(srv))
 	ws.GET("/v3/:channel/event", srv.wssm.RunEventSession, ChainInjector(srv))
+	ws.GET("/v3/:channel/transaction", srv.wssm.RunTransactionSession, ChainInjector(srv))
 }
 
 func (srv *Manager) RegisterMetricsHandler(g *echo.Group) {
@@ -276,6 +343,9 @@ func (srv *Manager) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 	srv.wssm.StopAllSessions()
+	if srv.grpc != nil {
+		srv.grpc.Stop()
+	}
 	return srv.e.Shutdown(ctx)
 }
 
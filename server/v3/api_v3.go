@@ -3,8 +3,10 @@ package v3
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	"github.com/icon-project/goloop/server/metric"
@@ -47,9 +50,35 @@ func MethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository {
 	mr.RegisterMethod("icx_getProofForResult", getProofForResult)
 	mr.RegisterMethod("icx_getProofForEvents", getProofForEvents)
 	mr.RegisterMethod("icx_getScoreStatus", getScoreStatus)
+	mr.RegisterMethod("icx_getLogs", getLogs)
+	mr.RegisterMethod("icx_getStorageAt", getStorageAt)
 
 	mr.SetAllowedNotification("icx_sendTransaction")
 	mr.SetAllowedNotification("icx_sendTransactionAndWait")
+
+	mr.SetParams("icx_getLastBlock", struct{}{})
+	mr.SetParams("icx_getBlockByHeight", BlockHeightParam{})
+	mr.SetParams("icx_getBlockByHash", BlockHashParam{})
+	mr.SetParams("icx_call", CallParam{})
+	mr.SetParams("icx_getBalance", AddressParam{})
+	mr.SetParams("icx_getScoreApi", ScoreAddressParam{})
+	mr.SetParams("icx_getTotalSupply", HeightParam{})
+	mr.SetParams("icx_getTransactionResult", TransactionHashParam{})
+	mr.SetParams("icx_getTransactionByHash", TransactionHashParam{})
+	mr.SetParams("icx_sendTransaction", TransactionParam{})
+	mr.SetParams("icx_sendTransactionAndWait", TransactionParam{})
+	mr.SetParams("icx_waitTransactionResult", TransactionHashParam{})
+
+	mr.SetParams("icx_getDataByHash", DataHashParam{})
+	mr.SetParams("icx_getBlockHeaderByHeight", BlockHeightParam{})
+	mr.SetParams("icx_getVotesByHeight", BlockHeightParam{})
+	mr.SetParams("icx_getProofForResult", ProofResultParam{})
+	mr.SetParams("icx_getProofForEvents", ProofEventsParam{})
+	mr.SetParams("icx_getScoreStatus", ScoreAddressParam{})
+	mr.SetParams("icx_getLogs", LogsParam{})
+	mr.SetParams("icx_getStorageAt", StorageParam{})
+
+	mr.RegisterDiscover(&jsonrpc.OpenRPCInfo{Title: "ICON JSON-RPC v3", Version: "3"})
 	return mr
 }
 
@@ -221,12 +250,9 @@ func call(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
 
-	block, err := getBlock(chain, bm, param.Height)
+	block, err := getStateBlock(chain, bm, param.Height, debug)
 	if err != nil {
-		if errors.NotFoundError.Equals(err) {
-			return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
-		}
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 	bi := common.NewBlockInfo(block.Height(), block.Timestamp())
 	result, err := sm.Call(block.Result(), block.NextValidators(), params.RawMessage(), bi)
@@ -256,6 +282,37 @@ func getBlock(chain module.Chain, bm module.BlockManager, height jsonrpc.HexInt)
 	return
 }
 
+// getStateBlock returns the block for reading the world state at the height.
+// It fails with ErrorCodeStateNotAvailable if the state is pruned or not kept
+// in the database.
+func getStateBlock(chain module.Chain, bm module.BlockManager, height jsonrpc.HexInt, debug bool) (module.Block, error) {
+	if height != "" {
+		h, err := height.Int64()
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		if base := chain.GenesisStorage().Height(); h >= 0 && h < base {
+			return nil, jsonrpc.ErrorCodeStateNotAvailable.Errorf(
+				"PrunedState(height=%d,base=%d)", h, base)
+		}
+	}
+	block, err := getBlock(chain, bm, height)
+	if err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	if err := service.CheckStateAvailable(chain.Database(), block.Result()); err != nil {
+		if service.StateNotAvailableError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeStateNotAvailable.Errorf(
+				"MissingState(height=%d,archive=%t)", block.Height(), chain.Archive())
+		}
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return block, nil
+}
+
 func getBalance(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	var param AddressParam
 	debug := ctx.IncludeDebug()
@@ -275,9 +332,9 @@ func getBalance(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, erro
 	}
 
 	var balance common.HexInt
-	block, err := getBlock(chain, bm, param.Height)
+	block, err := getStateBlock(chain, bm, param.Height, debug)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 	b, err := sm.GetBalance(block.Result(), param.Address.Address())
 	if err != nil {
@@ -302,9 +359,9 @@ func getScoreApi(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, err
 	if bm == nil || sm == nil {
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
-	b, err := getBlock(chain, bm, param.Height)
+	b, err := getStateBlock(chain, bm, param.Height, debug)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 	info, err := sm.GetAPIInfo(b.Result(), param.Address.Address())
 	if service.NoActiveContractError.Equals(err) {
@@ -342,9 +399,9 @@ func getTotalSupply(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
 
-	b, err := getBlock(chain, bm, height)
+	b, err := getStateBlock(chain, bm, height, debug)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 
 	var tsValue common.HexInt
@@ -485,10 +542,7 @@ func sendTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 
 	hash, err := sm.SendTransaction(state, height, params.RawMessage())
 	if err != nil {
-		if service.TransactionPoolOverflowError.Equals(err) {
-			return nil, jsonrpc.ErrorCodeTxPoolOverflow.Wrap(err, debug)
-		}
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, sendTransactionError(err, debug)
 	}
 
 	result := "0x" + hex.EncodeToString(hash)
@@ -496,6 +550,20 @@ func sendTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 	return result, nil
 }
 
+// sendTransactionError returns the error for the transaction rejected by
+// the transaction pool.
+func sendTransactionError(err error, debug bool) *jsonrpc.Error {
+	switch {
+	case service.TransactionPoolOverflowError.Equals(err),
+		service.SenderQuotaExceededError.Equals(err):
+		return jsonrpc.ErrorCodeTxPoolOverflow.Wrap(err, debug)
+	case service.UnderpricedTransactionError.Equals(err):
+		return jsonrpc.ErrorCodeUnderpriced.Wrap(err, debug)
+	default:
+		return jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+}
+
 func getDataByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	debug := ctx.IncludeDebug()
 
@@ -740,12 +808,9 @@ func getScoreStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 	if bm == nil || sm == nil {
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
-	b, err := getBlock(chain, bm, param.Height)
+	b, err := getStateBlock(chain, bm, param.Height, debug)
 	if err != nil {
-		if errors.NotFoundError.Equals(err) {
-			return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
-		}
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 	s, err := sm.GetSCOREStatus(b.Result(), param.Address.Address())
 	if err != nil {
@@ -761,6 +826,315 @@ func getScoreStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 	return jso, nil
 }
 
+func getStorageAt(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param StorageParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	bm := chain.BlockManager()
+	if bm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+	b, err := getStateBlock(chain, bm, param.Height, debug)
+	if err != nil {
+		return nil, err
+	}
+	wss, err := service.NewWorldSnapshot(chain.Database(), nil, b.Result(), nil)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	ass := wss.GetAccountSnapshot(param.Address.Address().ID())
+	if ass == nil || !ass.IsContract() {
+		return nil, jsonrpc.ErrorCodeNotFound.Errorf("NoContract(address=%s)", param.Address)
+	}
+	value, err := ass.GetValue(param.Key.Bytes())
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	return jsonrpc.HexBytes("0x" + hex.EncodeToString(value)), nil
+}
+
+const (
+	ConfigMaxLogsRange     = 5000
+	ConfigDefaultLogsLimit = 100
+	ConfigMaxLogsLimit     = 1000
+)
+
+// logPosition is the position of an event log in the chain.
+// It's used as a cursor of icx_getLogs in "<height>:<txIndex>:<logIndex>"
+// format where each of them is T_INT.
+type logPosition struct {
+	height   int64
+	txIndex  int
+	logIndex int
+}
+
+func (p logPosition) String() string {
+	return intconv.FormatInt(p.height) + ":" +
+		intconv.FormatInt(int64(p.txIndex)) + ":" +
+		intconv.FormatInt(int64(p.logIndex))
+}
+
+func (p *logPosition) SetString(s string) error {
+	values := strings.Split(s, ":")
+	if len(values) != 3 {
+		return errors.IllegalArgumentError.Errorf("InvalidCursor(%s)", s)
+	}
+	var v [3]int64
+	for i, bits := range []int{64, 32, 32} {
+		v64, err := intconv.ParseInt(values[i], bits)
+		if err != nil || v64 < 0 {
+			return errors.IllegalArgumentError.Errorf("InvalidCursor(%s)", s)
+		}
+		v[i] = v64
+	}
+	p.height, p.txIndex, p.logIndex = v[0], int(v[1]), int(v[2])
+	return nil
+}
+
+func (p logPosition) Less(p2 logPosition) bool {
+	if p.height != p2.height {
+		return p.height < p2.height
+	}
+	if p.txIndex != p2.txIndex {
+		return p.txIndex < p2.txIndex
+	}
+	return p.logIndex < p2.logIndex
+}
+
+func getLogs(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param LogsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	var addrs []module.Address
+	if param.Addr != "" {
+		addrs = append(addrs, param.Addr.Address())
+	}
+	for _, addr := range param.Addrs {
+		addrs = append(addrs, addr.Address())
+	}
+	filter, err := txresult.NewEventFilter(addrs, param.Event, param.Indexed, param.Data)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	from, err := param.FromHeight.Int64()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	if err := checkBaseHeight(chain, from); err != nil {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	}
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	// results of transactions in a block are in the next block.
+	to := last.Height() - 1
+	if param.ToHeight != "" {
+		if to, err = param.ToHeight.Int64(); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		if to >= last.Height() {
+			return nil, jsonrpc.ErrorCodeNotFound.Errorf(
+				"NoResult(toHeight=%d,last=%d)", to, last.Height())
+		}
+	}
+	if from > to {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"InvalidRange(from=%d,to=%d)", from, to)
+	}
+	if to-from >= ConfigMaxLogsRange {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"TooLargeRange(from=%d,to=%d,max=%d)", from, to, ConfigMaxLogsRange)
+	}
+	limit := int64(ConfigDefaultLogsLimit)
+	if param.Limit != "" {
+		limit, err = param.Limit.Int64()
+		if err != nil || limit <= 0 || limit > ConfigMaxLogsLimit {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidLimit(%s)", param.Limit)
+		}
+	}
+	start := logPosition{height: from}
+	if param.Cursor != "" {
+		if err := start.SetString(param.Cursor); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		if start.height < from || start.height > to {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+				"CursorOutOfRange(cursor=%s)", param.Cursor)
+		}
+	}
+
+	ir, err := findIndexedTransactions(chain, addrs, param.Event, start.height, to)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+
+	logs := make([]interface{}, 0)
+	var next *logPosition
+	for h := start.height; h <= to && next == nil; h++ {
+		if ir.covers(h) && len(ir.txs[h]) == 0 {
+			continue
+		}
+		rblk, err := bm.GetBlockByHeight(h + 1)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		if !rblk.LogsBloom().Contain(filter.LogsBloom()) {
+			continue
+		}
+		blk, err := bm.GetBlockByHeight(h)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		rl, err := sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		txIndex := 0
+		for rit := rl.Iterator(); rit.Has() && next == nil; rit.Next() {
+			if ir.covers(h) && !ir.txs[h][txIndex] {
+				txIndex++
+				continue
+			}
+			r, err := rit.Get()
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+			}
+			var txHash []byte
+			err = filter.ForEachMatch(r, func(idx int, el module.EventLog) {
+				pos := logPosition{h, txIndex, idx}
+				if pos.Less(start) || next != nil {
+					return
+				}
+				if int64(len(logs)) >= limit {
+					next = &pos
+					return
+				}
+				if txHash == nil {
+					if tx, err := blk.NormalTransactions().Get(txIndex); err == nil {
+						txHash = tx.ID()
+					}
+				}
+				jso, err := eventLogToJSON(el)
+				if err != nil {
+					return
+				}
+				jso["blockHeight"] = intconv.FormatInt(h)
+				jso["blockHash"] = common.HexBytes(blk.ID())
+				jso["txIndex"] = intconv.FormatInt(int64(txIndex))
+				jso["txHash"] = common.HexBytes(txHash)
+				jso["logIndex"] = intconv.FormatInt(int64(idx))
+				logs = append(logs, jso)
+			})
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+			}
+			txIndex++
+		}
+	}
+	result := map[string]interface{}{
+		"logs": logs,
+	}
+	if next != nil {
+		result["cursor"] = next.String()
+	}
+	return result, nil
+}
+
+// indexedRange is the transactions emitting the events in the range
+// covered by the event index.
+type indexedRange struct {
+	from, to int64
+	txs      map[int64]map[int]bool
+}
+
+func (r *indexedRange) covers(height int64) bool {
+	return height >= r.from && height <= r.to
+}
+
+// findIndexedTransactions returns the transactions in the blocks from the
+// height from to the height to emitting the event of the scores. It covers
+// nothing if the event index is not enabled or the scores are not specified.
+func findIndexedTransactions(chain module.Chain, addrs []module.Address, sig string, from, to int64) (*indexedRange, error) {
+	ir := &indexedRange{from: 0, to: -1}
+	if !chain.EventIndex() || len(addrs) == 0 {
+		return ir, nil
+	}
+	idx, err := block.NewEventIndex(chain.Database())
+	if err != nil {
+		return nil, err
+	}
+	ifrom, ito, err := idx.Range()
+	if err != nil {
+		return nil, err
+	}
+	if from < ifrom {
+		from = ifrom
+	}
+	if to > ito {
+		to = ito
+	}
+	if from > to {
+		return ir, nil
+	}
+	ir.from, ir.to = from, to
+	ir.txs = make(map[int64]map[int]bool)
+	for _, addr := range addrs {
+		err := idx.Find(addr, sig, from, to, func(e *block.EventIndexEntry) bool {
+			txs, ok := ir.txs[e.Height]
+			if !ok {
+				txs = make(map[int]bool)
+				ir.txs[e.Height] = txs
+			}
+			txs[e.TxIndex] = true
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ir, nil
+}
+
+func eventLogToJSON(el module.EventLog) (map[string]interface{}, error) {
+	bs, err := json.Marshal(el)
+	if err != nil {
+		return nil, err
+	}
+	var jso map[string]interface{}
+	if err := json.Unmarshal(bs, &jso); err != nil {
+		return nil, err
+	}
+	return jso, nil
+}
+
 // convert TransactionList to []Transaction
 func convertTransactionList(txs module.TransactionList, version module.JSONVersion) ([]interface{}, error) {
 	list := []interface{}{}
@@ -828,10 +1202,7 @@ func sendTransactionAndWait(ctx *jsonrpc.Context, params *jsonrpc.Params) (inter
 
 	hash, fc, err := bm.SendTransactionAndWait(state, height, params.RawMessage())
 	if err != nil {
-		if service.TransactionPoolOverflowError.Equals(err) {
-			return nil, jsonrpc.ErrorCodeTxPoolOverflow.Wrap(err, debug)
-		}
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, sendTransactionError(err, debug)
 	}
 
 	return waitTransactionResultOnChannel(ctx, chain, bm, hash, debug, timeout, maxLimit, fc)
@@ -944,14 +1315,119 @@ func DebugMethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository
 
 	mr.RegisterMethod("debug_getTrace", getTrace)
 	mr.RegisterMethod("debug_estimateStep", estimateStep)
-
+	mr.RegisterMethod("debug_simulateTransaction", simulateTransaction)
+	mr.RegisterMethod("debug_traceBlocks", traceBlocks)
+	mr.RegisterMethod("debug_getPendingTransactions", getPendingTransactions)
+	mr.RegisterMethod("debug_getPoolStatus", getPoolStatus)
+
+	mr.SetParams("debug_getTrace", TraceParam{})
+	mr.SetParams("debug_estimateStep", TransactionParamForEstimate{})
+	mr.SetParams("debug_simulateTransaction", TransactionParamForSimulate{})
+	mr.SetParams("debug_traceBlocks", TraceBlocksParam{})
+	mr.SetParams("debug_getPendingTransactions", PendingTransactionsParam{})
+
+	mr.RegisterDiscover(&jsonrpc.OpenRPCInfo{Title: "ICON JSON-RPC v3 Debug", Version: "3"})
 	return mr
 }
 
+const (
+	ConfigDefaultPendingTxLimit = 100
+	ConfigMaxPendingTxLimit     = 1000
+)
+
+var transactionGroups = map[string]module.TransactionGroup{
+	"normal": module.TransactionGroupNormal,
+	"patch":  module.TransactionGroupPatch,
+}
+
+func getPendingTransactions(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param PendingTransactionsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	group := module.TransactionGroupNormal
+	if param.Group != "" {
+		group = transactionGroups[param.Group]
+	}
+	var from module.Address
+	if param.From != "" {
+		from = param.From.Address()
+	}
+	var skip, limit int64 = 0, ConfigDefaultPendingTxLimit
+	if param.Skip != "" {
+		if skip, err = param.Skip.Int64(); err != nil || skip < 0 {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidSkip(%s)", param.Skip)
+		}
+	}
+	if param.Limit != "" {
+		limit, err = param.Limit.Int64()
+		if err != nil || limit <= 0 || limit > ConfigMaxPendingTxLimit {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidLimit(%s)", param.Limit)
+		}
+	}
+
+	ptxs, total := sm.GetPendingTransactions(group, from, int(skip), int(limit))
+	now := time.Now()
+	txs := make([]interface{}, len(ptxs))
+	for i, ptx := range ptxs {
+		tx := map[string]interface{}{
+			"txHash": common.HexBytes(ptx.ID),
+			"from":   ptx.From,
+			"size":   intconv.FormatInt(int64(ptx.Size)),
+			"age":    intconv.FormatInt(now.Sub(ptx.Received).Microseconds()),
+		}
+		if ptx.Nonce != nil {
+			tx["nonce"] = intconv.FormatBigInt(ptx.Nonce)
+		}
+		txs[i] = tx
+	}
+	return map[string]interface{}{
+		"total":        intconv.FormatInt(int64(total)),
+		"transactions": txs,
+	}, nil
+}
+
+func getPoolStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	result := make(map[string]interface{})
+	for name, group := range transactionGroups {
+		size, used := sm.GetPoolStatus(group)
+		result[name] = map[string]interface{}{
+			"size": intconv.FormatInt(int64(size)),
+			"used": intconv.FormatInt(int64(used)),
+		}
+	}
+	return result, nil
+}
+
 func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	debug := ctx.IncludeDebug()
 
-	var param TransactionHashParam
+	var param TraceParam
 	if err := params.Convert(&param); err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
 	}
@@ -1013,8 +1489,13 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 		logs:    make([]interface{}, 0, 100),
 		channel: make(chan interface{}, 10),
 	}
+	mode := module.TraceModeInvoke
+	if param.Mode == "callTree" {
+		mode = module.TraceModeCallTree
+		cb.ct = trace.NewCallTracer()
+	}
 	ti := module.TraceInfo{
-		TraceMode: module.TraceModeInvoke,
+		TraceMode: mode,
 		Range:     module.TraceRangeTransaction,
 		Group:     txInfo.Group(),
 		Index:     txInfo.Index(),
@@ -1033,6 +1514,9 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 			return nil, jsonrpc.ErrorCodeSystemTimeout.Errorf(
 				"Not enough time to get result of %x", param.Hash.Bytes())
 		case <-cb.channel:
+			if mode == module.TraceModeCallTree {
+				return cb.callTreeToJSON(), nil
+			}
 			return cb.invokeTraceToJSON(), nil
 		}
 	}
@@ -1095,6 +1579,70 @@ func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, er
 	return steps, nil
 }
 
+func simulateTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	var param TransactionParamForSimulate
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("ChannelStopped")
+	}
+
+	blk, err := getStateBlock(chain, bm, param.Height, debug)
+	if err != nil {
+		return nil, err
+	}
+
+	// new block information based on the block. use current time for
+	// the last block as estimateStep does.
+	oldTS := blk.Timestamp()
+	newTS := oldTS + 1
+	if param.Height == "" {
+		if ts := common.UnixMicroFromTime(time.Now()); ts > oldTS {
+			newTS = ts
+		}
+	}
+	bi := common.NewBlockInfo(blk.Height()+1, newTS)
+
+	js, err := json.Marshal(&param.TransactionParamForEstimate)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	rct, diff, err := sm.SimulateTransaction(
+		blk.Result(),
+		blk.NextValidators().Hash(),
+		js,
+		bi,
+	)
+	if err != nil {
+		if scoreresult.InvalidParameterError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	res, err := rct.ToJSON(module.JSONVersion3)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	result := res.(map[string]interface{})
+	result["stateHeight"] = intconv.FormatInt(blk.Height())
+	if result["stateDiff"], err = diff.ToJSON(module.JSONVersion3); err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return result, nil
+}
+
 const CIDForMainNet = 0x1
 
 func getTraceForRosetta(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
@@ -1121,27 +1669,72 @@ func getTraceForRosetta(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 
-	csi, err := bm.NewConsensusInfo(blk)
+	tr2, nblk, err := newTransitionForTrace(bm, sm, blk)
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
-	nblk, err := bm.GetBlockByHeight(blk.Height() + 1)
+	cb, ti, err := newBalanceTraceInfo(chain, sm, blk, nblk)
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
-	tr1, err := sm.CreateInitialTransition(blk.Result(), blk.NextValidators())
+	if txInfo != nil {
+		ti.Range = module.TraceRangeTransaction
+		ti.Group = module.TransactionGroupNormal
+		ti.Index = txInfo.Index()
+	} else {
+		if len(param.Tx) > 0 {
+			ti.Range = module.TraceRangeBlockTransaction
+		} else {
+			ti.Range = module.TraceRangeBlock
+		}
+	}
+	canceller, err := tr2.ExecuteForTrace(ti)
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
+
+	timer := time.After(time.Second * 60)
+	for {
+		select {
+		case <-timer:
+			canceller()
+			return nil, jsonrpc.ErrorCodeSystemTimeout.Errorf(
+				"Not enough time to get result of %+v", param)
+		case <-cb.channel:
+			return cb.balanceChangeToJSON(blk, nil), nil
+		}
+	}
+	return nil, jsonrpc.ErrorCodeSystem.New("Unknown error on channel")
+}
+
+// newTransitionForTrace returns the transition replaying transactions of
+// the block, and the next block having the result of them.
+func newTransitionForTrace(bm module.BlockManager, sm module.ServiceManager, blk module.Block) (module.Transition, module.Block, error) {
+	csi, err := bm.NewConsensusInfo(blk)
+	if err != nil {
+		return nil, nil, err
+	}
+	nblk, err := bm.GetBlockByHeight(blk.Height() + 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	tr1, err := sm.CreateInitialTransition(blk.Result(), blk.NextValidators())
+	if err != nil {
+		return nil, nil, err
+	}
 	tr2, err := sm.CreateTransition(tr1, blk.NormalTransactions(), blk, csi, true)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, nil, err
 	}
-	tr2 = sm.PatchTransition(tr2, nblk.PatchTransactions(), nblk)
+	return sm.PatchTransition(tr2, nblk.PatchTransactions(), nblk), nblk, nil
+}
 
+// newBalanceTraceInfo returns the trace information for balance changes
+// of the whole block.
+func newBalanceTraceInfo(chain module.Chain, sm module.ServiceManager, blk, nblk module.Block) (*traceCallback, module.TraceInfo, error) {
 	rl, err := sm.ReceiptListFromResult(nblk.Result(), module.TransactionGroupNormal)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, module.TraceInfo{}, err
 	}
 
 	var replacer trace.TxHashReplacer
@@ -1155,36 +1748,113 @@ func getTraceForRosetta(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface
 	ti := module.TraceInfo{
 		TraceMode:  module.TraceModeBalanceChange,
 		TraceBlock: trace.NewTraceBlock(blk.ID(), rl),
+		Range:      module.TraceRangeBlock,
 		Callback:   cb,
 	}
-	if txInfo != nil {
-		ti.Range = module.TraceRangeTransaction
-		ti.Group = module.TransactionGroupNormal
-		ti.Index = txInfo.Index()
-	} else {
-		if len(param.Tx) > 0 {
-			ti.Range = module.TraceRangeBlockTransaction
-		} else {
-			ti.Range = module.TraceRangeBlock
-		}
+	return cb, ti, nil
+}
+
+const (
+	ConfigMaxTraceBlocks     = 100
+	ConfigTraceBlocksTimeout = 10 * time.Second
+	ConfigTraceBlockTimeout  = 60 * time.Second
+)
+
+// traceBlocks returns balance changes of blocks in the range touching the
+// addresses. It returns a chunk of the range not to hold the request too
+// long, then "next" in the result is the height to continue with. A block
+// taking too long ends the chunk unless it's the first one.
+func traceBlocks(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param TraceBlocksParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
 	}
-	canceller, err := tr2.ExecuteForTrace(ti)
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	last, err := bm.GetLastBlock()
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
+	// transactions in the last block are not finalized yet.
+	from, _ := param.From.Int64()
+	to := last.Height() - 1
+	if param.To != "" {
+		if h, _ := param.To.Int64(); h < to {
+			to = h
+		}
+	}
+	if from < 0 || from > to {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"InvalidRange(from=%d,to=%d,last=%d)", from, to, last.Height()-1)
+	}
+	addrs := make([]module.Address, len(param.Addresses))
+	for i, addr := range param.Addresses {
+		addrs[i] = addr.Address()
+	}
 
-	timer := time.After(time.Second * 60)
-	for {
+	blocks := make([]interface{}, 0)
+	deadline := time.Now().Add(ConfigTraceBlocksTimeout)
+	height := from
+trace:
+	for ; height <= to && height < from+ConfigMaxTraceBlocks; height++ {
+		if height > from && time.Now().After(deadline) {
+			break
+		}
+		blk, err := getStateBlock(chain, bm, jsonrpc.HexInt(intconv.FormatInt(height)), debug)
+		if err != nil {
+			return nil, err
+		}
+		tr, nblk, err := newTransitionForTrace(bm, sm, blk)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		cb, ti, err := newBalanceTraceInfo(chain, sm, blk, nblk)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		canceller, err := tr.ExecuteForTrace(ti)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
 		select {
-		case <-timer:
+		case <-time.After(ConfigTraceBlockTimeout):
 			canceller()
-			return nil, jsonrpc.ErrorCodeSystemTimeout.Errorf(
-				"Not enough time to get result of %+v", param)
+			if height == from {
+				return nil, jsonrpc.ErrorCodeSystemTimeout.Errorf(
+					"Not enough time to trace block height=%d", height)
+			}
+			// keep traced blocks, then it continues with this block.
+			break trace
 		case <-cb.channel:
-			return cb.balanceChangeToJSON(blk), nil
+		}
+		if err := cb.lastError(); err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		jso := cb.balanceChangeToJSON(blk, addrs)
+		if changes := jso["balanceChanges"].([]interface{}); len(changes) > 0 {
+			blocks = append(blocks, jso)
 		}
 	}
-	return nil, jsonrpc.ErrorCodeSystem.New("Unknown error on channel")
+
+	result := map[string]interface{}{
+		"blocks": blocks,
+	}
+	if height <= to {
+		result["next"] = intconv.FormatInt(height)
+	}
+	return result, nil
 }
 
 func findBlockAndTxInfoByRosettaTraceParam(
@@ -1298,5 +1968,8 @@ func RosettaMethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepositor
 
 	mr.RegisterMethod("rosetta_getTrace", getTraceForRosetta)
 
+	mr.SetParams("rosetta_getTrace", RosettaTraceParam{})
+
+	mr.RegisterDiscover(&jsonrpc.OpenRPCInfo{Title: "ICON JSON-RPC v3 Rosetta", Version: "3"})
 	return mr
 }
@@ -0,0 +1,22 @@
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogPosition(t *testing.T) {
+	var p logPosition
+	assert.NoError(t, p.SetString("0x10:0x2:0x0"))
+	assert.Equal(t, logPosition{0x10, 2, 0}, p)
+	assert.Equal(t, "0x10:0x2:0x0", p.String())
+
+	assert.True(t, logPosition{0x10, 1, 5}.Less(p))
+	assert.True(t, p.Less(logPosition{0x10, 2, 1}))
+	assert.False(t, p.Less(p))
+
+	for _, s := range []string{"", "0x10", "0x10:0x2", "0x10:-0x1:0x0", "0x10:0x2:xyz"} {
+		assert.Error(t, p.SetString(s), s)
+	}
+}
@@ -43,10 +43,23 @@ type ScoreAddressParam struct {
 	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
 }
 
+type StorageParam struct {
+	Address jsonrpc.Address  `json:"address" validate:"required,t_addr_score"`
+	Key     jsonrpc.HexBytes `json:"key" validate:"required,t_bin_data"`
+	Height  jsonrpc.HexInt   `json:"height,omitempty" validate:"optional,t_int"`
+}
+
 type TransactionHashParam struct {
 	Hash jsonrpc.HexBytes `json:"txHash" validate:"required,t_hash"`
 }
 
+// TraceParam is the parameter of debug_getTrace. Empty mode means
+// TraceModeInvoke.
+type TraceParam struct {
+	Hash jsonrpc.HexBytes `json:"txHash" validate:"required,t_hash"`
+	Mode string           `json:"traceMode,omitempty" validate:"optional,oneof=invoke callTree"`
+}
+
 type TransactionParamForEstimate struct {
 	Version     jsonrpc.HexInt  `json:"version" validate:"required,t_int"`
 	FromAddress jsonrpc.Address `json:"from" validate:"required,t_addr_eoa"`
@@ -59,6 +72,13 @@ type TransactionParamForEstimate struct {
 	Data        interface{}     `json:"data,omitempty"`
 }
 
+// TransactionParamForSimulate is the transaction for estimation with the
+// height of the state to run on. Empty height means the last block.
+type TransactionParamForSimulate struct {
+	TransactionParamForEstimate
+	Height jsonrpc.HexInt `json:"height,omitempty" validate:"optional,t_int"`
+}
+
 type TransactionParam struct {
 	Version     jsonrpc.HexInt  `json:"version" validate:"required,t_int"`
 	FromAddress jsonrpc.Address `json:"from" validate:"required,t_addr_eoa"`
@@ -93,3 +113,30 @@ type RosettaTraceParam struct {
 	Block  jsonrpc.HexBytes `json:"block,omitempty" validate:"optional,t_hash"`
 	Height jsonrpc.HexInt   `json:"height,omitempty" validate:"optional,gte=0,t_int"`
 }
+
+// TraceBlocksParam is the range of blocks to trace balance changes.
+// Empty To means the last finalized block.
+type TraceBlocksParam struct {
+	From      jsonrpc.HexInt    `json:"from" validate:"required,t_int"`
+	To        jsonrpc.HexInt    `json:"to,omitempty" validate:"optional,t_int"`
+	Addresses []jsonrpc.Address `json:"addresses,omitempty" validate:"optional,dive,t_addr"`
+}
+
+type PendingTransactionsParam struct {
+	Group string          `json:"group,omitempty" validate:"optional,oneof=normal patch"`
+	From  jsonrpc.Address `json:"from,omitempty" validate:"optional,t_addr_eoa"`
+	Skip  jsonrpc.HexInt  `json:"skip,omitempty" validate:"optional,t_int"`
+	Limit jsonrpc.HexInt  `json:"limit,omitempty" validate:"optional,t_int"`
+}
+
+type LogsParam struct {
+	FromHeight jsonrpc.HexInt    `json:"fromHeight" validate:"required,t_int"`
+	ToHeight   jsonrpc.HexInt    `json:"toHeight,omitempty" validate:"optional,t_int"`
+	Addr       jsonrpc.Address   `json:"addr,omitempty" validate:"optional,t_addr_score"`
+	Addrs      []jsonrpc.Address `json:"addrs,omitempty" validate:"optional,dive,t_addr_score"`
+	Event      string            `json:"event" validate:"required"`
+	Indexed    []*string         `json:"indexed,omitempty"`
+	Data       []*string         `json:"data,omitempty"`
+	Cursor     string            `json:"cursor,omitempty"`
+	Limit      jsonrpc.HexInt    `json:"limit,omitempty" validate:"optional,t_int"`
+}
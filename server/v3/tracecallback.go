@@ -19,6 +19,7 @@ type traceCallback struct {
 	ts      time.Time
 	channel chan interface{}
 	bt      *trace.BalanceTracer
+	ct      *trace.CallTracer
 }
 
 type traceLog struct {
@@ -49,6 +50,14 @@ func (t *traceCallback) OnEnd(e error) {
 	close(t.channel)
 }
 
+// lastError returns the error given to OnEnd.
+func (t *traceCallback) lastError() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.last
+}
+
 func (t *traceCallback) invokeTraceToJSON() interface{} {
 	t.lock.Lock()
 	defer t.lock.Unlock()
@@ -69,7 +78,30 @@ func (t *traceCallback) invokeTraceToJSON() interface{} {
 	return result
 }
 
-func (t *traceCallback) balanceChangeToJSON(blk module.Block) interface{} {
+func (t *traceCallback) callTreeToJSON() interface{} {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	result := map[string]interface{}{
+		"calls": []interface{}{},
+	}
+	if txs, ok := t.ct.ToJSON().([]interface{}); ok && len(txs) > 0 {
+		result["calls"] = txs[0].(map[string]interface{})["calls"]
+	}
+	if t.last == nil {
+		result["status"] = "0x1"
+	} else {
+		result["status"] = "0x0"
+		status, _ := scoreresult.StatusOf(t.last)
+		result["failure"] = map[string]interface{}{
+			"code":    status,
+			"message": t.last.Error(),
+		}
+	}
+	return result
+}
+
+func (t *traceCallback) balanceChangeToJSON(blk module.Block, addrs []module.Address) map[string]interface{} {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
@@ -81,10 +113,7 @@ func (t *traceCallback) balanceChangeToJSON(blk module.Block) interface{} {
 		"timestamp":     fmt.Sprintf("%#x", blk.Timestamp()),
 	}
 
-	balanceChanges := t.bt.ToJSON(height)
-	if balanceChanges != nil {
-		result["balanceChanges"] = balanceChanges
-	}
+	result["balanceChanges"] = t.bt.ToJSONForAddresses(height, addrs)
 	return result
 }
 
@@ -94,6 +123,11 @@ func (t *traceCallback) OnTransactionStart(txIndex int, txHash []byte, isBlockTx
 		defer t.lock.Unlock()
 		return t.bt.OnTransactionStart(txIndex, txHash, isBlockTx)
 	}
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnTransactionStart(txIndex, txHash, isBlockTx)
+	}
 	return nil
 }
 
@@ -105,6 +139,9 @@ func (t *traceCallback) OnTransactionReset() error {
 	if t.bt != nil {
 		return t.bt.OnTransactionReset()
 	}
+	if t.ct != nil {
+		return t.ct.OnTransactionReset()
+	}
 	return nil
 }
 
@@ -114,6 +151,11 @@ func (t *traceCallback) OnTransactionEnd(txIndex int, txHash []byte) error {
 		defer t.lock.Unlock()
 		return t.bt.OnTransactionEnd(txIndex, txHash)
 	}
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnTransactionEnd(txIndex, txHash)
+	}
 	return nil
 }
 
@@ -123,6 +165,11 @@ func (t *traceCallback) OnFrameEnter() error {
 		defer t.lock.Unlock()
 		return t.bt.OnFrameEnter()
 	}
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnFrameEnter()
+	}
 	return nil
 }
 
@@ -132,6 +179,11 @@ func (t *traceCallback) OnFrameExit(success bool) error {
 		defer t.lock.Unlock()
 		return t.bt.OnFrameExit(success)
 	}
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnFrameExit(success)
+	}
 	return nil
 }
 
@@ -143,3 +195,30 @@ func (t *traceCallback) OnBalanceChange(opType module.OpType, from, to module.Ad
 	}
 	return nil
 }
+
+func (t *traceCallback) OnFrameCall(call *module.FrameCall) error {
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnFrameCall(call)
+	}
+	return nil
+}
+
+func (t *traceCallback) OnFrameResult(stepUsed *big.Int, result interface{}, reason error) error {
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnFrameResult(stepUsed, result, reason)
+	}
+	return nil
+}
+
+func (t *traceCallback) OnFrameEvent(addr module.Address, indexed, data [][]byte) error {
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnFrameEvent(addr, indexed, data)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+func TestTraceCallback_LastError(t *testing.T) {
+	cb := &traceCallback{channel: make(chan interface{}, 10)}
+	e := errors.InvalidStateError.New("Failed")
+
+	// OnEnd is called by the executor in another goroutine
+	go cb.OnEnd(e)
+	<-cb.channel
+	assert.Equal(t, e, cb.lastError())
+}
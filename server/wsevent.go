@@ -1,11 +1,13 @@
 package server
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
 
+	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 
+	"github.com/icon-project/goloop/block"
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
@@ -15,30 +17,53 @@ import (
 
 type EventRequest struct {
 	EventFilter
-	Height common.HexInt64 `json:"height"`
-	Logs   common.HexInt32 `json:"logs,omitempty""`
+	Height       common.HexInt64 `json:"height"`
+	Logs         common.HexInt32 `json:"logs,omitempty""`
+	EventFilters []*EventFilter  `json:"eventFilters,omitempty"`
+	filters      []*eventFilterEntry
+	nextID       int32
 }
 
 type EventFilter struct {
-	Addr       *common.Address `json:"addr,omitempty"`
-	Signature  string          `json:"event"`
-	Indexed    []*string       `json:"indexed,omitempty"`
-	Data       []*string       `json:"data,omitempty"`
-	indexedBSs [][]byte
-	dataBSs    [][]byte
-	numOfArgs  int
-	lb         module.LogsBloom
-	indexes    []int
+	Addr      *common.Address   `json:"addr,omitempty"`
+	Addrs     []*common.Address `json:"addrs,omitempty"`
+	Signature string            `json:"event"`
+	Indexed   []*string         `json:"indexed,omitempty"`
+	Data      []*string         `json:"data,omitempty"`
+	addrs     []module.Address
+	ef        *txresult.EventFilter
+	lb        module.LogsBloom
+	indexes   []int
+}
+
+type eventFilterEntry struct {
+	id     int32
+	filter *EventFilter
 }
 
 type EventNotification struct {
 	Hash   common.HexBytes   `json:"hash"`
 	Height common.HexInt64   `json:"height"`
 	Index  common.HexInt32   `json:"index"`
+	Filter *common.HexInt32  `json:"filter,omitempty"`
 	Events []common.HexInt32 `json:"events"`
 	Logs   []module.EventLog `json:"logs,omitempty"`
 }
 
+// EventFilterUpdate is the message from the client to add or remove
+// filters of the session started with eventFilters.
+type EventFilterUpdate struct {
+	AddFilters    []*EventFilter    `json:"addFilters,omitempty"`
+	RemoveFilters []common.HexInt32 `json:"removeFilters,omitempty"`
+}
+
+// EventFilterUpdateResponse is the response for EventFilterUpdate.
+// IDs are identifiers of added filters.
+type EventFilterUpdateResponse struct {
+	WSResponse
+	IDs []common.HexInt32 `json:"ids,omitempty"`
+}
+
 func (wm *wsSessionManager) RunEventSession(ctx echo.Context) error {
 	var er EventRequest
 	wss, err := wm.initSession(ctx, &er)
@@ -68,48 +93,45 @@ func (wm *wsSessionManager) RunEventSession(ctx echo.Context) error {
 
 	_ = wss.response(0, "")
 
-	ech := make(chan error)
-	go readLoop(wss.c, ech)
+	ech := make(chan error, 1)
+	mch := make(chan []byte)
+	done := make(chan struct{})
+	defer close(done)
+	go readMessageLoop(wss.c, mch, ech, done)
+
+	var idx *block.EventIndex
+	if wss.chain.EventIndex() {
+		if idx, err = block.NewEventIndex(wss.chain.Database()); err != nil {
+			wm.logger.Warnf("fail to open event index err:%+v\n", err)
+			idx = nil
+		}
+	}
 
 	var bch <-chan module.Block
 
 loop:
 	for {
+		if h, err = er.nextHeight(idx, h); err != nil {
+			break loop
+		}
 		bch, err = bm.WaitForBlock(h)
 		if err != nil {
 			break loop
 		}
-		select {
-		case err = <-ech:
-			break loop
-		case blk := <-bch:
-			if !blk.LogsBloom().Contain(er.lb) {
-				h++
-				continue loop
-			}
-			rl, err := sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
-			if err != nil {
+	wait:
+		for {
+			select {
+			case err = <-ech:
 				break loop
-			}
-			index := int32(0)
-			for rit := rl.Iterator(); rit.Has(); rit.Next() {
-				r, err := rit.Get()
-				if err != nil {
+			case msg := <-mch:
+				if err = wss.WriteJSON(er.update(msg)); err != nil {
 					break loop
 				}
-				if es, el, err := er.matchWithLogs(r, er.Logs.Value != 0); err == nil && len(es) > 0 {
-					var en EventNotification
-					en.Height.Value = h
-					en.Hash = blk.ID()
-					en.Index.Value = index
-					en.Events = es
-					en.Logs = el
-					if err := wss.WriteJSON(&en); err != nil {
-						wm.logger.Infof("fail to write json EventNotification err:%+v\n", err)
-						break loop
-					}
+			case blk := <-bch:
+				if err = wm.notifyEvents(wss, &er, sm, blk); err != nil {
+					break loop
 				}
-				index++
+				break wait
 			}
 		}
 		h++
@@ -118,55 +140,221 @@ loop:
 	return nil
 }
 
-func (f *EventFilter) compile() error {
-	lb := txresult.NewLogsBloom(nil)
-	if f.Addr != nil {
-		lb.AddAddressOfLog(f.Addr)
-	}
-	f.numOfArgs = len(f.Indexed) + len(f.Data)
-	name, pts := txresult.DecomposeEventSignature(f.Signature)
-	if len(name) == 0 || pts == nil || len(pts) < f.numOfArgs {
-		return errors.NewBase(errors.IllegalArgumentError, "bad event signature")
-	}
-	lb.AddIndexedOfLog(0, []byte(f.Signature))
-	idx := 0
-	f.indexedBSs = make([][]byte, len(f.Indexed))
-	for i, arg := range f.Indexed {
-		if arg != nil {
-			bs, err := txresult.EventDataStringToBytesByType(pts[idx], string(*arg))
+// nextHeight returns the height of the next block to notify. It skips
+// blocks without events of the filters if they are covered by the event
+// index. It can't skip any block if one of the filters has no address.
+func (er *EventRequest) nextHeight(idx *block.EventIndex, height int64) (int64, error) {
+	if idx == nil || len(er.filters) == 0 {
+		return height, nil
+	}
+	from, to, err := idx.Range()
+	if err != nil {
+		return height, err
+	}
+	// receipts of transactions in the previous block are in the block.
+	txh := height - 1
+	if txh < from || txh > to {
+		return height, nil
+	}
+	next := to + 1
+	for _, fe := range er.filters {
+		if len(fe.filter.addrs) == 0 {
+			return height, nil
+		}
+		for _, addr := range fe.filter.addrs {
+			n, err := idx.Next(addr, fe.filter.Signature, txh)
 			if err != nil {
-				return errors.NewBase(errors.IllegalArgumentError, "bad event data")
+				return height, err
+			}
+			if n >= 0 && n < next {
+				next = n
 			}
-			lb.AddIndexedOfLog(i+1, bs)
-			f.indexedBSs[i] = bs
 		}
-		idx++
 	}
-	f.dataBSs = make([][]byte, len(f.Data))
-	for i, arg := range f.Data {
-		if arg != nil {
-			bs, err := txresult.EventDataStringToBytesByType(pts[idx], string(*arg))
-			if err != nil {
-				return errors.NewBase(errors.IllegalArgumentError, "bad event data")
+	return next + 1, nil
+}
+
+func (wm *wsSessionManager) notifyEvents(wss *wsSession, er *EventRequest, sm module.ServiceManager, blk module.Block) error {
+	return er.forEachNotification(sm, blk, func(en *EventNotification) error {
+		if err := wss.WriteJSON(en); err != nil {
+			wm.logger.Infof("fail to write json EventNotification err:%+v\n", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// forEachNotification calls f with the notification for each pair of
+// a transaction and a filter matched in the block.
+func (er *EventRequest) forEachNotification(sm module.ServiceManager, blk module.Block, f func(en *EventNotification) error) error {
+	lb := blk.LogsBloom()
+	var filters []*eventFilterEntry
+	for _, fe := range er.filters {
+		if lb.Contain(fe.filter.lb) {
+			filters = append(filters, fe)
+		}
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	rl, err := sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return err
+	}
+	multi := er.EventFilters != nil
+	includeLogs := multi || er.Logs.Value != 0
+	index := int32(0)
+	for rit := rl.Iterator(); rit.Has(); rit.Next() {
+		r, err := rit.Get()
+		if err != nil {
+			return err
+		}
+		for _, fe := range filters {
+			if es, el, err := fe.filter.matchWithLogs(r, includeLogs); err == nil && len(es) > 0 {
+				var en EventNotification
+				en.Height.Value = blk.Height()
+				en.Hash = blk.ID()
+				en.Index.Value = index
+				if multi {
+					en.Filter = &common.HexInt32{Value: fe.id}
+				}
+				en.Events = es
+				en.Logs = el
+				if err := f(&en); err != nil {
+					return err
+				}
 			}
-			f.dataBSs[i] = bs
 		}
-		idx++
+		index++
 	}
-	f.lb = lb
 	return nil
 }
 
-// bytesEqual check equality of byte slice.
-// But it doesn't assume nil as empty bytes.
-func bytesEqual(b1 []byte, b2 []byte) bool {
-	if b1 == nil && b2 == nil {
-		return true
+// readMessageLoop delivers messages from the client until it fails to read.
+func readMessageLoop(c *websocket.Conn, mch chan<- []byte, ech chan<- error, done <-chan struct{}) {
+	for {
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			ech <- err
+			return
+		}
+		select {
+		case mch <- msg:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (r *EventRequest) compile() error {
+	if r.EventFilters == nil {
+		if err := r.EventFilter.compile(); err != nil {
+			return err
+		}
+		r.filters = []*eventFilterEntry{{id: 0, filter: &r.EventFilter}}
+		return nil
+	}
+	if len(r.Signature) > 0 {
+		return errors.NewBase(errors.IllegalArgumentError,
+			"event and eventFilters can't be used together")
+	}
+	_, err := r.addFilters(r.EventFilters)
+	return err
+}
+
+// addFilters adds all filters or nothing if any of them is invalid.
+func (r *EventRequest) addFilters(fs []*EventFilter) ([]common.HexInt32, error) {
+	for i, f := range fs {
+		if f == nil {
+			return nil, errors.NewBase(errors.IllegalArgumentError,
+				fmt.Sprintf("null filter idx:%d", i))
+		}
+		if err := f.compile(); err != nil {
+			return nil, errors.NewBase(errors.IllegalArgumentError,
+				fmt.Sprintf("fail to compile idx:%d, err:%v", i, err))
+		}
+	}
+	ids := make([]common.HexInt32, len(fs))
+	for i, f := range fs {
+		ids[i].Value = r.nextID
+		r.filters = append(r.filters, &eventFilterEntry{id: r.nextID, filter: f})
+		r.nextID++
+	}
+	return ids, nil
+}
+
+// removeFilters removes all filters or nothing if any of them is unknown.
+func (r *EventRequest) removeFilters(ids []common.HexInt32) error {
+	remove := make(map[int32]bool)
+	for _, id := range ids {
+		remove[id.Value] = true
+	}
+	filters := make([]*eventFilterEntry, 0, len(r.filters))
+	for _, fe := range r.filters {
+		if remove[fe.id] {
+			delete(remove, fe.id)
+		} else {
+			filters = append(filters, fe)
+		}
+	}
+	if len(remove) > 0 {
+		return errors.NewBase(errors.IllegalArgumentError, "unknown filter id")
+	}
+	r.filters = filters
+	return nil
+}
+
+// update applies the message from the client, then it returns the response
+// for the message.
+func (r *EventRequest) update(msg []byte) *EventFilterUpdateResponse {
+	resp := new(EventFilterUpdateResponse)
+	if r.EventFilters == nil {
+		resp.Code = int(jsonrpc.ErrorCodeInvalidRequest)
+		resp.Message = "filter update requires eventFilters"
+		return resp
 	}
-	if b1 == nil || b2 == nil {
-		return false
+	var u EventFilterUpdate
+	if err := json.Unmarshal(msg, &u); err != nil {
+		resp.Code = int(jsonrpc.ErrorCodeJsonParse)
+		resp.Message = "bad filter update"
+		return resp
 	}
-	return bytes.Equal(b1, b2)
+	filters := r.filters
+	if err := r.removeFilters(u.RemoveFilters); err != nil {
+		resp.Code = int(jsonrpc.ErrorCodeInvalidParams)
+		resp.Message = err.Error()
+		return resp
+	}
+	ids, err := r.addFilters(u.AddFilters)
+	if err != nil {
+		r.filters = filters
+		resp.Code = int(jsonrpc.ErrorCodeInvalidParams)
+		resp.Message = err.Error()
+		return resp
+	}
+	resp.IDs = ids
+	return resp
+}
+
+func (f *EventFilter) compile() error {
+	var addrs []module.Address
+	if f.Addr != nil {
+		addrs = append(addrs, f.Addr)
+	}
+	for _, addr := range f.Addrs {
+		if addr == nil {
+			return errors.NewBase(errors.IllegalArgumentError, "null address")
+		}
+		addrs = append(addrs, addr)
+	}
+	ef, err := txresult.NewEventFilter(addrs, f.Signature, f.Indexed, f.Data)
+	if err != nil {
+		return err
+	}
+	f.addrs = addrs
+	f.ef = ef
+	f.lb = ef.LogsBloom()
+	return nil
 }
 
 func (f *EventFilter) matchWithLogs(r module.Receipt, includeLogs bool) ([]common.HexInt32, []module.EventLog, error) {
@@ -196,37 +384,5 @@ func (f *EventFilter) match(r module.Receipt) ([]common.HexInt32, bool) {
 }
 
 func (f *EventFilter) filterFunc(r module.Receipt, v func(idx int, log module.EventLog)) error {
-	if r.LogsBloom().Contain(f.lb) {
-	loop:
-		for it, idx := r.EventLogIterator(), 0; it.Has(); _, idx = it.Next(), idx+1 {
-			el, err := it.Get()
-			if err != nil {
-				return err
-			}
-
-			if bytes.Equal([]byte(f.Signature), el.Indexed()[0]) {
-				if f.Addr != nil && !el.Address().Equal(f.Addr) {
-					continue loop
-				}
-				if f.numOfArgs > 0 {
-					if (len(el.Indexed()) + len(el.Data())) <= f.numOfArgs {
-						continue loop
-					}
-
-					for i, arg := range f.indexedBSs {
-						if arg != nil && !bytesEqual(arg, el.Indexed()[i+1]) {
-							continue loop
-						}
-					}
-					for i, arg := range f.dataBSs {
-						if arg != nil && !bytesEqual(arg, el.Data()[i]) {
-							continue loop
-						}
-					}
-				}
-				v(idx, el)
-			}
-		}
-	}
-	return nil
+	return f.ef.ForEachMatch(r, v)
 }
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+func TestEventRequest_Update(t *testing.T) {
+	var er EventRequest
+	req := `{"height":"0x1","eventFilters":[` +
+		`{"event":"Transfer(Address,Address,int)","addrs":["cx0000000000000000000000000000000000000001","cx0000000000000000000000000000000000000002"]}]}`
+	if err := json.Unmarshal([]byte(req), &er); err != nil {
+		t.Fatalf("Fail to unmarshal request err=%+v", err)
+	}
+	if err := er.compile(); err != nil {
+		t.Fatalf("Fail to compile request err=%+v", err)
+	}
+	if len(er.filters) != 1 || len(er.filters[0].filter.Addrs) != 2 {
+		t.Fatalf("Unexpected filters %+v", er.filters)
+	}
+
+	resp := er.update([]byte(`{"addFilters":[{"event":"Approval(Address,Address,int)"}]}`))
+	if resp.Code != 0 || len(resp.IDs) != 1 || resp.IDs[0].Value != 1 {
+		t.Fatalf("Unexpected response %+v", resp)
+	}
+
+	resp = er.update([]byte(`{"removeFilters":["0x0"],"addFilters":[{"event":"Bad"}]}`))
+	if resp.Code == 0 || len(er.filters) != 2 {
+		t.Errorf("It should fail without changes resp=%+v filters=%d", resp, len(er.filters))
+	}
+
+	resp = er.update([]byte(`{"removeFilters":["0x5"]}`))
+	if resp.Code == 0 || len(er.filters) != 2 {
+		t.Errorf("It should fail with unknown id resp=%+v", resp)
+	}
+
+	resp = er.update([]byte(`{"removeFilters":["0x0"]}`))
+	if resp.Code != 0 || len(er.filters) != 1 || er.filters[0].id != 1 {
+		t.Errorf("Fail to remove filter resp=%+v", resp)
+	}
+}
+
+func TestEventRequest_Legacy(t *testing.T) {
+	er := EventRequest{
+		EventFilter: EventFilter{
+			Addr:      common.MustNewAddressFromString("cx0000000000000000000000000000000000000001"),
+			Signature: "Transfer(Address,Address,int)",
+		},
+	}
+	if err := er.compile(); err != nil {
+		t.Fatalf("Fail to compile request err=%+v", err)
+	}
+	if len(er.filters) != 1 || er.filters[0].filter != &er.EventFilter {
+		t.Fatalf("Unexpected filters %+v", er.filters)
+	}
+	if resp := er.update([]byte(`{"removeFilters":["0x0"]}`)); resp.Code == 0 {
+		t.Errorf("It should fail to update filters without eventFilters")
+	}
+
+	er.EventFilters = []*EventFilter{}
+	if err := er.compile(); err == nil {
+		t.Errorf("It should fail with both of event and eventFilters")
+	}
+}
+
+func TestEventRequest_NextHeight(t *testing.T) {
+	score := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	sig := "Transfer(Address,Address,int)"
+	dbase := db.NewMapDB()
+	idx, err := block.NewEventIndex(dbase)
+	if err != nil {
+		t.Fatalf("Fail to make event index err=%+v", err)
+	}
+	for h := int64(10); h <= 20; h++ {
+		r := txresult.NewReceipt(dbase, module.LatestRevision, nil)
+		if h == 15 {
+			r.AddLog(score, [][]byte{[]byte(sig)}, nil)
+		}
+		r.SetResult(module.StatusSuccess, big.NewInt(0), big.NewInt(0), nil)
+		rl := txresult.NewReceiptListFromSlice(dbase, []txresult.Receipt{r})
+		if err := idx.Add(h, rl); err != nil {
+			t.Fatalf("Fail to add receipts err=%+v", err)
+		}
+	}
+
+	er := EventRequest{
+		EventFilter: EventFilter{Addr: score, Signature: sig},
+	}
+	if err := er.compile(); err != nil {
+		t.Fatalf("Fail to compile request err=%+v", err)
+	}
+	cases := []struct {
+		height int64
+		next   int64
+	}{
+		{5, 5},
+		{11, 16},
+		{16, 16},
+		{17, 22},
+		{22, 22},
+	}
+	for _, c := range cases {
+		if next, err := er.nextHeight(idx, c.height); err != nil || next != c.next {
+			t.Errorf("nextHeight(%d)=%d err=%v expected=%d", c.height, next, err, c.next)
+		}
+	}
+
+	er = EventRequest{
+		EventFilter: EventFilter{Signature: sig},
+	}
+	if err := er.compile(); err != nil {
+		t.Fatalf("Fail to compile request err=%+v", err)
+	}
+	if next, _ := er.nextHeight(idx, 11); next != 11 {
+		t.Errorf("It should not skip blocks without address next=%d", next)
+	}
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+const configTxEventBuffer = 256
+
+const (
+	txStatusAccepted = "accepted"
+	txStatusDropped  = "dropped"
+)
+
+type TransactionRequest struct {
+	From     *common.Address `json:"from,omitempty"`
+	To       *common.Address `json:"to,omitempty"`
+	DataType string          `json:"dataType,omitempty"`
+}
+
+type TransactionNotification struct {
+	Hash        common.HexBytes `json:"hash"`
+	Status      string          `json:"status"`
+	Reason      string          `json:"reason,omitempty"`
+	Transaction interface{}     `json:"transaction"`
+}
+
+func (wm *wsSessionManager) RunTransactionSession(ctx echo.Context) error {
+	var tr TransactionRequest
+	wss, err := wm.initSession(ctx, &tr)
+	if err != nil {
+		return err
+	}
+	defer wm.StopSession(wss)
+
+	sm := wss.chain.ServiceManager()
+	if sm == nil {
+		_ = wss.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return nil
+	}
+
+	evch, cancel, err := sm.SubscribeTransactionPool(configTxEventBuffer)
+	if err != nil {
+		_ = wss.response(int(jsonrpc.ErrorCodeServer), err.Error())
+		return nil
+	}
+	defer cancel()
+
+	_ = wss.response(0, "")
+
+	ech := make(chan error)
+	go readLoop(wss.c, ech)
+
+loop:
+	for {
+		select {
+		case err = <-ech:
+			break loop
+		case ev, ok := <-evch:
+			if !ok {
+				err = errors.New("transaction subscription is closed")
+				break loop
+			}
+			tn, ok := tr.match(&ev)
+			if !ok {
+				continue loop
+			}
+			if err = wss.WriteJSON(tn); err != nil {
+				wm.logger.Infof("fail to write json TransactionNotification err:%+v\n", err)
+				break loop
+			}
+		}
+	}
+	wm.logger.Warnf("%+v\n", err)
+	return nil
+}
+
+// match returns the notification for the event if the transaction of the
+// event is acceptable for the request. It checks the transaction before
+// getting the JSON object shared by the sessions.
+func (r *TransactionRequest) match(ev *module.TransactionPoolEvent) (*TransactionNotification, bool) {
+	tx := ev.Tx
+	if tx.Group() != module.TransactionGroupNormal {
+		return nil, false
+	}
+	if r.From != nil && !r.From.Equal(tx.From()) {
+		return nil, false
+	}
+	if r.To != nil {
+		if rtx, ok := tx.(receiverTransaction); !ok || !r.To.Equal(rtx.To()) {
+			return nil, false
+		}
+	}
+	js, err := jsonOf(ev)
+	if err != nil {
+		return nil, false
+	}
+	jso, ok := js.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if len(r.DataType) > 0 {
+		if dt, _ := jso["dataType"].(string); dt != r.DataType {
+			return nil, false
+		}
+	}
+	tn := &TransactionNotification{
+		Hash:        tx.ID(),
+		Transaction: jso,
+	}
+	switch ev.Type {
+	case module.TransactionAccepted:
+		tn.Status = txStatusAccepted
+	case module.TransactionDropped:
+		tn.Status = txStatusDropped
+		if ev.Error != nil {
+			tn.Reason = ev.Error.Error()
+		}
+	}
+	return tn, true
+}
+
+// receiverTransaction is a transaction having the receiver, like the ones
+// in the pool.
+type receiverTransaction interface {
+	To() module.Address
+}
+
+func jsonOf(ev *module.TransactionPoolEvent) (interface{}, error) {
+	if ev.JSON != nil {
+		return ev.JSON()
+	}
+	return ev.Tx.ToJSON(module.JSONVersionLast)
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+)
+
+type testPoolTransaction struct {
+	module.Transaction
+	from     module.Address
+	to       module.Address
+	dataType string
+	encoded  int
+}
+
+func (tx *testPoolTransaction) Group() module.TransactionGroup {
+	return module.TransactionGroupNormal
+}
+
+func (tx *testPoolTransaction) ID() []byte {
+	return []byte("tx")
+}
+
+func (tx *testPoolTransaction) From() module.Address {
+	return tx.from
+}
+
+func (tx *testPoolTransaction) To() module.Address {
+	return tx.to
+}
+
+func (tx *testPoolTransaction) ToJSON(version module.JSONVersion) (interface{}, error) {
+	tx.encoded++
+	jso := map[string]interface{}{
+		"from": tx.from,
+		"to":   tx.to,
+	}
+	if len(tx.dataType) > 0 {
+		jso["dataType"] = tx.dataType
+	}
+	return jso, nil
+}
+
+func TestTransactionRequest_Match(t *testing.T) {
+	addr1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	addr2 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+	tx := &testPoolTransaction{from: addr1, to: addr2, dataType: "call"}
+	var calls int
+	ev := &module.TransactionPoolEvent{
+		Type: module.TransactionAccepted,
+		Tx:   tx,
+		JSON: func() (interface{}, error) {
+			calls++
+			return tx.ToJSON(module.JSONVersionLast)
+		},
+	}
+
+	// transactions are filtered without encoding
+	for _, r := range []*TransactionRequest{
+		{From: common.AddressToPtr(addr2)},
+		{To: common.AddressToPtr(addr1)},
+	} {
+		if _, ok := r.match(ev); ok {
+			t.Errorf("It should not match request=%+v", r)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("Transaction is encoded for filtered requests calls=%d", calls)
+	}
+
+	for _, r := range []*TransactionRequest{
+		{},
+		{From: common.AddressToPtr(addr1), To: common.AddressToPtr(addr2), DataType: "call"},
+	} {
+		tn, ok := r.match(ev)
+		if !ok {
+			t.Errorf("It should match request=%+v", r)
+			continue
+		}
+		if tn.Status != txStatusAccepted || string(tn.Hash) != "tx" {
+			t.Errorf("Unexpected notification %+v", tn)
+		}
+	}
+	r := &TransactionRequest{DataType: "deploy"}
+	if _, ok := r.match(ev); ok {
+		t.Errorf("It should not match request=%+v", r)
+	}
+	if calls != 3 {
+		t.Errorf("Unexpected calls for JSON calls=%d", calls)
+	}
+}
@@ -75,6 +75,12 @@ type (
 		handler   ContractHandler
 		stepLimit *big.Int
 	}
+
+	// frameCaller is implemented by handlers describing their calls for
+	// TraceModeCallTree.
+	frameCaller interface {
+		FrameCall() *module.FrameCall
+	}
 )
 
 const (
@@ -146,17 +152,30 @@ func (cc *callContext) pushFrame(handler ContractHandler, limit *big.Int) *callF
 		frame.snapshot = cc.GetSnapshot()
 	}
 	logger.OnFrameEnter(cc.frame.fid)
+	if logger.CallTreeEnabled() {
+		if fc, ok := handler.(frameCaller); ok {
+			logger.OnFrameCall(fc.FrameCall())
+		}
+	}
 	frame.fid = cc.nextFID
 	cc.nextFID += 1
 	cc.frame = frame
 	return frame
 }
 
-func (cc *callContext) popFrame(success bool) *callFrame {
+func (cc *callContext) popFrame(status error, result *codec.TypedObj) *callFrame {
 	cc.lock.Lock()
 	defer cc.lock.Unlock()
 
 	frame := cc.frame
+	success := status == nil
+	if frame.log.CallTreeEnabled() {
+		var res interface{}
+		if result != nil {
+			res, _ = common.DecodeAnyForJSON(result)
+		}
+		frame.log.OnFrameResult(&frame.stepUsed, res, status)
+	}
 	cc.frame.log.OnFrameExit(success, &frame.stepUsed)
 	if !frame.isQuery {
 		if success {
@@ -211,6 +230,7 @@ func (cc *callContext) addLogToFrame(addr module.Address, indexed [][]byte, data
 		addr, indexed[0],
 		common.SliceOfHexBytes(indexed[1:]),
 		common.SliceOfHexBytes(data))
+	cc.frame.log.OnFrameEvent(addr, indexed, data)
 	cc.frame.addLog(addr, indexed, data)
 	return nil
 }
@@ -373,7 +393,7 @@ func (cc *callContext) handleResult(target *callFrame, status error, result *cod
 		return false
 	}
 
-	current := cc.popFrame(status == nil)
+	current := cc.popFrame(status, result)
 	if current == nil {
 		return false
 	}
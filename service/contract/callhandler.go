@@ -184,6 +184,17 @@ func (h *CallHandler) TLogDone(status error, steps *big.Int, result *codec.Typed
 	}
 }
 
+func (h *CallHandler) FrameCall() *module.FrameCall {
+	call := h.CommonHandler.FrameCall()
+	call.Method = h.name
+	if h.params != nil {
+		call.Params = json.RawMessage(h.params)
+	} else if h.paramObj != nil {
+		call.Params, _ = common.DecodeAnyForJSON(h.paramObj)
+	}
+	return call
+}
+
 func (h *CallHandler) ExecuteAsync(cc CallContext) (err error) {
 	h.TLogStart()
 	defer func() {
@@ -92,6 +92,11 @@ func (h *CommonHandler) TraceLogger() *trace.Logger {
 	return h.Log
 }
 
+// FrameCall returns the call of the handler for TraceModeCallTree.
+func (h *CommonHandler) FrameCall() *module.FrameCall {
+	return &module.FrameCall{From: h.From, To: h.To, Value: h.Value}
+}
+
 func (h *CommonHandler) Logger() log.Logger {
 	return h.Log
 }
@@ -15,6 +15,11 @@ const (
 	NotContractAddressError
 	InvalidPatchDataError
 	CommittedTransactionError
+	ReplacedTransactionError
+	UnderpricedTransactionError
+	SenderQuotaExceededError
+	EvictedTransactionError
+	StateNotAvailableError
 )
 
 var (
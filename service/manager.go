@@ -78,8 +78,14 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 		logger.Warnf("FAIL to create TXIDManager : %v\n", err)
 		return nil, err
 	}
-	pTxPool := NewTransactionPool(module.TransactionGroupPatch, chain.PatchTxPoolSize(), tim, pMetric, logger)
-	nTxPool := NewTransactionPool(module.TransactionGroupNormal, chain.NormalTxPoolSize(), tim, nMetric, logger)
+	// the policy is for normal transactions, patches use the default policy.
+	policy := TxPoolPolicy{
+		Order:       chain.TxPoolOrder(),
+		SenderLimit: chain.TxPoolSenderLimit(),
+		Eviction:    chain.TxPoolEviction(),
+	}
+	pTxPool := NewTransactionPool(module.TransactionGroupPatch, chain.PatchTxPoolSize(), TxPoolPolicy{}, tim, pMetric, logger)
+	nTxPool := NewTransactionPool(module.TransactionGroupNormal, chain.NormalTxPoolSize(), policy, tim, nMetric, logger)
 	tm := NewTransactionManager(chain.NID(), tsc, pTxPool, nTxPool, tim, logger)
 	syncm := ssync.NewSyncManager(chain.Database(), chain.NetworkManager(), plt, logger)
 
@@ -715,6 +721,19 @@ func (m *manager) HasTransaction(id []byte) bool {
 	return m.tm.HasTx(id)
 }
 
+func (m *manager) GetPendingTransactions(g module.TransactionGroup, from module.Address, skip, limit int) ([]module.PendingTransaction, int) {
+	return m.tm.PendingTransactions(g, from, skip, limit)
+}
+
+func (m *manager) GetPoolStatus(g module.TransactionGroup) (int, int) {
+	return m.tm.PoolStatus(g)
+}
+
+func (m *manager) SubscribeTransactionPool(size int) (<-chan module.TransactionPoolEvent, func(), error) {
+	ch, cancel := m.tm.Subscribe(size)
+	return ch, cancel, nil
+}
+
 func (m *manager) WaitForTransaction(
 	parent module.Transition,
 	bi module.BlockInfo,
@@ -754,31 +773,43 @@ func (m *manager) ImportResult(result []byte, vh []byte, src db.Database) error
 }
 
 func (m *manager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, error) {
+	rct, _, err := m.executeTransaction(result, vh, js, bi, false)
+	return rct, err
+}
+
+func (m *manager) SimulateTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, module.StateDiff, error) {
+	return m.executeTransaction(result, vh, js, bi, true)
+}
+
+func (m *manager) executeTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo, withDiff bool) (module.Receipt, module.StateDiff, error) {
 	tx, err := transaction.NewTransactionFromJSON(js)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if err := tx.Verify(); err != nil && !transaction.InvalidSignatureError.Equals(err) {
-		return nil, scoreresult.InvalidParameterError.Wrap(err, "InvalidTransaction")
+		return nil, nil, scoreresult.InvalidParameterError.Wrap(err, "InvalidTransaction")
 	}
 
 	txh, err := tx.GetHandler(m.cm)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer txh.Dispose()
 
-	var wc state.WorldContext
 	wss, err := m.trc.GetWorldSnapshot(result, vh)
-	if err == nil {
-		ws, err := state.WorldStateFromSnapshot(wss)
-		if err != nil {
-			return nil, err
-		}
-		wc = state.NewWorldContext(ws, bi, nil, m.plt)
-	} else {
-		return nil, err
+	if err != nil {
+		return nil, nil, err
+	}
+	ws, err := state.WorldStateFromSnapshot(wss)
+	if err != nil {
+		return nil, nil, err
 	}
+	var rec *state.DiffRecorder
+	if withDiff {
+		rec = state.NewDiffRecorder(ws)
+		ws = rec
+	}
+	wc := state.NewWorldContext(ws, bi, nil, m.plt)
 	ctx := contract.NewContext(wc, m.cm, m.eem, m.chain, m.log, nil, eeproxy.ForQuery)
 	ctx.SetTransactionInfo(&state.TransactionInfo{
 		Group:     module.TransactionGroupNormal,
@@ -790,7 +821,18 @@ func (m *manager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi mod
 	})
 	ctx.UpdateSystemInfo()
 
-	return txh.Execute(ctx, wss, true)
+	rct, err := txh.Execute(ctx, wss, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rec == nil {
+		return rct, nil, nil
+	}
+	diff, err := rec.Diff(wss)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rct, diff, nil
 }
 
 func (m *manager) AddSyncRequest(id db.BucketID, key []byte) error {
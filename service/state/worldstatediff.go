@@ -0,0 +1,175 @@
+package state
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+// DiffRecorder is WorldState recording accounts and storage keys written
+// through it. Diff returns the balance changes and the written keys from the
+// base snapshot.
+type DiffRecorder struct {
+	WorldState
+
+	lock     sync.Mutex
+	accounts map[string]*diffRecordingAccountState
+	ids      [][]byte
+}
+
+type diffRecordingAccountState struct {
+	AccountState
+
+	rec  *DiffRecorder
+	keys [][]byte
+	set  map[string]bool
+}
+
+func (as *diffRecordingAccountState) record(k []byte) {
+	as.rec.lock.Lock()
+	defer as.rec.lock.Unlock()
+
+	if !as.set[string(k)] {
+		as.set[string(k)] = true
+		as.keys = append(as.keys, k)
+	}
+}
+
+func (as *diffRecordingAccountState) SetValue(k, v []byte) ([]byte, error) {
+	as.record(k)
+	return as.AccountState.SetValue(k, v)
+}
+
+func (as *diffRecordingAccountState) DeleteValue(k []byte) ([]byte, error) {
+	as.record(k)
+	return as.AccountState.DeleteValue(k)
+}
+
+func (r *DiffRecorder) GetAccountState(id []byte) AccountState {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ids := string(id)
+	if as, ok := r.accounts[ids]; ok {
+		return as
+	}
+	as := &diffRecordingAccountState{
+		AccountState: r.WorldState.GetAccountState(id),
+		rec:          r,
+		set:          make(map[string]bool),
+	}
+	r.accounts[ids] = as
+	r.ids = append(r.ids, id)
+	return as
+}
+
+func balanceOf(ass AccountSnapshot) *big.Int {
+	if ass == nil {
+		return new(big.Int)
+	}
+	return ass.GetBalance()
+}
+
+func valueOf(ass AccountSnapshot, k []byte) ([]byte, error) {
+	if ass == nil {
+		return nil, nil
+	}
+	return ass.GetValue(k)
+}
+
+// Diff returns changes of balances and all storage keys written through the
+// recorder since base. Written keys are reported even if their values are
+// same as before. Accounts without balance change and written keys are
+// omitted.
+func (r *DiffRecorder) Diff(base WorldSnapshot) (*WorldStateDiff, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	diff := &WorldStateDiff{Accounts: []*AccountDiff{}}
+	for _, id := range r.ids {
+		as := r.accounts[string(id)]
+		before := base.GetAccountSnapshot(id)
+		after := r.WorldState.GetAccountSnapshot(id)
+
+		isContract := (after != nil && after.IsContract()) ||
+			(before != nil && before.IsContract())
+		ad := &AccountDiff{
+			Address: common.NewAddressWithTypeAndID(isContract, id),
+			Balance: [2]*big.Int{balanceOf(before), balanceOf(after)},
+		}
+		for _, k := range as.keys {
+			v1, err := valueOf(before, k)
+			if err != nil {
+				return nil, err
+			}
+			v2, err := valueOf(after, k)
+			if err != nil {
+				return nil, err
+			}
+			ad.Storage = append(ad.Storage, &StorageDiff{Key: k, Before: v1, After: v2})
+		}
+		if ad.Balance[0].Cmp(ad.Balance[1]) == 0 && len(ad.Storage) == 0 {
+			continue
+		}
+		diff.Accounts = append(diff.Accounts, ad)
+	}
+	return diff, nil
+}
+
+func NewDiffRecorder(ws WorldState) *DiffRecorder {
+	return &DiffRecorder{
+		WorldState: ws,
+		accounts:   make(map[string]*diffRecordingAccountState),
+	}
+}
+
+// WorldStateDiff is changes of accounts between two world states.
+type WorldStateDiff struct {
+	Accounts []*AccountDiff
+}
+
+type AccountDiff struct {
+	Address module.Address
+	// Balance has balances before and after.
+	Balance [2]*big.Int
+	Storage []*StorageDiff
+}
+
+// StorageDiff is a change of the storage. Nil value means the absence of
+// the key.
+type StorageDiff struct {
+	Key    []byte
+	Before []byte
+	After  []byte
+}
+
+func (d *WorldStateDiff) ToJSON(version module.JSONVersion) (interface{}, error) {
+	accounts := make([]interface{}, 0, len(d.Accounts))
+	for _, ad := range d.Accounts {
+		jso := map[string]interface{}{
+			"address": ad.Address,
+		}
+		if ad.Balance[0].Cmp(ad.Balance[1]) != 0 {
+			jso["balance"] = map[string]interface{}{
+				"before": intconv.FormatBigInt(ad.Balance[0]),
+				"after":  intconv.FormatBigInt(ad.Balance[1]),
+			}
+		}
+		storage := make([]interface{}, 0, len(ad.Storage))
+		for _, sd := range ad.Storage {
+			storage = append(storage, map[string]interface{}{
+				"key":    common.HexBytes(sd.Key),
+				"before": common.HexBytes(sd.Before),
+				"after":  common.HexBytes(sd.After),
+			})
+		}
+		jso["storage"] = storage
+		accounts = append(accounts, jso)
+	}
+	return map[string]interface{}{
+		"accounts": accounts,
+	}, nil
+}
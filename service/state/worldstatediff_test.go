@@ -0,0 +1,108 @@
+package state
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestDiffRecorder(t *testing.T) {
+	database := db.NewMapDB()
+	ws := NewWorldState(database, nil, nil, nil)
+
+	id1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001").ID()
+	id2 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002").ID()
+	as1 := ws.GetAccountState(id1)
+	as1.SetBalance(big.NewInt(100))
+	_, err := as1.SetValue([]byte("k1"), []byte("v1"))
+	assert.NoError(t, err)
+	id4 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000004").ID()
+	_, err = ws.GetAccountState(id4).SetValue([]byte("k4"), []byte("v5"))
+	assert.NoError(t, err)
+	base := ws.GetSnapshot()
+
+	rec := NewDiffRecorder(ws)
+	as1 = rec.GetAccountState(id1)
+	as1.SetBalance(big.NewInt(70))
+	_, err = as1.SetValue([]byte("k1"), []byte("v2"))
+	assert.NoError(t, err)
+	_, err = as1.SetValue([]byte("k2"), []byte("v3"))
+	assert.NoError(t, err)
+	_, err = as1.DeleteValue([]byte("k2"))
+	assert.NoError(t, err)
+
+	as2 := rec.GetAccountState(id2)
+	as2.SetBalance(big.NewInt(30))
+	_, err = as2.SetValue([]byte("k3"), []byte("v4"))
+	assert.NoError(t, err)
+
+	// writing the same value is reported without balance change
+	_, err = rec.GetAccountState(id4).SetValue([]byte("k4"), []byte("v5"))
+	assert.NoError(t, err)
+
+	// read only access isn't a change
+	rec.GetAccountState(common.MustNewAddressFromString("hx0000000000000000000000000000000000000003").ID()).GetBalance()
+
+	diff, err := rec.Diff(base)
+	assert.NoError(t, err)
+	assert.Len(t, diff.Accounts, 3)
+
+	ad1 := diff.Accounts[0]
+	assert.Equal(t, id1, ad1.Address.ID())
+	assert.Equal(t, int64(100), ad1.Balance[0].Int64())
+	assert.Equal(t, int64(70), ad1.Balance[1].Int64())
+	assert.Len(t, ad1.Storage, 2)
+	assert.Equal(t, &StorageDiff{
+		Key:    []byte("k1"),
+		Before: []byte("v1"),
+		After:  []byte("v2"),
+	}, ad1.Storage[0])
+	// k2 is written, but it doesn't exist before and after.
+	assert.Equal(t, &StorageDiff{Key: []byte("k2")}, ad1.Storage[1])
+
+	ad2 := diff.Accounts[1]
+	assert.Equal(t, id2, ad2.Address.ID())
+	assert.Equal(t, int64(0), ad2.Balance[0].Int64())
+	assert.Equal(t, int64(30), ad2.Balance[1].Int64())
+	assert.Len(t, ad2.Storage, 1)
+	assert.Nil(t, ad2.Storage[0].Before)
+
+	ad4 := diff.Accounts[2]
+	assert.Equal(t, id4, ad4.Address.ID())
+	assert.Equal(t, 0, ad4.Balance[0].Cmp(ad4.Balance[1]))
+	assert.Equal(t, []*StorageDiff{{
+		Key:    []byte("k4"),
+		Before: []byte("v5"),
+		After:  []byte("v5"),
+	}}, ad4.Storage)
+
+	jso, err := diff.ToJSON(0)
+	assert.NoError(t, err)
+	bs, err := json.Marshal(jso)
+	assert.NoError(t, err)
+	var res struct {
+		Accounts []struct {
+			Balance *struct {
+				Before string `json:"before"`
+				After  string `json:"after"`
+			} `json:"balance"`
+			Storage []struct {
+				Key    string  `json:"key"`
+				Before *string `json:"before"`
+				After  *string `json:"after"`
+			} `json:"storage"`
+		} `json:"accounts"`
+	}
+	assert.NoError(t, json.Unmarshal(bs, &res))
+	assert.Len(t, res.Accounts, 3)
+	assert.Equal(t, "0x64", res.Accounts[0].Balance.Before)
+	assert.Equal(t, "0x46", res.Accounts[0].Balance.After)
+	assert.Nil(t, res.Accounts[1].Storage[0].Before)
+	assert.Equal(t, "0x7634", *res.Accounts[1].Storage[0].After)
+	assert.Nil(t, res.Accounts[2].Balance)
+}
@@ -35,6 +35,12 @@ func opTypeToString(o module.OpType) string {
 	return opTypeNames[o]
 }
 
+// OpTypeNames returns names of all operation types in the order of
+// module.OpType.
+func OpTypeNames() []string {
+	return append([]string(nil), opTypeNames...)
+}
+
 type operation struct {
 	depth  int
 	opType module.OpType
@@ -71,13 +77,25 @@ func (c *callFrame) mergeOpsToParent() {
 	parent.ops = append(parent.ops, c.ops...)
 }
 
-func (c *callFrame) toJSON() []map[string]interface{} {
-	size := len(c.ops)
-	if size > 0 {
-		jso := make([]map[string]interface{}, size)
-		for i, op := range c.ops {
-			jso[i] = op.toJSON()
+// touches returns whether the operation moves the balance of one of
+// the addresses.
+func (o *operation) touches(addrs []module.Address) bool {
+	for _, addr := range addrs {
+		if (o.from != nil && o.from.Equal(addr)) || (o.to != nil && o.to.Equal(addr)) {
+			return true
 		}
+	}
+	return false
+}
+
+func (c *callFrame) toJSON(addrs []module.Address) []map[string]interface{} {
+	jso := make([]map[string]interface{}, 0, len(c.ops))
+	for _, op := range c.ops {
+		if len(addrs) == 0 || op.touches(addrs) {
+			jso = append(jso, op.toJSON())
+		}
+	}
+	if len(jso) > 0 {
 		return jso
 	}
 	return nil
@@ -90,8 +108,8 @@ type transaction struct {
 	*callFrame
 }
 
-func (t *transaction) toJSON() map[string]interface{} {
-	ops := t.callFrame.toJSON()
+func (t *transaction) toJSON(addrs []module.Address) map[string]interface{} {
+	ops := t.callFrame.toJSON(addrs)
 	if ops != nil {
 		prefix := "0x"
 		if t.isBlockTx {
@@ -217,12 +235,19 @@ func (bt *BalanceTracer) OnBalanceChange(opType module.OpType, from, to module.A
 }
 
 func (bt *BalanceTracer) ToJSON(height int64) interface{} {
+	return bt.ToJSONForAddresses(height, nil)
+}
+
+// ToJSONForAddresses returns balance changes like ToJSON, but it includes
+// only operations moving the balance of one of the addresses. Empty
+// addresses mean all operations.
+func (bt *BalanceTracer) ToJSONForAddresses(height int64, addrs []module.Address) []interface{} {
 	jso := make([]interface{}, 0, len(bt.txs))
 	for _, tx := range bt.txs {
 		if bt.thr != nil {
 			tx.hash = bt.thr(height, tx.hash)
 		}
-		if txJso := tx.toJSON(); txJso != nil {
+		if txJso := tx.toJSON(addrs); txJso != nil {
 			jso = append(jso, txJso)
 		}
 	}
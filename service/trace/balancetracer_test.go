@@ -207,6 +207,46 @@ func TestEmpyBalanceTracer_OnTransactionReset(t *testing.T) {
 	assert.Equal(t, 0, getCurrentFrameOpsLength(bt))
 }
 
+func TestBalanceTracer_ToJSONForAddresses(t *testing.T) {
+	treasury := common.MustNewAddressFromString("hx10")
+	from := common.MustNewAddressFromString("hx11")
+	to := common.MustNewAddressFromString("hx22")
+	score := common.MustNewAddressFromString("cx33")
+
+	bt := NewBalanceTracer(10, nil)
+
+	txHash0 := newRandomHash(32)
+	txHash1 := newRandomHash(32)
+
+	assert.NoError(t, bt.OnTransactionStart(0, txHash0, false))
+	assert.NoError(t, bt.OnBalanceChange(module.Transfer, from, to, big.NewInt(1000)))
+	assert.NoError(t, bt.OnBalanceChange(module.Claim, treasury, from, big.NewInt(2000)))
+	assert.NoError(t, bt.OnTransactionEnd(0, txHash0))
+
+	assert.NoError(t, bt.OnTransactionStart(1, txHash1, false))
+	assert.NoError(t, bt.OnBalanceChange(module.Transfer, from, score, big.NewInt(3000)))
+	assert.NoError(t, bt.OnTransactionEnd(1, txHash1))
+
+	jso := bt.ToJSONForAddresses(1, nil)
+	assert.Len(t, jso, 2)
+
+	jso = bt.ToJSONForAddresses(1, []module.Address{to})
+	assert.Len(t, jso, 1)
+	tx := jso[0].(map[string]interface{})
+	assert.Equal(t, "0x0", tx["txIndex"])
+	assert.Len(t, tx["ops"], 1)
+
+	jso = bt.ToJSONForAddresses(1, []module.Address{score, treasury})
+	assert.Len(t, jso, 2)
+	assert.Len(t, jso[0].(map[string]interface{})["ops"], 1)
+	assert.Len(t, jso[1].(map[string]interface{})["ops"], 1)
+
+	jso = bt.ToJSONForAddresses(1, []module.Address{
+		common.MustNewAddressFromString("hx44"),
+	})
+	assert.Len(t, jso, 0)
+}
+
 func TestOpTypeToString(t *testing.T) {
 	type data struct {
 		opType module.OpType
@@ -0,0 +1,204 @@
+package trace
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+type callNode struct {
+	parent   *callNode
+	call     *module.FrameCall
+	success  bool
+	stepUsed *big.Int
+	result   interface{}
+	reason   error
+	events   []module.EventLog
+	calls    []*callNode
+}
+
+func (n *callNode) toJSON() map[string]interface{} {
+	jso := make(map[string]interface{})
+	if c := n.call; c != nil {
+		jso["from"] = c.From
+		jso["to"] = c.To
+		if c.Value != nil && c.Value.Sign() != 0 {
+			jso["value"] = &common.HexInt{Int: *c.Value}
+		}
+		if c.Method != "" {
+			jso["method"] = c.Method
+		}
+		if c.Params != nil {
+			jso["params"] = c.Params
+		}
+	}
+	if n.stepUsed != nil {
+		jso["stepUsed"] = &common.HexInt{Int: *n.stepUsed}
+	}
+	if n.success {
+		jso["status"] = "0x1"
+		if n.result != nil {
+			jso["result"] = n.result
+		}
+	} else {
+		jso["status"] = "0x0"
+		if n.reason != nil {
+			status, _ := scoreresult.StatusOf(n.reason)
+			jso["failure"] = map[string]interface{}{
+				"code":    status,
+				"message": n.reason.Error(),
+			}
+		}
+	}
+	if len(n.events) > 0 {
+		jso["eventLogs"] = n.events
+	}
+	if len(n.calls) > 0 {
+		calls := make([]interface{}, len(n.calls))
+		for i, c := range n.calls {
+			calls[i] = c.toJSON()
+		}
+		jso["calls"] = calls
+	}
+	return jso
+}
+
+type callTreeTx struct {
+	index     int
+	hash      []byte
+	isBlockTx bool
+	root      *callNode
+}
+
+func (t *callTreeTx) toJSON() map[string]interface{} {
+	prefix := "0x"
+	if t.isBlockTx {
+		prefix = "bx"
+	}
+	calls := make([]interface{}, len(t.root.calls))
+	for i, c := range t.root.calls {
+		calls[i] = c.toJSON()
+	}
+	return map[string]interface{}{
+		"txIndex": fmt.Sprintf("%#x", t.index),
+		"txHash":  prefix + hex.EncodeToString(t.hash),
+		"calls":   calls,
+	}
+}
+
+// CallTracer builds call trees of transactions for TraceModeCallTree.
+// Each frame has the call, the result and the events emitted in the
+// frame. Events of failed frames are kept in the tree although they are
+// not in the receipt.
+type CallTracer struct {
+	txs []*callTreeTx
+	cur *callNode
+}
+
+func (ct *CallTracer) OnTransactionStart(txIndex int, txHash []byte, isBlockTx bool) error {
+	if ct.cur != nil {
+		return errors.InvalidStateError.Errorf(
+			"Invalid curFrame: txIndex=%d txHash=%#x", txIndex, txHash)
+	}
+	root := &callNode{}
+	ct.txs = append(ct.txs, &callTreeTx{
+		index:     txIndex,
+		hash:      txHash,
+		isBlockTx: isBlockTx,
+		root:      root,
+	})
+	ct.cur = root
+	return nil
+}
+
+func (ct *CallTracer) OnTransactionReset() error {
+	if len(ct.txs) == 0 {
+		return errors.InvalidStateError.New("No transaction")
+	}
+	root := &callNode{}
+	ct.txs[len(ct.txs)-1].root = root
+	ct.cur = root
+	return nil
+}
+
+func (ct *CallTracer) OnTransactionEnd(txIndex int, txHash []byte) error {
+	if ct.cur == nil || ct.cur.parent != nil {
+		return errors.InvalidStateError.Errorf(
+			"Invalid curFrame: txIndex=%d txHash=%#x", txIndex, txHash)
+	}
+	ct.cur = nil
+	return nil
+}
+
+func (ct *CallTracer) OnFrameEnter() error {
+	if ct.cur == nil {
+		return errors.InvalidStateError.New("CallTracer Not Ready")
+	}
+	n := &callNode{parent: ct.cur}
+	ct.cur.calls = append(ct.cur.calls, n)
+	ct.cur = n
+	return nil
+}
+
+func (ct *CallTracer) OnFrameExit(success bool) error {
+	if ct.cur == nil || ct.cur.parent == nil {
+		return errors.InvalidStateError.New("curFrame Not Ready")
+	}
+	ct.cur.success = success
+	ct.cur = ct.cur.parent
+	return nil
+}
+
+func (ct *CallTracer) currentFrame() (*callNode, error) {
+	if ct.cur == nil || ct.cur.parent == nil {
+		return nil, errors.InvalidStateError.New("NoFrame")
+	}
+	return ct.cur, nil
+}
+
+func (ct *CallTracer) OnFrameCall(call *module.FrameCall) error {
+	n, err := ct.currentFrame()
+	if err != nil {
+		return err
+	}
+	n.call = call
+	return nil
+}
+
+func (ct *CallTracer) OnFrameResult(stepUsed *big.Int, result interface{}, reason error) error {
+	n, err := ct.currentFrame()
+	if err != nil {
+		return err
+	}
+	n.stepUsed = new(big.Int).Set(stepUsed)
+	n.result = result
+	n.reason = reason
+	return nil
+}
+
+func (ct *CallTracer) OnFrameEvent(addr module.Address, indexed, data [][]byte) error {
+	n, err := ct.currentFrame()
+	if err != nil {
+		return err
+	}
+	n.events = append(n.events, txresult.NewEventLog(addr, indexed, data))
+	return nil
+}
+
+func (ct *CallTracer) ToJSON() interface{} {
+	jso := make([]interface{}, 0, len(ct.txs))
+	for _, tx := range ct.txs {
+		jso = append(jso, tx.toJSON())
+	}
+	return jso
+}
+
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
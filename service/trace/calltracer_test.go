@@ -0,0 +1,106 @@
+package trace
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+func TestCallTracer(t *testing.T) {
+	ct := NewCallTracer()
+
+	eoa := common.MustNewAddressFromString("hx100")
+	score1 := common.MustNewAddressFromString("cx101")
+	score2 := common.MustNewAddressFromString("cx102")
+
+	txHash := newRandomHash(32)
+	assert.NoError(t, ct.OnTransactionStart(0, txHash, false))
+
+	// frames can't be described before entering
+	assert.Error(t, ct.OnFrameCall(&module.FrameCall{}))
+
+	assert.NoError(t, ct.OnFrameEnter())
+	assert.NoError(t, ct.OnFrameCall(&module.FrameCall{
+		From:   eoa,
+		To:     score1,
+		Value:  big.NewInt(10),
+		Method: "transfer",
+		Params: json.RawMessage(`{"to":"cx102"}`),
+	}))
+	assert.NoError(t, ct.OnFrameEvent(score1,
+		[][]byte{[]byte("Called(Address)"), eoa.Bytes()}, nil))
+
+	assert.NoError(t, ct.OnFrameEnter())
+	assert.NoError(t, ct.OnFrameCall(&module.FrameCall{
+		From:   score1,
+		To:     score2,
+		Method: "fail",
+	}))
+	assert.NoError(t, ct.OnFrameResult(big.NewInt(100), nil,
+		scoreresult.UnknownFailureError.New("reverted")))
+	assert.NoError(t, ct.OnFrameExit(false))
+
+	assert.NoError(t, ct.OnFrameResult(big.NewInt(300), "0x1", nil))
+	assert.NoError(t, ct.OnFrameExit(true))
+
+	// exit without frame
+	assert.Error(t, ct.OnFrameExit(true))
+
+	assert.NoError(t, ct.OnTransactionEnd(0, txHash))
+
+	bs, err := json.Marshal(ct.ToJSON())
+	assert.NoError(t, err)
+
+	var txs []struct {
+		TxIndex string `json:"txIndex"`
+		Calls   []struct {
+			From      string            `json:"from"`
+			To        string            `json:"to"`
+			Value     string            `json:"value"`
+			Method    string            `json:"method"`
+			Params    map[string]string `json:"params"`
+			StepUsed  string            `json:"stepUsed"`
+			Status    string            `json:"status"`
+			Result    string            `json:"result"`
+			EventLogs []struct {
+				ScoreAddress string   `json:"scoreAddress"`
+				Indexed      []string `json:"indexed"`
+			} `json:"eventLogs"`
+			Calls []struct {
+				To      string `json:"to"`
+				Status  string `json:"status"`
+				Failure *struct {
+					Message string `json:"message"`
+				} `json:"failure"`
+			} `json:"calls"`
+		} `json:"calls"`
+	}
+	assert.NoError(t, json.Unmarshal(bs, &txs))
+	assert.Len(t, txs, 1)
+	assert.Equal(t, "0x0", txs[0].TxIndex)
+	assert.Len(t, txs[0].Calls, 1)
+
+	c := txs[0].Calls[0]
+	assert.Equal(t, eoa.String(), c.From)
+	assert.Equal(t, score1.String(), c.To)
+	assert.Equal(t, "0xa", c.Value)
+	assert.Equal(t, "transfer", c.Method)
+	assert.Equal(t, "cx102", c.Params["to"])
+	assert.Equal(t, "0x12c", c.StepUsed)
+	assert.Equal(t, "0x1", c.Status)
+	assert.Equal(t, "0x1", c.Result)
+	assert.Len(t, c.EventLogs, 1)
+	assert.Equal(t, score1.String(), c.EventLogs[0].ScoreAddress)
+	assert.Equal(t, []string{"Called(Address)", eoa.String()}, c.EventLogs[0].Indexed)
+
+	assert.Len(t, c.Calls, 1)
+	assert.Equal(t, score2.String(), c.Calls[0].To)
+	assert.Equal(t, "0x0", c.Calls[0].Status)
+	assert.Equal(t, "reverted", c.Calls[0].Failure.Message)
+}
@@ -209,6 +209,44 @@ func (l *Logger) OnFrameExit(success bool, stepUsed *big.Int) {
 	}
 }
 
+func (l *Logger) callTreeCallback() module.CallTreeCallback {
+	if l.TraceMode() != module.TraceModeCallTree {
+		return nil
+	}
+	ct, _ := l.cb.(module.CallTreeCallback)
+	return ct
+}
+
+// CallTreeEnabled returns whether it needs details of frames for
+// TraceModeCallTree.
+func (l *Logger) CallTreeEnabled() bool {
+	return l.callTreeCallback() != nil
+}
+
+func (l *Logger) OnFrameCall(call *module.FrameCall) {
+	if ct := l.callTreeCallback(); ct != nil {
+		if err := ct.OnFrameCall(call); err != nil {
+			l.Warnf("OnFrameCall() error: err=%#v", err)
+		}
+	}
+}
+
+func (l *Logger) OnFrameResult(stepUsed *big.Int, result interface{}, reason error) {
+	if ct := l.callTreeCallback(); ct != nil {
+		if err := ct.OnFrameResult(stepUsed, result, reason); err != nil {
+			l.Warnf("OnFrameResult() error: err=%#v", err)
+		}
+	}
+}
+
+func (l *Logger) OnFrameEvent(addr module.Address, indexed, data [][]byte) {
+	if ct := l.callTreeCallback(); ct != nil {
+		if err := ct.OnFrameEvent(addr, indexed, data); err != nil {
+			l.Warnf("OnFrameEvent() error: err=%#v", err)
+		}
+	}
+}
+
 func (l *Logger) OnBalanceChange(opType module.OpType, from, to module.Address, amount *big.Int) {
 	if l.TraceMode() == module.TraceModeNone {
 		return
@@ -357,6 +357,10 @@ func (g *genesisV3) Nonce() *big.Int {
 	return nil
 }
 
+func (g *genesisV3) StepLimit() *big.Int {
+	return nil
+}
+
 func (g *genesisV3) To() module.Address {
 	if g.CID() == ICONMainNetCID {
 		return state.ZeroAddress
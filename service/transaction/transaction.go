@@ -24,6 +24,7 @@ type Transaction interface {
 	GetHandler(cm contract.ContractManager) (Handler, error)
 	Timestamp() int64
 	Nonce() *big.Int
+	StepLimit() *big.Int
 	To() module.Address
 	IsSkippable() bool
 }
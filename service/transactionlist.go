@@ -1,6 +1,8 @@
 package service
 
 import (
+	"container/heap"
+	"math/big"
 	"time"
 
 	"github.com/icon-project/goloop/module"
@@ -17,17 +19,34 @@ func indexAndBucketKeyFromKey(k string) (int, string) {
 
 type transactionList struct {
 	size      int
+	seq       uint64
 	listFront *txElement
 	listBack  *txElement
 
-	idMap        []map[string]*txElement
-	srcMapToLast []map[string]*txElement
+	idMap  []map[string]*txElement
+	srcMap []map[string]*txSender
+
+	steps   stepHeap
+	senders senderHeap
+}
+
+// txSender keeps the last element and the number of elements of a sender.
+type txSender struct {
+	last  *txElement
+	count int
+	index int
 }
 
 type txElement struct {
 	value transaction.Transaction
 	ts    int64
 	err   error
+	seq   uint64
+
+	stepLimit *big.Int
+	stepIndex int
+
+	received time.Time
 
 	list               *transactionList
 	listNext, listPrev *txElement
@@ -118,21 +137,26 @@ func (l *transactionList) Add(tx transaction.Transaction, ts bool) error {
 		return ErrDuplicateTransaction
 	}
 
+	l.seq += 1
 	e := &txElement{
-		value: tx,
-		list:  l,
+		value:     tx,
+		list:      l,
+		seq:       l.seq,
+		stepLimit: stepLimitOf(tx),
+		received:  time.Now(),
 	}
 	if ts {
-		e.ts = time.Now().UnixNano()
+		e.ts = e.received.UnixNano()
 	}
 
 	l.idMap[tidBk][tidSlot] = e
 
 	uidBk, uidSlot := indexAndBucketKeyFromKey(string(tx.From().ID()))
-	t2, ok := l.srcMapToLast[uidBk][uidSlot]
+	sender, ok := l.srcMap[uidBk][uidSlot]
 
 	var insertPos *txElement
 	if ok {
+		t2 := sender.last
 		ts := tx.Timestamp()
 		if t2.value.Timestamp() > ts {
 			insertPos = t2
@@ -150,11 +174,18 @@ func (l *transactionList) Add(tx transaction.Transaction, ts bool) error {
 			e.srcNext = insertPos
 			insertPos.srcPrev = e
 		} else {
-			l.srcMapToLast[uidBk][uidSlot] = e
+			e.srcPrev = t2
+			t2.srcNext = e
+			sender.last = e
 		}
+		sender.count += 1
+		heap.Fix(&l.senders, sender.index)
 	} else {
-		l.srcMapToLast[uidBk][uidSlot] = e
+		sender = &txSender{last: e, count: 1}
+		l.srcMap[uidBk][uidSlot] = sender
+		heap.Push(&l.senders, sender)
 	}
+	heap.Push(&l.steps, e)
 
 	if insertPos != nil {
 		if insertPos.listPrev != nil {
@@ -208,14 +239,18 @@ func (l *transactionList) Remove(t *txElement) bool {
 	t.listPrev = nil
 
 	uidBk, uidSlot := indexAndBucketKeyFromKey(string(t.value.From().ID()))
-	t2 := l.srcMapToLast[uidBk][uidSlot]
-	if t2 == t {
-		if t.srcPrev != nil {
-			l.srcMapToLast[uidBk][uidSlot] = t.srcPrev
-		} else {
-			delete(l.srcMapToLast[uidBk], uidSlot)
-		}
+	sender := l.srcMap[uidBk][uidSlot]
+	if sender.last == t {
+		sender.last = t.srcPrev
+	}
+	sender.count -= 1
+	if sender.count > 0 {
+		heap.Fix(&l.senders, sender.index)
+	} else {
+		heap.Remove(&l.senders, sender.index)
+		delete(l.srcMap[uidBk], uidSlot)
 	}
+	heap.Remove(&l.steps, t.stepIndex)
 	if t.srcPrev != nil {
 		t.srcPrev.srcNext = t.srcNext
 	}
@@ -247,6 +282,68 @@ func (l *transactionList) HasTx(id []byte) bool {
 	return ok
 }
 
+// FindByNonce returns the element from the same sender with the same
+// explicit nonce. It returns nil if the transaction has no nonce or there
+// is no such element.
+func (l *transactionList) FindByNonce(tx transaction.Transaction) *txElement {
+	nonce := tx.Nonce()
+	if nonce == nil {
+		return nil
+	}
+	uidBk, uidSlot := indexAndBucketKeyFromKey(string(tx.From().ID()))
+	sender, ok := l.srcMap[uidBk][uidSlot]
+	if !ok {
+		return nil
+	}
+	for e := sender.last; e != nil; e = e.srcPrev {
+		if n := e.value.Nonce(); n != nil && n.Cmp(nonce) == 0 {
+			return e
+		}
+	}
+	return nil
+}
+
+// FirstOf returns the first element of the sender.
+func (l *transactionList) FirstOf(from module.Address) *txElement {
+	uidBk, uidSlot := indexAndBucketKeyFromKey(string(from.ID()))
+	sender, ok := l.srcMap[uidBk][uidSlot]
+	if !ok {
+		return nil
+	}
+	e := sender.last
+	for e.srcPrev != nil {
+		e = e.srcPrev
+	}
+	return e
+}
+
+// CountOf returns the number of transactions from the sender.
+func (l *transactionList) CountOf(from module.Address) int {
+	uidBk, uidSlot := indexAndBucketKeyFromKey(string(from.ID()))
+	if sender, ok := l.srcMap[uidBk][uidSlot]; ok {
+		return sender.count
+	}
+	return 0
+}
+
+// LowestStepLimit returns the latest element among the ones with the lowest
+// step limit.
+func (l *transactionList) LowestStepLimit() *txElement {
+	if len(l.steps) == 0 {
+		return nil
+	}
+	return l.steps[0]
+}
+
+// LargestSender returns the last element of the sender who has the most
+// transactions in the list.
+func (l *transactionList) LargestSender() *txElement {
+	if len(l.senders) == 0 {
+		return nil
+	}
+	return l.senders[0].last
+}
+
 func (l *transactionList) GetBloom() *TxBloom {
 	if l.listFront == nil {
 		return &TxBloom{}
@@ -258,10 +355,180 @@ func newTransactionList() *transactionList {
 	l := new(transactionList)
 
 	l.idMap = make([]map[string]*txElement, txBucketCount)
-	l.srcMapToLast = make([]map[string]*txElement, txBucketCount)
+	l.srcMap = make([]map[string]*txSender, txBucketCount)
 	for i := 0; i < txBucketCount; i++ {
 		l.idMap[i] = make(map[string]*txElement)
-		l.srcMapToLast[i] = make(map[string]*txElement)
+		l.srcMap[i] = make(map[string]*txSender)
 	}
 	return l
 }
+
+type txIterator interface {
+	Next() *txElement
+}
+
+type listIterator struct {
+	next *txElement
+}
+
+func (i *listIterator) Next() *txElement {
+	e := i.next
+	if e != nil {
+		i.next = e.listNext
+	}
+	return e
+}
+
+// Iterator returns elements in the order of the list.
+func (l *transactionList) Iterator() txIterator {
+	return &listIterator{next: l.listFront}
+}
+
+// TransactionFee returns the fee offered by the transaction
+// (step limit at the step price).
+func TransactionFee(tx transaction.Transaction, price *big.Int) *big.Int {
+	limit := tx.StepLimit()
+	if limit == nil || price == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Mul(limit, price)
+}
+
+type feeEntry struct {
+	e   *txElement
+	fee *big.Int
+}
+
+type feeHeap []feeEntry
+
+func (h feeHeap) Len() int {
+	return len(h)
+}
+
+func (h feeHeap) Less(i, j int) bool {
+	if c := h[i].fee.Cmp(h[j].fee); c != 0 {
+		return c > 0
+	}
+	return h[i].e.seq < h[j].e.seq
+}
+
+func (h feeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *feeHeap) Push(x interface{}) {
+	*h = append(*h, x.(feeEntry))
+}
+
+func (h *feeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+type feeIterator struct {
+	price *big.Int
+	heads feeHeap
+}
+
+func (i *feeIterator) entryOf(e *txElement) feeEntry {
+	return feeEntry{e, TransactionFee(e.value, i.price)}
+}
+
+func (i *feeIterator) Next() *txElement {
+	if len(i.heads) == 0 {
+		return nil
+	}
+	head := heap.Pop(&i.heads).(feeEntry)
+	if next := head.e.srcNext; next != nil {
+		heap.Push(&i.heads, i.entryOf(next))
+	}
+	return head.e
+}
+
+// FeeIterator returns elements in descending order of the offered fee.
+// Transactions from the same sender are returned in the order of the list.
+// Transactions with the same fee are returned in the order of arrival.
+func (l *transactionList) FeeIterator(price *big.Int) txIterator {
+	i := &feeIterator{price: price}
+	for e := l.listFront; e != nil; e = e.listNext {
+		if e.srcPrev == nil {
+			i.heads = append(i.heads, i.entryOf(e))
+		}
+	}
+	heap.Init(&i.heads)
+	return i
+}
+
+// stepHeap keeps elements in ascending order of the step limit.
+// Elements with the same step limit are ordered from the latest one.
+type stepHeap []*txElement
+
+func (h stepHeap) Len() int {
+	return len(h)
+}
+
+func (h stepHeap) Less(i, j int) bool {
+	if c := h[i].stepLimit.Cmp(h[j].stepLimit); c != 0 {
+		return c < 0
+	}
+	return h[i].seq > h[j].seq
+}
+
+func (h stepHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].stepIndex = i
+	h[j].stepIndex = j
+}
+
+func (h *stepHeap) Push(x interface{}) {
+	e := x.(*txElement)
+	e.stepIndex = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *stepHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[0 : n-1]
+	return x
+}
+
+// senderHeap keeps senders in descending order of the number of elements.
+type senderHeap []*txSender
+
+func (h senderHeap) Len() int {
+	return len(h)
+}
+
+func (h senderHeap) Less(i, j int) bool {
+	if h[i].count != h[j].count {
+		return h[i].count > h[j].count
+	}
+	return h[i].last.seq < h[j].last.seq
+}
+
+func (h senderHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *senderHeap) Push(x interface{}) {
+	s := x.(*txSender)
+	s.index = len(*h)
+	*h = append(*h, s)
+}
+
+func (h *senderHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[0 : n-1]
+	return x
+}
@@ -16,6 +16,8 @@ type mockTransaction struct {
 	id        []byte
 	from      module.Address
 	timeStamp int64
+	nonce     *big.Int
+	stepLimit *big.Int
 }
 
 func (*mockTransaction) Group() module.TransactionGroup {
@@ -62,8 +64,12 @@ func (t *mockTransaction) Timestamp() int64 {
 	return t.timeStamp
 }
 
-func (*mockTransaction) Nonce() *big.Int {
-	panic("implement me")
+func (t *mockTransaction) Nonce() *big.Int {
+	return t.nonce
+}
+
+func (t *mockTransaction) StepLimit() *big.Int {
+	return t.stepLimit
 }
 
 func (t *mockTransaction) To() module.Address {
@@ -212,3 +218,135 @@ func TestTransactionList_TestSort(t *testing.T) {
 		t.Errorf("First item should be tx4 but tx=%x", tx.ID())
 	}
 }
+
+func TestTransactionList_FeeIterator(t *testing.T) {
+	from1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	from2 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	from3 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000003")
+	tx1 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x01}, from1, 1)
+	tx1.stepLimit = big.NewInt(100)
+	tx2 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x02}, from1, 2)
+	tx2.stepLimit = big.NewInt(1000)
+	tx3 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x03}, from2, 1)
+	tx3.stepLimit = big.NewInt(500)
+	tx4 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x04}, from3, 1)
+	tx4.stepLimit = big.NewInt(500)
+
+	l := newTransactionList()
+	l.Add(tx1, false)
+	l.Add(tx2, false)
+	l.Add(tx3, false)
+	l.Add(tx4, false)
+
+	// tx2 has the highest fee, but it should follow tx1 from the same sender.
+	// tx3 and tx4 have the same fee, so the order of arrival is used.
+	expected := []*mockTransaction{tx3, tx4, tx1, tx2}
+	iter := l.FeeIterator(big.NewInt(10))
+	for idx, exp := range expected {
+		e := iter.Next()
+		if e == nil {
+			t.Fatalf("Iterator returns nil at %d", idx)
+		}
+		if tx := e.Value(); tx != exp {
+			t.Errorf("Item at %d should be %x but tx=%x", idx, exp.ID(), tx.ID())
+		}
+	}
+	if e := iter.Next(); e != nil {
+		t.Errorf("Iterator should end but tx=%x", e.Value().ID())
+	}
+}
+
+func TestTransactionList_FindByNonce(t *testing.T) {
+	from1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	from2 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	tx1 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x01}, from1, 1)
+	tx1.nonce = big.NewInt(1)
+	tx2 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x02}, from1, 2)
+	tx2.nonce = big.NewInt(2)
+	tx3 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x03}, from2, 3)
+
+	l := newTransactionList()
+	l.Add(tx1, false)
+	l.Add(tx2, false)
+
+	if e := l.FindByNonce(tx3); e != nil {
+		t.Errorf("It should not find a transaction without nonce")
+	}
+	tx3.nonce = big.NewInt(1)
+	if e := l.FindByNonce(tx3); e != nil {
+		t.Errorf("It should not find a transaction of another sender")
+	}
+	tx3.from = from1
+	if e := l.FindByNonce(tx3); e == nil || e.Value() != tx1 {
+		t.Errorf("It should find tx1 with the same nonce")
+	}
+}
+
+func TestTransactionList_EvictionIndex(t *testing.T) {
+	from1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	from2 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	tx1 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x01}, from1, 1)
+	tx1.stepLimit = big.NewInt(100)
+	tx2 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x02}, from2, 1)
+	tx2.stepLimit = big.NewInt(200)
+	tx3 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x03}, from2, 2)
+	tx3.stepLimit = big.NewInt(100)
+	tx4 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x04}, from1, 2)
+	tx4.stepLimit = big.NewInt(300)
+	tx5 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x05}, from1, 3)
+	tx5.stepLimit = big.NewInt(300)
+
+	l := newTransactionList()
+	if e := l.LowestStepLimit(); e != nil {
+		t.Errorf("Empty list should return nil for the lowest step limit")
+	}
+	if e := l.LargestSender(); e != nil {
+		t.Errorf("Empty list should return nil for the largest sender")
+	}
+
+	check := func(lowest, largest *mockTransaction, cnt1, cnt2 int) {
+		t.Helper()
+		if e := l.LowestStepLimit(); e == nil || e.Value() != lowest {
+			t.Errorf("Lowest step limit should be %x", lowest.ID())
+		}
+		if e := l.LargestSender(); e == nil || e.Value() != largest {
+			t.Errorf("Largest sender should end with %x", largest.ID())
+		}
+		if cnt := l.CountOf(from1); cnt != cnt1 {
+			t.Errorf("Count of from1 should be %d but cnt=%d", cnt1, cnt)
+		}
+		if cnt := l.CountOf(from2); cnt != cnt2 {
+			t.Errorf("Count of from2 should be %d but cnt=%d", cnt2, cnt)
+		}
+	}
+
+	l.Add(tx1, false)
+	l.Add(tx2, false)
+	l.Add(tx3, false)
+	// tx3 is the latest among the ones with the lowest step limit
+	check(tx3, tx3, 1, 2)
+
+	l.Add(tx4, false)
+	l.Add(tx5, false)
+	check(tx3, tx5, 3, 2)
+
+	l.RemoveTx(tx3)
+	check(tx1, tx5, 3, 1)
+
+	l.RemoveTx(tx5)
+	l.RemoveTx(tx1)
+	// the sender with the older last element comes first on the tie
+	check(tx2, tx2, 1, 1)
+
+	l.RemoveTx(tx2)
+	l.RemoveTx(tx4)
+	if e := l.LowestStepLimit(); e != nil {
+		t.Errorf("Empty list should return nil for the lowest step limit")
+	}
+	if e := l.LargestSender(); e != nil {
+		t.Errorf("Empty list should return nil for the largest sender")
+	}
+	if cnt := l.CountOf(from1); cnt != 0 {
+		t.Errorf("Count of from1 should be 0 but cnt=%d", cnt)
+	}
+}
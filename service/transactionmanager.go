@@ -28,7 +28,12 @@ type TransactionManager struct {
 
 	callback func()
 
-	txWaiters map[hashValue][]chan<- interface{}
+	txWaiters   map[hashValue][]chan<- interface{}
+	subscribers map[*txSubscriber]struct{}
+}
+
+type txSubscriber struct {
+	ch chan module.TransactionPoolEvent
 }
 
 func (m *TransactionManager) getTxPool(g module.TransactionGroup) *TransactionPool {
@@ -132,18 +137,85 @@ func (m *TransactionManager) removeWaiters(id []byte) []chan<- interface{} {
 type TxDrop struct {
 	ID  []byte
 	Err error
+	Tx  transaction.Transaction
 }
 
 func (m *TransactionManager) OnTxDrops(drops []TxDrop) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	m.onTxDropsInLock(drops)
+}
+
+func (m *TransactionManager) onTxDropsInLock(drops []TxDrop) {
 	for _, drop := range drops {
 		ws := m.removeWaitersInLock(drop.ID)
 		for _, c := range ws {
 			c <- drop.Err
 			close(c)
 		}
+		if drop.Tx != nil {
+			m.notifyInLock(module.TransactionPoolEvent{
+				Type:  module.TransactionDropped,
+				Tx:    drop.Tx,
+				Error: drop.Err,
+			})
+		}
+	}
+}
+
+// Subscribe registers a subscriber for events of the pools. It returns
+// the channel for the events and the function to cancel the subscription.
+// If the subscriber has size pending events, then it's cancelled and the
+// channel is closed.
+func (m *TransactionManager) Subscribe(size int) (<-chan module.TransactionPoolEvent, func()) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	s := &txSubscriber{
+		ch: make(chan module.TransactionPoolEvent, size),
+	}
+	m.subscribers[s] = struct{}{}
+	return s.ch, func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.unsubscribeInLock(s)
+	}
+}
+
+func (m *TransactionManager) unsubscribeInLock(s *txSubscriber) {
+	if _, ok := m.subscribers[s]; ok {
+		delete(m.subscribers, s)
+		close(s.ch)
+	}
+}
+
+func (m *TransactionManager) notifyInLock(ev module.TransactionPoolEvent) {
+	if len(m.subscribers) == 0 {
+		return
+	}
+	ev.JSON = jsonOnce(ev.Tx)
+	for s := range m.subscribers {
+		select {
+		case s.ch <- ev:
+		default:
+			m.log.Warnf("Drop slow subscriber of the pool pending=%d", len(s.ch))
+			m.unsubscribeInLock(s)
+		}
+	}
+}
+
+// jsonOnce returns the function encoding the transaction on the first call,
+// then it returns the same object for following calls.
+func jsonOnce(tx module.Transaction) func() (interface{}, error) {
+	var once sync.Once
+	var js interface{}
+	var err error
+	return func() (interface{}, error) {
+		once.Do(func() {
+			js, err = tx.ToJSON(module.JSONVersionLast)
+		})
+		return js, err
 	}
 }
 
@@ -216,9 +288,15 @@ func (m *TransactionManager) addInLock(tx transaction.Transaction, direct bool)
 	}
 
 	pool := m.getTxPool(tx.Group())
-	if err := pool.Add(tx, direct); err != nil {
+	drops, err := pool.addWithDrops(tx, direct)
+	if err != nil {
 		return err
 	}
+	m.notifyInLock(module.TransactionPoolEvent{
+		Type: module.TransactionAccepted,
+		Tx:   tx,
+	})
+	m.onTxDropsInLock(drops)
 	if m.callback != nil {
 		cb := m.callback
 		m.callback = nil
@@ -247,6 +325,15 @@ func (m *TransactionManager) FilterTransactions(g module.TransactionGroup, bloom
 	return pool.FilterTransactions(bloom, max)
 }
 
+func (m *TransactionManager) PendingTransactions(g module.TransactionGroup, from module.Address, skip, limit int) ([]module.PendingTransaction, int) {
+	return m.getTxPool(g).PendingTransactions(from, skip, limit)
+}
+
+func (m *TransactionManager) PoolStatus(g module.TransactionGroup) (int, int) {
+	pool := m.getTxPool(g)
+	return pool.Size(), pool.Used()
+}
+
 func (m *TransactionManager) Logger() log.Logger {
 	return m.log
 }
@@ -265,6 +352,7 @@ func NewTransactionManager(nid int, tsc *TxTimestampChecker, ptp *TransactionPoo
 		tim:          tim,
 		log:          logger,
 		txWaiters:    map[hashValue][]chan<- interface{}{},
+		subscribers:  map[*txSubscriber]struct{}{},
 	}
 	ptp.SetTxManager(txm)
 	ntp.SetTxManager(txm)
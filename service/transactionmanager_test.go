@@ -0,0 +1,153 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+func newTestTransactionManager(t *testing.T) *TransactionManager {
+	return newTestTransactionManagerWithPolicy(t, 10, TxPoolPolicy{})
+}
+
+func newTestTransactionManagerWithPolicy(t *testing.T, size int, policy TxPoolPolicy) *TransactionManager {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, err := NewTXIDManager(dbase, tsc)
+	if err != nil {
+		t.Fatalf("Fail to create TXIDManager err=%+v", err)
+	}
+	logger := log.New()
+	ptp := NewTransactionPool(module.TransactionGroupPatch, 10, TxPoolPolicy{}, tim, &mockMonitor{}, logger)
+	ntp := NewTransactionPool(module.TransactionGroupNormal, size, policy, tim, &mockMonitor{}, logger)
+	return NewTransactionManager(1, tsc, ptp, ntp, tim, logger)
+}
+
+func TestTransactionManager_Subscribe(t *testing.T) {
+	tm := newTestTransactionManager(t)
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	now := time.Now().UnixNano() / int64(time.Microsecond)
+
+	ch, cancel := tm.Subscribe(10)
+
+	tx1 := newMockTransaction([]byte("tx1"), addr, now)
+	if err := tm.Add(tx1, true, true); err != nil {
+		t.Fatalf("Fail to add transaction err=%+v", err)
+	}
+	ev := <-ch
+	if ev.Type != module.TransactionAccepted || ev.Tx != tx1 {
+		t.Errorf("Unexpected event type=%d tx=%#x", ev.Type, ev.Tx.ID())
+	}
+
+	reason := ExpiredTransactionError.New("Expired")
+	tm.OnTxDrops([]TxDrop{{tx1.ID(), reason, tx1}})
+	ev = <-ch
+	if ev.Type != module.TransactionDropped || ev.Tx != tx1 || ev.Error != reason {
+		t.Errorf("Unexpected event type=%d tx=%#x err=%v", ev.Type, ev.Tx.ID(), ev.Error)
+	}
+
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Errorf("Channel should be closed after cancel")
+	}
+	cancel()
+}
+
+type jsonMockTransaction struct {
+	*mockTransaction
+	encoded int
+}
+
+func (t *jsonMockTransaction) ToJSON(version module.JSONVersion) (interface{}, error) {
+	t.encoded++
+	return map[string]interface{}{"from": t.from}, nil
+}
+
+func TestTransactionManager_SubscribeJSON(t *testing.T) {
+	tm := newTestTransactionManager(t)
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	now := time.Now().UnixNano() / int64(time.Microsecond)
+
+	ch1, cancel1 := tm.Subscribe(10)
+	defer cancel1()
+	ch2, cancel2 := tm.Subscribe(10)
+	defer cancel2()
+
+	tx := &jsonMockTransaction{mockTransaction: newMockTransaction([]byte("tx1"), addr, now)}
+	if err := tm.Add(tx, true, true); err != nil {
+		t.Fatalf("Fail to add transaction err=%+v", err)
+	}
+	for _, ch := range []<-chan module.TransactionPoolEvent{ch1, ch2} {
+		ev := <-ch
+		if _, err := ev.JSON(); err != nil {
+			t.Errorf("Fail to get JSON err=%+v", err)
+		}
+	}
+	if tx.encoded != 1 {
+		t.Errorf("Transaction should be encoded once encoded=%d", tx.encoded)
+	}
+}
+
+func TestTransactionManager_SubscribeSlow(t *testing.T) {
+	tm := newTestTransactionManager(t)
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	now := time.Now().UnixNano() / int64(time.Microsecond)
+
+	ch, cancel := tm.Subscribe(1)
+	defer cancel()
+
+	for i, id := range []string{"tx1", "tx2"} {
+		tx := newMockTransaction([]byte(id), addr, now+int64(i))
+		if err := tm.Add(tx, true, true); err != nil {
+			t.Fatalf("Fail to add transaction err=%+v", err)
+		}
+	}
+	if _, ok := <-ch; !ok {
+		t.Fatalf("The first event should be delivered")
+	}
+	if _, ok := <-ch; ok {
+		t.Errorf("Channel should be closed for the slow subscriber")
+	}
+}
+
+func TestTransactionManager_SubscribeEviction(t *testing.T) {
+	tm := newTestTransactionManagerWithPolicy(t, 1, TxPoolPolicy{Eviction: TxPoolEvictionOldest})
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	now := time.Now().UnixNano() / int64(time.Microsecond)
+
+	ch, cancel := tm.Subscribe(10)
+	defer cancel()
+
+	tx1 := newMockTransaction([]byte("tx1"), addr, now)
+	tx2 := newMockTransaction([]byte("tx2"), addr, now+1)
+	for _, tx := range []*mockTransaction{tx1, tx2} {
+		if err := tm.Add(tx, true, true); err != nil {
+			t.Fatalf("Fail to add transaction err=%+v", err)
+		}
+	}
+
+	// the drop is notified in order without blocking the manager
+	expected := []struct {
+		tp module.TransactionPoolEventType
+		tx *mockTransaction
+	}{
+		{module.TransactionAccepted, tx1},
+		{module.TransactionAccepted, tx2},
+		{module.TransactionDropped, tx1},
+	}
+	for _, exp := range expected {
+		ev := <-ch
+		if ev.Type != exp.tp || ev.Tx != exp.tx {
+			t.Fatalf("Unexpected event type=%d tx=%#x", ev.Type, ev.Tx.ID())
+		}
+	}
+	select {
+	case ev := <-ch:
+		t.Errorf("Unexpected event type=%d tx=%#x", ev.Type, ev.Tx.ID())
+	default:
+	}
+}
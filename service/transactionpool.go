@@ -17,6 +17,7 @@
 package service
 
 import (
+	"math/big"
 	"sync"
 	"time"
 
@@ -34,6 +35,53 @@ const (
 	configDefaultMaxTxCount         = 1500
 )
 
+const (
+	TxPoolOrderFIFO    = "fifo"
+	TxPoolOrderFee     = "fee"
+	TxPoolOrderDefault = TxPoolOrderFIFO
+)
+
+const (
+	TxPoolEvictionNone          = "none"
+	TxPoolEvictionOldest        = "oldest"
+	TxPoolEvictionLowestFee     = "lowest_fee"
+	TxPoolEvictionLargestSender = "largest_sender"
+	TxPoolEvictionDefault       = TxPoolEvictionNone
+)
+
+func IsTxPoolOrder(s string) bool {
+	switch s {
+	case TxPoolOrderFIFO, TxPoolOrderFee:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsTxPoolEviction(s string) bool {
+	switch s {
+	case TxPoolEvictionNone, TxPoolEvictionOldest,
+		TxPoolEvictionLowestFee, TxPoolEvictionLargestSender:
+		return true
+	default:
+		return false
+	}
+}
+
+// TxPoolPolicy describes how the pool orders, limits and evicts transactions.
+type TxPoolPolicy struct {
+	// Order is the order of candidates (TxPoolOrderXXX).
+	Order string
+
+	// SenderLimit is the maximum number of pending transactions
+	// of a sender. Zero or negative value means no limit.
+	SenderLimit int
+
+	// Eviction is the policy to select the transaction to be dropped
+	// when the pool is full (TxPoolEvictionXXX).
+	Eviction string
+}
+
 type Monitor interface {
 	OnDropTx(n int, user bool)
 	OnAddTx(n int, user bool)
@@ -64,8 +112,9 @@ func (m dummyPoolCapacityMonitor) OnPoolCapacityUpdated(group module.Transaction
 type TransactionPool struct {
 	group module.TransactionGroup
 
-	size int
-	tim  TXIDManager
+	size   int
+	policy TxPoolPolicy
+	tim    TXIDManager
 
 	list *transactionList
 
@@ -77,10 +126,17 @@ type TransactionPool struct {
 	log     log.Logger
 }
 
-func NewTransactionPool(group module.TransactionGroup, size int, tim TXIDManager, m Monitor, log log.Logger) *TransactionPool {
+func NewTransactionPool(group module.TransactionGroup, size int, policy TxPoolPolicy, tim TXIDManager, m Monitor, log log.Logger) *TransactionPool {
+	if len(policy.Order) == 0 {
+		policy.Order = TxPoolOrderDefault
+	}
+	if len(policy.Eviction) == 0 {
+		policy.Eviction = TxPoolEvictionDefault
+	}
 	pool := &TransactionPool{
 		group:   group,
 		size:    size,
+		policy:  policy,
 		tim:     tim,
 		list:    newTransactionList(),
 		txm:     dummyTxWaiterManager{},
@@ -110,7 +166,7 @@ func (tp *TransactionPool) DropOldTXs(bts int64) {
 					"ExpiredTransaction(diff=%s)", TimestampToDuration(bts-tx.Timestamp()))
 			}
 			tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), iter.err)
-			drops = append(drops, TxDrop{tx.ID(), iter.err})
+			drops = append(drops, TxDrop{tx.ID(), iter.err, tx})
 			tp.monitor.OnDropTx(len(tx.Bytes()), direct)
 		}
 		iter = next
@@ -147,7 +203,8 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 	dropped := make([]*txElement, 0, configDefaultTxSliceCapacity)
 	poolSize := tp.list.Len()
 	txSize := int(0)
-	for e := tp.list.Front(); e != nil && txSize < maxBytes && len(txs) < maxCount; e = e.Next() {
+	iter := tp.iteratorFor(wc)
+	for e := iter.Next(); e != nil && txSize < maxBytes && len(txs) < maxCount; e = iter.Next() {
 		tx := e.Value()
 		if err := tsr.CheckTx(tx); err != nil {
 			if ExpiredTransactionError.Equals(err) {
@@ -195,6 +252,13 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 	return txs, txSize
 }
 
+func (tp *TransactionPool) iteratorFor(wc state.WorldContext) txIterator {
+	if tp.policy.Order == TxPoolOrderFee {
+		return tp.list.FeeIterator(wc.StepPrice())
+	}
+	return tp.list.Iterator()
+}
+
 func (tp *TransactionPool) CheckTxs(wc state.WorldContext) bool {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
@@ -216,24 +280,137 @@ func (tp *TransactionPool) CheckTxs(wc state.WorldContext) bool {
 /*
 	return nil if tx is nil or tx is added to pool
 	return ErrTransactionPoolOverFlow if pool is full
+	return UnderpricedTransactionError if it fails to replace the pending one
+	return SenderQuotaExceededError if the sender has too many transactions
 */
 func (tp *TransactionPool) Add(tx transaction.Transaction, direct bool) error {
 	if tx == nil {
 		return nil
 	}
+	lock := common.LockForAutoCall(&tp.mutex)
+	defer lock.Unlock()
+
+	drops, err := tp.addInLock(tx, direct)
+	if len(drops) > 0 {
+		lock.CallAfterUnlock(func() {
+			tp.txm.OnTxDrops(drops)
+		})
+	}
+	return err
+}
+
+// addWithDrops adds the transaction like Add, but it returns the
+// transactions dropped by the transaction instead of notifying them. It's
+// used by the caller notifying them in order with its own lock.
+func (tp *TransactionPool) addWithDrops(tx transaction.Transaction, direct bool) ([]TxDrop, error) {
+	if tx == nil {
+		return nil, nil
+	}
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
 
-	if tp.list.Len() >= tp.size {
-		return ErrTransactionPoolOverFlow
+	return tp.addInLock(tx, direct)
+}
+
+func (tp *TransactionPool) addInLock(tx transaction.Transaction, direct bool) ([]TxDrop, error) {
+	if tp.list.HasTx(tx.ID()) {
+		return nil, ErrDuplicateTransaction
 	}
 
-	err := tp.list.Add(tx, direct)
-	if err == nil {
-		tp.monitor.OnAddTx(len(tx.Bytes()), direct)
-		tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
+	var replaced *txElement
+	if tp.policy.Order == TxPoolOrderFee {
+		if replaced = tp.list.FindByNonce(tx); replaced != nil {
+			if err := checkReplacement(replaced.Value(), tx); err != nil {
+				return nil, err
+			}
+		}
 	}
-	return err
+
+	var evicted *txElement
+	if replaced == nil {
+		if limit := tp.policy.SenderLimit; limit > 0 {
+			if cnt := tp.list.CountOf(tx.From()); cnt >= limit {
+				return nil, SenderQuotaExceededError.Errorf(
+					"SenderQuotaExceeded(from=%s,pending=%d,limit=%d)",
+					tx.From(), cnt, limit)
+			}
+		}
+		if tp.list.Len() >= tp.size {
+			if evicted = tp.evictionFor(tx); evicted == nil {
+				return nil, ErrTransactionPoolOverFlow
+			}
+		}
+	}
+
+	if err := tp.list.Add(tx, direct); err != nil {
+		return nil, err
+	}
+	tp.monitor.OnAddTx(len(tx.Bytes()), direct)
+	var drops []TxDrop
+	if replaced != nil {
+		replaced.err = ReplacedTransactionError.Errorf(
+			"ReplacedTransaction(by=%#x)", tx.ID())
+		drops = tp.dropInLock(drops, replaced)
+	}
+	if evicted != nil {
+		evicted.err = EvictedTransactionError.Errorf(
+			"EvictedTransaction(policy=%s,by=%#x)",
+			tp.policy.Eviction, tx.ID())
+		drops = tp.dropInLock(drops, evicted)
+	}
+	tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
+	return drops, nil
+}
+
+// evictionFor returns the element to be dropped for tx when the pool is full.
+// It returns nil if tx can't take the place of any transaction.
+func (tp *TransactionPool) evictionFor(tx transaction.Transaction) *txElement {
+	switch tp.policy.Eviction {
+	case TxPoolEvictionOldest:
+		return tp.list.Front()
+	case TxPoolEvictionLowestFee:
+		e := tp.list.LowestStepLimit()
+		if e != nil && stepLimitOf(tx).Cmp(stepLimitOf(e.Value())) > 0 {
+			return e
+		}
+	case TxPoolEvictionLargestSender:
+		e := tp.list.LargestSender()
+		if e != nil && !e.Value().From().Equal(tx.From()) {
+			return e
+		}
+	}
+	return nil
+}
+
+func stepLimitOf(tx transaction.Transaction) *big.Int {
+	if limit := tx.StepLimit(); limit != nil {
+		return limit
+	}
+	return new(big.Int)
+}
+
+// checkReplacement checks whether tx may replace old pending transaction
+// with the same nonce. The new one should offer higher step limit.
+func checkReplacement(old, tx transaction.Transaction) error {
+	if stepLimitOf(tx).Cmp(stepLimitOf(old)) <= 0 {
+		return UnderpricedTransactionError.Errorf(
+			"UnderpricedTransaction(nonce=%s,stepLimit=%s,pending=%s)",
+			tx.Nonce(), stepLimitOf(tx), stepLimitOf(old))
+	}
+	return nil
+}
+
+// dropInLock removes the element replaced or evicted by another transaction.
+// It's called with the lock, so it appends the drop to drops to be notified
+// after unlock.
+func (tp *TransactionPool) dropInLock(drops []TxDrop, e *txElement) []TxDrop {
+	if !tp.list.Remove(e) {
+		return drops
+	}
+	tx := e.Value()
+	tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), e.err)
+	tp.monitor.OnDropTx(len(tx.Bytes()), e.ts != 0)
+	return append(drops, TxDrop{tx.ID(), e.err, tx})
 }
 
 // removeList remove transactions when transactions are finalized.
@@ -291,6 +468,42 @@ func (tp *TransactionPool) Used() int {
 	return tp.list.Len()
 }
 
+// PendingTransactions returns information of transactions in the pool.
+// If from is not nil, it returns transactions of the sender only.
+// It also returns the total number of matching transactions.
+func (tp *TransactionPool) PendingTransactions(from module.Address, skip, limit int) ([]module.PendingTransaction, int) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	next := func(e *txElement) *txElement {
+		return e.listNext
+	}
+	first := tp.list.Front()
+	if from != nil {
+		next = func(e *txElement) *txElement {
+			return e.srcNext
+		}
+		first = tp.list.FirstOf(from)
+	}
+
+	txs := make([]module.PendingTransaction, 0)
+	total := 0
+	for e := first; e != nil; e = next(e) {
+		if total >= skip && (limit <= 0 || len(txs) < limit) {
+			tx := e.Value()
+			txs = append(txs, module.PendingTransaction{
+				ID:       tx.ID(),
+				From:     tx.From(),
+				Nonce:    tx.Nonce(),
+				Size:     len(tx.Bytes()),
+				Received: e.received,
+			})
+		}
+		total += 1
+	}
+	return txs, total
+}
+
 func (tp *TransactionPool) SetTxManager(txm TxWaiterManager) {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
@@ -326,7 +539,7 @@ func (tp *TransactionPool) dropTransactions(txs []*txElement) {
 				tp.log.Panicf("No reason to drop the tx=<%#x>", tx.ID())
 			}
 			tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), e.err)
-			drops = append(drops, TxDrop{tx.ID(), e.err})
+			drops = append(drops, TxDrop{tx.ID(), e.err, tx})
 			tp.monitor.OnDropTx(len(tx.Bytes()), direct)
 		}
 	}
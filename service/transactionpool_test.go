@@ -1,6 +1,7 @@
 package service
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
@@ -33,7 +34,7 @@ func TestTransactionPool_Add(t *testing.T) {
 	dbase := db.NewMapDB()
 	tsc := NewTimestampChecker()
 	tim, _ := NewTXIDManager(dbase, tsc)
-	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, TxPoolPolicy{}, tim, &mockMonitor{}, log.New())
 
 	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
 	tx1 := newMockTransaction([]byte("tx1"), addr, 1)
@@ -43,3 +44,189 @@ func TestTransactionPool_Add(t *testing.T) {
 		t.Error("Fail to add transaction with valid network ID")
 	}
 }
+
+func TestTransactionPool_Replace(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, TxPoolPolicy{Order: TxPoolOrderFee}, tim, &mockMonitor{}, log.New())
+
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	tx1 := newMockTransaction([]byte("tx1"), addr, 1)
+	tx1.nonce = big.NewInt(1)
+	tx1.stepLimit = big.NewInt(1000)
+	if err := pool.Add(tx1, true); err != nil {
+		t.Fatalf("Fail to add transaction err=%+v", err)
+	}
+
+	tx2 := newMockTransaction([]byte("tx2"), addr, 2)
+	tx2.nonce = big.NewInt(1)
+	tx2.stepLimit = big.NewInt(1000)
+	if err := pool.Add(tx2, true); !UnderpricedTransactionError.Equals(err) {
+		t.Errorf("It should fail to replace with same step limit err=%+v", err)
+	}
+
+	tx2.stepLimit = big.NewInt(2000)
+	if err := pool.Add(tx2, true); err != nil {
+		t.Errorf("Fail to replace transaction err=%+v", err)
+	}
+	if pool.HasTx(tx1.ID()) || !pool.HasTx(tx2.ID()) {
+		t.Errorf("tx1 should be replaced with tx2")
+	}
+	if used := pool.Used(); used != 1 {
+		t.Errorf("Used should be 1 but used=%d", used)
+	}
+}
+
+func TestTransactionPool_SenderLimit(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, TxPoolPolicy{SenderLimit: 2}, tim, &mockMonitor{}, log.New())
+
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	if err := pool.Add(newMockTransaction([]byte("tx1"), addr1, 1), true); err != nil {
+		t.Fatalf("Fail to add transaction err=%+v", err)
+	}
+	if err := pool.Add(newMockTransaction([]byte("tx2"), addr1, 2), true); err != nil {
+		t.Fatalf("Fail to add transaction err=%+v", err)
+	}
+	err := pool.Add(newMockTransaction([]byte("tx3"), addr1, 3), true)
+	if !SenderQuotaExceededError.Equals(err) {
+		t.Errorf("It should fail with SenderQuotaExceeded err=%+v", err)
+	}
+	if err := pool.Add(newMockTransaction([]byte("tx4"), addr2, 4), true); err != nil {
+		t.Errorf("Fail to add transaction of another sender err=%+v", err)
+	}
+}
+
+type dropRecorder struct {
+	drops chan []TxDrop
+}
+
+func (r *dropRecorder) OnTxDrops(drops []TxDrop) {
+	r.drops <- drops
+}
+
+func TestTransactionPool_Eviction(t *testing.T) {
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	newTx := func(id string, from module.Address, ts int64, limit int64) *mockTransaction {
+		tx := newMockTransaction([]byte(id), from, ts)
+		tx.stepLimit = big.NewInt(limit)
+		return tx
+	}
+
+	cases := []struct {
+		eviction string
+		txs      []*mockTransaction
+		tx       *mockTransaction
+		evicted  string
+	}{
+		{
+			TxPoolEvictionNone,
+			[]*mockTransaction{newTx("tx1", addr1, 1, 100), newTx("tx2", addr2, 2, 100)},
+			newTx("tx3", addr2, 3, 100),
+			"",
+		},
+		{
+			TxPoolEvictionOldest,
+			[]*mockTransaction{newTx("tx1", addr1, 1, 100), newTx("tx2", addr2, 2, 100)},
+			newTx("tx3", addr2, 3, 100),
+			"tx1",
+		},
+		{
+			TxPoolEvictionLowestFee,
+			[]*mockTransaction{newTx("tx1", addr1, 1, 200), newTx("tx2", addr2, 2, 100)},
+			newTx("tx3", addr1, 3, 300),
+			"tx2",
+		},
+		{
+			TxPoolEvictionLowestFee,
+			[]*mockTransaction{newTx("tx1", addr1, 1, 200), newTx("tx2", addr2, 2, 100)},
+			newTx("tx3", addr1, 3, 100),
+			"",
+		},
+		{
+			TxPoolEvictionLargestSender,
+			[]*mockTransaction{newTx("tx1", addr1, 1, 100), newTx("tx2", addr1, 2, 100)},
+			newTx("tx3", addr2, 3, 100),
+			"tx2",
+		},
+		{
+			TxPoolEvictionLargestSender,
+			[]*mockTransaction{newTx("tx1", addr1, 1, 100), newTx("tx2", addr1, 2, 100)},
+			newTx("tx3", addr1, 3, 100),
+			"",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.eviction, func(t *testing.T) {
+			dbase := db.NewMapDB()
+			tsc := NewTimestampChecker()
+			tim, _ := NewTXIDManager(dbase, tsc)
+			pool := NewTransactionPool(module.TransactionGroupNormal, len(c.txs), TxPoolPolicy{Eviction: c.eviction}, tim, &mockMonitor{}, log.New())
+			recorder := &dropRecorder{drops: make(chan []TxDrop, 1)}
+			pool.SetTxManager(recorder)
+			for _, tx := range c.txs {
+				if err := pool.Add(tx, true); err != nil {
+					t.Fatalf("Fail to add transaction err=%+v", err)
+				}
+			}
+
+			err := pool.Add(c.tx, true)
+			if c.evicted == "" {
+				if err != ErrTransactionPoolOverFlow {
+					t.Errorf("It should fail with overflow err=%+v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Fail to add transaction err=%+v", err)
+			}
+			drops := <-recorder.drops
+			if len(drops) != 1 || string(drops[0].ID) != c.evicted {
+				t.Errorf("It should drop %s drops=%+v", c.evicted, drops)
+			} else if !EvictedTransactionError.Equals(drops[0].Err) {
+				t.Errorf("It should drop with EvictedTransaction err=%+v", drops[0].Err)
+			}
+			if used := pool.Used(); used != len(c.txs) {
+				t.Errorf("Used should be %d but used=%d", len(c.txs), used)
+			}
+		})
+	}
+}
+
+func TestTransactionPool_PendingTransactions(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, TxPoolPolicy{}, tim, &mockMonitor{}, log.New())
+
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	pool.Add(newMockTransaction([]byte("tx1"), addr1, 1), true)
+	pool.Add(newMockTransaction([]byte("tx2"), addr2, 2), true)
+	pool.Add(newMockTransaction([]byte("tx3"), addr1, 3), false)
+	pool.Add(newMockTransaction([]byte("tx4"), addr1, 4), true)
+
+	txs, total := pool.PendingTransactions(nil, 1, 2)
+	if total != 4 || len(txs) != 2 {
+		t.Fatalf("It should return 2 of 4 but len=%d total=%d", len(txs), total)
+	}
+	if string(txs[0].ID) != "tx2" || string(txs[1].ID) != "tx3" {
+		t.Errorf("Unexpected transactions txs=%+v", txs)
+	}
+
+	txs, total = pool.PendingTransactions(addr1, 1, 0)
+	if total != 3 || len(txs) != 2 {
+		t.Fatalf("It should return 2 of 3 but len=%d total=%d", len(txs), total)
+	}
+	if string(txs[0].ID) != "tx3" || string(txs[1].ID) != "tx4" {
+		t.Errorf("Unexpected transactions txs=%+v", txs)
+	}
+	if !txs[0].From.Equal(addr1) || txs[0].Size != 3 || txs[0].Received.IsZero() {
+		t.Errorf("Unexpected transaction information tx=%+v", txs[0])
+	}
+}
@@ -21,8 +21,10 @@ import (
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/merkle"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/txresult"
 )
 
 type transitionResult struct {
@@ -66,3 +68,52 @@ func (tr *transitionResult) Bytes() []byte {
 func NewWorldSnapshot(database db.Database, plt base.Platform, result []byte, vl module.ValidatorList) (state.WorldSnapshot, error) {
 	return newWorldSnapshot(database, plt, result, vl)
 }
+
+// CheckStateAvailable returns StateNotAvailableError if the world state of
+// the result isn't stored in the database. It happens for the old results
+// after pruning, or on the node not keeping old states.
+func CheckStateAvailable(database db.Database, result []byte) error {
+	tr, err := newTransitionResultFromBytes(result)
+	if err != nil {
+		return err
+	}
+	if len(tr.StateHash) == 0 {
+		return nil
+	}
+	bk, err := database.GetBucket(db.MerkleTrie)
+	if err != nil {
+		return err
+	}
+	if has, err := bk.Has(tr.StateHash); err != nil {
+		return err
+	} else if !has {
+		return StateNotAvailableError.Errorf("StateNotAvailable(hash=%#x)", tr.StateHash)
+	}
+	return nil
+}
+
+// RequestReceipts requests receipts of the result to the builder. Unlike
+// ExportResult of the manager, it doesn't request the world state, so that
+// receipts of old results can be handled without their states.
+func RequestReceipts(builder merkle.Builder, result []byte) error {
+	tr, err := newTransitionResultFromBytes(result)
+	if err != nil {
+		return err
+	}
+	txresult.NewReceiptListWithBuilder(builder, tr.NormalReceiptHash)
+	txresult.NewReceiptListWithBuilder(builder, tr.PatchReceiptHash)
+	return nil
+}
+
+// ReceiptListFromResult returns receipts of the group in the result without
+// caching the result.
+func ReceiptListFromResult(database db.Database, result []byte, g module.TransactionGroup) (module.ReceiptList, error) {
+	tr, err := newTransitionResultFromBytes(result)
+	if err != nil {
+		return nil, err
+	}
+	if g == module.TransactionGroupNormal {
+		return txresult.NewReceiptListFromHash(database, tr.NormalReceiptHash), nil
+	}
+	return txresult.NewReceiptListFromHash(database, tr.PatchReceiptHash), nil
+}
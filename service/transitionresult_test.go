@@ -18,10 +18,15 @@ package service
 
 import (
 	"encoding/hex"
+	"math/big"
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/service/state"
 )
 
 func Test_newTransitionResultFromBytes(t *testing.T) {
@@ -56,3 +61,20 @@ func Test_newTransitionResultFromBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckStateAvailable(t *testing.T) {
+	dbase := db.NewMapDB()
+	ws := state.NewWorldState(dbase, nil, nil, nil)
+	ws.GetAccountState([]byte("\x00test")).SetBalance(big.NewInt(10))
+	wss := ws.GetSnapshot()
+	assert.NoError(t, wss.Flush())
+
+	tr := &transitionResult{StateHash: wss.StateHash()}
+	assert.NoError(t, CheckStateAvailable(dbase, tr.Bytes()))
+	assert.NoError(t, CheckStateAvailable(dbase, nil))
+
+	s1, _ := hex.DecodeString("6a41c16fb4827945748042f252c39805fb916e3e47f157b3620cfc8ce0c3093d")
+	tr = &transitionResult{StateHash: s1}
+	err := CheckStateAvailable(dbase, tr.Bytes())
+	assert.True(t, StateNotAvailableError.Equals(err))
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package txresult
+
+import (
+	"bytes"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+// EventFilter selects event logs with the signature, addresses of the
+// SCOREs and values of the arguments.
+type EventFilter struct {
+	addrs     []module.Address
+	signature []byte
+	indexed   [][]byte
+	data      [][]byte
+	numOfArgs int
+	lb        *LogsBloom
+}
+
+// NewEventFilter returns a filter for the event of the signature.
+// Empty addrs matches any SCORE. A nil element of indexed and data
+// matches any value.
+func NewEventFilter(addrs []module.Address, sig string, indexed, data []*string) (*EventFilter, error) {
+	f := &EventFilter{
+		addrs:     addrs,
+		signature: []byte(sig),
+		numOfArgs: len(indexed) + len(data),
+	}
+	name, pts := DecomposeEventSignature(sig)
+	if len(name) == 0 || pts == nil || len(pts) < f.numOfArgs {
+		return nil, errors.IllegalArgumentError.New("bad event signature")
+	}
+	lb := NewLogsBloom(nil)
+	if len(addrs) == 1 {
+		lb.AddAddressOfLog(addrs[0])
+	}
+	lb.AddIndexedOfLog(0, f.signature)
+	idx := 0
+	f.indexed = make([][]byte, len(indexed))
+	for i, arg := range indexed {
+		if arg != nil {
+			bs, err := EventDataStringToBytesByType(pts[idx], *arg)
+			if err != nil {
+				return nil, errors.IllegalArgumentError.New("bad event data")
+			}
+			lb.AddIndexedOfLog(i+1, bs)
+			f.indexed[i] = bs
+		}
+		idx++
+	}
+	f.data = make([][]byte, len(data))
+	for i, arg := range data {
+		if arg != nil {
+			bs, err := EventDataStringToBytesByType(pts[idx], *arg)
+			if err != nil {
+				return nil, errors.IllegalArgumentError.New("bad event data")
+			}
+			f.data[i] = bs
+		}
+		idx++
+	}
+	f.lb = lb
+	return f, nil
+}
+
+// LogsBloom returns the bloom to be contained by the bloom of the block or
+// the receipt having matching logs.
+func (f *EventFilter) LogsBloom() module.LogsBloom {
+	return f.lb
+}
+
+// bytesEqual check equality of byte slice.
+// But it doesn't assume nil as empty bytes.
+func bytesEqual(b1 []byte, b2 []byte) bool {
+	if b1 == nil && b2 == nil {
+		return true
+	}
+	if b1 == nil || b2 == nil {
+		return false
+	}
+	return bytes.Equal(b1, b2)
+}
+
+func (f *EventFilter) matchAddress(addr module.Address) bool {
+	if len(f.addrs) == 0 {
+		return true
+	}
+	for _, a := range f.addrs {
+		if addr.Equal(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns whether the event log is selected by the filter.
+// Logs having less indexed values or data than the filter don't match.
+func (f *EventFilter) Match(el module.EventLog) bool {
+	indexed, data := el.Indexed(), el.Data()
+	if len(indexed) == 0 || !bytes.Equal(f.signature, indexed[0]) {
+		return false
+	}
+	if !f.matchAddress(el.Address()) {
+		return false
+	}
+	if f.numOfArgs > 0 {
+		if (len(indexed) + len(data)) <= f.numOfArgs {
+			return false
+		}
+		for i, arg := range f.indexed {
+			if arg == nil {
+				continue
+			}
+			if i+1 >= len(indexed) || !bytesEqual(arg, indexed[i+1]) {
+				return false
+			}
+		}
+		for i, arg := range f.data {
+			if arg == nil {
+				continue
+			}
+			if i >= len(data) || !bytesEqual(arg, data[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ForEachMatch calls v for each event log of the receipt selected by
+// the filter with the index of the log in the receipt.
+func (f *EventFilter) ForEachMatch(r module.Receipt, v func(idx int, el module.EventLog)) error {
+	if !r.LogsBloom().Contain(f.lb) {
+		return nil
+	}
+	for it, idx := r.EventLogIterator(), 0; it.Has(); _, idx = it.Next(), idx+1 {
+		el, err := it.Get()
+		if err != nil {
+			return err
+		}
+		if f.Match(el) {
+			v(idx, el)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package txresult
+
+import (
+	"testing"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestEventFilter_Match(t *testing.T) {
+	score1 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	score2 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+	score3 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000003")
+	user := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	sig := "Transfer(Address,int)"
+
+	newLog := func(addr *common.Address, to *common.Address, value int64) module.EventLog {
+		el := new(eventLog)
+		el.Addr.Set(addr)
+		el.eventLogData.Indexed = [][]byte{[]byte(sig), to.Bytes()}
+		el.eventLogData.Data = [][]byte{common.NewHexInt(value).Bytes()}
+		return el
+	}
+	// logs of the same signature but with the arguments placed differently
+	newShortLog := func(addr *common.Address, to *common.Address, value int64, indexed int) module.EventLog {
+		el := new(eventLog)
+		el.Addr.Set(addr)
+		args := [][]byte{[]byte(sig), to.Bytes(), common.NewHexInt(value).Bytes()}
+		el.eventLogData.Indexed = args[:indexed]
+		el.eventLogData.Data = args[indexed:]
+		return el
+	}
+	emptyLog := new(eventLog)
+	emptyLog.Addr.Set(score1)
+	str := func(s string) *string {
+		return &s
+	}
+
+	cases := []struct {
+		name    string
+		addrs   []module.Address
+		indexed []*string
+		data    []*string
+		log     module.EventLog
+		match   bool
+	}{
+		{"Any", nil, nil, nil, newLog(score1, user, 1), true},
+		{"OneOfAddrs", []module.Address{score1, score2}, nil, nil, newLog(score2, user, 1), true},
+		{"OtherAddr", []module.Address{score1, score2}, nil, nil, newLog(score3, user, 1), false},
+		{"Indexed", nil, []*string{str(user.String())}, nil, newLog(score1, user, 1), true},
+		{"OtherIndexed", nil, []*string{str(score1.String())}, nil, newLog(score1, user, 1), false},
+		{"Data", nil, []*string{nil}, []*string{str("0x2")}, newLog(score1, user, 2), true},
+		{"OtherData", nil, []*string{nil}, []*string{str("0x2")}, newLog(score1, user, 1), false},
+		{"ShortIndexed", nil, []*string{str(user.String())}, []*string{nil}, newShortLog(score1, user, 1, 1), false},
+		{"ShortData", nil, []*string{nil}, []*string{str("0x1")}, newShortLog(score1, user, 1, 3), false},
+		{"NoIndexed", nil, nil, nil, emptyLog, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, err := NewEventFilter(c.addrs, sig, c.indexed, c.data)
+			if err != nil {
+				t.Fatalf("Fail to make filter err=%+v", err)
+			}
+			if match := f.Match(c.log); match != c.match {
+				t.Errorf("Match()=%v expected=%v", match, c.match)
+			}
+		})
+	}
+
+	if _, err := NewEventFilter(nil, "Transfer", nil, nil); err == nil {
+		t.Errorf("It should fail with bad signature")
+	}
+	if _, err := NewEventFilter(nil, sig, []*string{nil, nil, nil}, nil); err == nil {
+		t.Errorf("It should fail with too many arguments")
+	}
+}
@@ -73,6 +73,15 @@ func (log *eventLog) ClearCache() {
 	return
 }
 
+// NewEventLog returns the event log with the values.
+func NewEventLog(addr module.Address, indexed, data [][]byte) module.EventLog {
+	log := new(eventLog)
+	log.eventLogData.Addr.Set(addr)
+	log.eventLogData.Indexed = indexed
+	log.eventLogData.Data = data
+	return log
+}
+
 func (log *eventLog) Address() module.Address {
 	return &log.eventLogData.Addr
 }
@@ -105,10 +105,30 @@ func (c *Chain) NephewsLimit() int {
 	panic("implement me")
 }
 
+func (c *Chain) TxPoolOrder() string {
+	panic("implement me")
+}
+
+func (c *Chain) TxPoolSenderLimit() int {
+	panic("implement me")
+}
+
+func (c *Chain) TxPoolEviction() string {
+	panic("implement me")
+}
+
 func (c *Chain) ValidateTxOnSend() bool {
 	panic("implement me")
 }
 
+func (c *Chain) EventIndex() bool {
+	return false
+}
+
+func (c *Chain) Archive() bool {
+	return false
+}
+
 var defaultGenesis = "{\n  \"accounts\": [\n    {\n      \"name\": \"god\",\n      \"address\": \"hx54f7853dc6481b670caf69c5a27c7c8fe5be8269\",\n      \"balance\": \"0x2961fff8ca4a62327800000\"\n    },\n    {\n      \"name\": \"treasury\",\n      \"address\": \"hx1000000000000000000000000000000000000000\",\n      \"balance\": \"0x0\"\n    }\n  ],\n  \"message\": \"A rhizome has no beginning or end; it is always in the middle, between things, interbeing, intermezzo. The tree is filiation, but the rhizome is alliance, uniquely alliance. The tree imposes the verb \\\"to be\\\" but the fabric of the rhizome is the conjunction, \\\"and ... and ...and...\\\"This conjunction carries enough force to shake and uproot the verb \\\"to be.\\\" Where are you going? Where are you coming from? What are you heading for? These are totally useless questions.\\n\\n - Mille Plateaux, Gilles Deleuze & Felix Guattari\\n\\n\\\"Hyperconnect the world\\\"\"\n}\n"
 
 func (c *Chain) Genesis() []byte {
@@ -195,7 +215,7 @@ func (c *Chain) Reset(gs string, height int64, blockHash []byte) error {
 	panic("implement me")
 }
 
-func (c *Chain) Verify() error {
+func (c *Chain) Verify(height int64, execute bool) error {
 	panic("implement me")
 }
 
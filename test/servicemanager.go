@@ -20,6 +20,7 @@ import (
 	"github.com/icon-project/goloop/chain/base"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/merkle"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service"
 	"github.com/icon-project/goloop/service/contract"
@@ -204,7 +205,7 @@ func (sm *ServiceManager) TransactionListFromHash(hash []byte) module.Transactio
 }
 
 func (sm *ServiceManager) ReceiptListFromResult(result []byte, g module.TransactionGroup) (module.ReceiptList, error) {
-	panic("implement me")
+	return service.ReceiptListFromResult(sm.dbase, result, g)
 }
 
 func (sm *ServiceManager) SendTransaction(result []byte, height int64, tx interface{}) ([]byte, error) {
@@ -244,5 +245,18 @@ func (sm *ServiceManager) WaitTransactionResult(id []byte) (<-chan interface{},
 }
 
 func (sm *ServiceManager) ExportResult(result []byte, vh []byte, dst db.Database) error {
-	panic("implement me")
+	ws, err := service.NewWorldSnapshot(sm.dbase, sm.plt, result, nil)
+	if err != nil {
+		return err
+	}
+	e := merkle.NewCopyContext(sm.dbase, dst)
+	if err := service.RequestReceipts(e.Builder(), result); err != nil {
+		return err
+	}
+	ess := sm.plt.NewExtensionWithBuilder(e.Builder(), ws.ExtensionData())
+	_, err = state.NewWorldSnapshotWithBuilder(e.Builder(), ws.StateHash(), vh, ess)
+	if err != nil {
+		return err
+	}
+	return e.Run()
 }
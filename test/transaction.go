@@ -220,6 +220,10 @@ func (t *Transaction) Nonce() *big.Int {
 	return nil
 }
 
+func (t *Transaction) StepLimit() *big.Int {
+	return nil
+}
+
 func (t *Transaction) To() module.Address {
 	return state.SystemAddress
 }